@@ -0,0 +1,264 @@
+package classgroup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var ErrInvalidDiscriminantSize = errors.New("the discriminant must be of at least 1024 bits")
+
+// discriminantDST is the domain separation tag of the hash to discriminant.
+var discriminantDST = []byte("CLASS_GROUP_DISCRIMINANT_V1_")
+
+// Form is the binary quadratic form ax² + bxy + cy².
+type Form struct {
+	A, B, C *big.Int
+}
+
+// Group is the class group of discriminant D, with the generator G.
+type Group struct {
+	D *big.Int
+	G *Form
+}
+
+// New returns the class group of the discriminant -p, for p the first prime
+// of the given size with p ≡ 7 mod 8 among the hashes of the seed with a
+// counter, and the generator (2, 1, (1 - D)/8).
+func New(seed []byte, bits int) (*Group, error) {
+	if bits < 1024 {
+		return nil, ErrInvalidDiscriminantSize
+	}
+	d := hashToDiscriminant(seed, bits)
+
+	// c = (1 - D)/8
+	c := new(big.Int).Sub(big.NewInt(1), d)
+	c.Rsh(c, 3)
+	return &Group{
+		D: d,
+		G: &Form{A: big.NewInt(2), B: big.NewInt(1), C: c},
+	}, nil
+}
+
+// hashToDiscriminant returns -p, for p the first prime of the given size with
+// p ≡ 7 mod 8 among the expansions of the seed with a counter: the
+// concatenation of SHA-256(dst ‖ counter ‖ i ‖ seed) for i = 0, 1, …
+func hashToDiscriminant(seed []byte, bits int) *big.Int {
+	h := sha256.New()
+	var buf [8]byte
+	nbBytes := (bits + 7) / 8
+	res := new(big.Int)
+	for counter := uint64(0); ; counter++ {
+		expanded := make([]byte, 0, nbBytes+sha256.Size)
+		for i := uint64(0); len(expanded) < nbBytes; i++ {
+			h.Reset()
+			h.Write(discriminantDST)
+			binary.BigEndian.PutUint64(buf[:], counter)
+			h.Write(buf[:])
+			binary.BigEndian.PutUint64(buf[:], i)
+			h.Write(buf[:])
+			h.Write(seed)
+			expanded = h.Sum(expanded)
+		}
+		res.SetBytes(expanded[:nbBytes])
+
+		// keep the bits low bits, set the most significant one and p ≡ 7 mod 8
+		res.SetBit(res, bits-1, 1)
+		for i := res.BitLen() - 1; i >= bits; i-- {
+			res.SetBit(res, i, 0)
+		}
+		res.SetBit(res, 0, 1).SetBit(res, 1, 1).SetBit(res, 2, 1)
+		if res.ProbablyPrime(20) {
+			return res.Neg(res)
+		}
+	}
+}
+
+// Generator returns G.
+func (g *Group) Generator() *Form {
+	return g.G
+}
+
+// Identity returns the principal form (1, 1, (1 - D)/4).
+func (g *Group) Identity() *Form {
+	c := new(big.Int).Sub(big.NewInt(1), g.D)
+	c.Rsh(c, 2)
+	return &Form{A: big.NewInt(1), B: big.NewInt(1), C: c}
+}
+
+// Inverse returns the reduced form of (a, -b, c).
+func (g *Group) Inverse(x *Form) *Form {
+	return g.reduce(new(big.Int).Set(x.A), new(big.Int).Neg(x.B), new(big.Int).Set(x.C))
+}
+
+// Mul returns the reduced form of the composition of x and y (Cohen,
+// algorithm 5.4.7).
+func (g *Group) Mul(x, y *Form) *Form {
+	if x.A.Cmp(y.A) > 0 {
+		x, y = y, x
+	}
+	a1, b1 := x.A, x.B
+	a2, b2, c2 := y.A, y.B, y.C
+
+	// s = (b₁ + b₂)/2, n = b₂ - s
+	s := new(big.Int).Add(b1, b2)
+	s.Rsh(s, 1)
+	n := new(big.Int).Sub(b2, s)
+
+	// d = gcd(a₁, a₂) = u⋅a₂ + v⋅a₁, y₁ = u
+	y1, d := new(big.Int), new(big.Int)
+	if new(big.Int).Mod(a2, a1).Sign() == 0 {
+		d.Set(a1)
+	} else {
+		d.GCD(y1, nil, a2, a1)
+	}
+
+	// d₁ = gcd(s, d) = x₂⋅s + y₂⋅d, y₂ = -y₂
+	x2, y2, d1 := new(big.Int), new(big.Int), new(big.Int)
+	if new(big.Int).Mod(s, d).Sign() == 0 {
+		y2.SetInt64(-1)
+		d1.Set(d)
+	} else {
+		d1.GCD(x2, y2, s, d)
+		y2.Neg(y2)
+	}
+
+	// v₁ = a₁/d₁, v₂ = a₂/d₁, r = y₁⋅y₂⋅n - x₂⋅c₂ mod v₁
+	v1 := new(big.Int).Quo(a1, d1)
+	v2 := new(big.Int).Quo(a2, d1)
+	r := new(big.Int).Mul(y1, y2)
+	r.Mul(r, n)
+	r.Sub(r, x2.Mul(x2, c2))
+	r.Mod(r, v1)
+
+	// (a₃, b₃) = (v₁⋅v₂, b₂ + 2⋅v₂⋅r)
+	a3 := v1.Mul(v1, v2)
+	b3 := r.Mul(r, v2)
+	b3.Lsh(b3, 1).Add(b3, b2)
+	return g.reduce(a3, b3, nil)
+}
+
+// Exp returns xᵉ, where e may be negative.
+func (g *Group) Exp(x *Form, e *big.Int) *Form {
+	if e.Sign() < 0 {
+		x = g.Inverse(x)
+		e = new(big.Int).Neg(e)
+	}
+	res := g.Identity()
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		res = g.Mul(res, res)
+		if e.Bit(i) == 1 {
+			res = g.Mul(res, x)
+		}
+	}
+	return res
+}
+
+// Equal returns true if x = y, both being reduced.
+func (g *Group) Equal(x, y *Form) bool {
+	return x.A.Cmp(y.A) == 0 && x.B.Cmp(y.B) == 0 && x.C.Cmp(y.C) == 0
+}
+
+// IsElement returns true if x is a reduced form of discriminant D: |b| ≤ a ≤ c,
+// and b ≥ 0 if |b| = a or a = c.
+func (g *Group) IsElement(x *Form) bool {
+	if x == nil || x.A == nil || x.B == nil || x.C == nil || x.A.Sign() <= 0 {
+		return false
+	}
+	if g.discriminant(x.A, x.B, x.C).Cmp(g.D) != 0 {
+		return false
+	}
+	absB := new(big.Int).Abs(x.B)
+	switch absB.Cmp(x.A) {
+	case 1:
+		return false
+	case 0:
+		if x.B.Sign() < 0 {
+			return false
+		}
+	}
+	switch x.A.Cmp(x.C) {
+	case 1:
+		return false
+	case 0:
+		return x.B.Sign() >= 0
+	}
+	return true
+}
+
+// Bytes returns the encoding of -D followed by the encoding of G.
+func (g *Group) Bytes() []byte {
+	d := new(big.Int).Neg(g.D).Bytes()
+	return append(d, g.ElementBytes(g.G)...)
+}
+
+// ElementBytes returns the encoding of a reduced form: a, the sign of b and
+// |b|, a and |b| being on the size of ⌊√(|D|/3)⌋.
+func (g *Group) ElementBytes(x *Form) []byte {
+	size := (g.D.BitLen()+1)/2/8 + 1
+	res := make([]byte, 2*size+1)
+	x.A.FillBytes(res[:size])
+	if x.B.Sign() < 0 {
+		res[size] = 1
+	}
+	new(big.Int).Abs(x.B).FillBytes(res[size+1:])
+	return res
+}
+
+// reduce returns the reduced form equivalent to (a, b, c) (Cohen, algorithm
+// 5.4.2), modifying a and b. If c is nil, it is computed from the
+// discriminant.
+func (g *Group) reduce(a, b, c *big.Int) *Form {
+	if c == nil {
+		c = g.c(a, b)
+	} else {
+		c = new(big.Int).Set(c)
+	}
+	for {
+		if !isNormal(a, b) {
+			normalize(a, b)
+			c = g.c(a, b)
+		}
+		if a.Cmp(c) <= 0 {
+			break
+		}
+		// (a, b, c) ~ (c, -b, a)
+		a, c = c, a
+		b.Neg(b)
+	}
+	if a.Cmp(c) == 0 && b.Sign() < 0 {
+		b.Neg(b)
+	}
+	return &Form{A: a, B: b, C: c}
+}
+
+// isNormal returns true if -a < b ≤ a.
+func isNormal(a, b *big.Int) bool {
+	if b.Cmp(a) > 0 {
+		return false
+	}
+	return new(big.Int).Neg(a).Cmp(b) < 0
+}
+
+// normalize sets b to b + 2ra in (-a, a], for r = ⌊(a - b)/2a⌋.
+func normalize(a, b *big.Int) {
+	twoA := new(big.Int).Lsh(a, 1)
+	r := new(big.Int).Sub(a, b)
+	r.Div(r, twoA)
+	b.Add(b, r.Mul(r, twoA))
+}
+
+// c returns (b² - D)/4a.
+func (g *Group) c(a, b *big.Int) *big.Int {
+	c := new(big.Int).Mul(b, b)
+	c.Sub(c, g.D)
+	return c.Quo(c, new(big.Int).Lsh(a, 2))
+}
+
+// discriminant returns b² - 4ac.
+func (g *Group) discriminant(a, b, c *big.Int) *big.Int {
+	d := new(big.Int).Mul(b, b)
+	ac := new(big.Int).Mul(a, c)
+	return d.Sub(d, ac.Lsh(ac, 2))
+}
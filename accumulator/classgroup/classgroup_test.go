@@ -0,0 +1,109 @@
+package classgroup
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmallClassGroups(t *testing.T) {
+	assert := require.New(t)
+
+	// the class numbers of the discriminants -23, -47 and -71 are 3, 5 and 7,
+	// and the classes of (2, 1, (1 - D)/8) generate the groups
+	for _, tc := range []struct {
+		d, h int64
+	}{{-23, 3}, {-47, 5}, {-71, 7}} {
+		g := &Group{
+			D: big.NewInt(tc.d),
+			G: &Form{A: big.NewInt(2), B: big.NewInt(1), C: big.NewInt((1 - tc.d) / 8)},
+		}
+		assert.True(g.IsElement(g.G))
+		x := g.Identity()
+		for i := int64(1); i < tc.h; i++ {
+			x = g.Mul(x, g.G)
+			assert.True(g.IsElement(x))
+			assert.False(g.Equal(x, g.Identity()))
+		}
+		assert.True(g.Equal(g.Mul(x, g.G), g.Identity()))
+		assert.True(g.Equal(g.Exp(g.G, big.NewInt(tc.h)), g.Identity()))
+		assert.True(g.Equal(g.Exp(g.G, big.NewInt(-1)), x))
+	}
+}
+
+func TestClassGroup(t *testing.T) {
+	assert := require.New(t)
+
+	g, err := New([]byte("seed"), 1024)
+	assert.NoError(err)
+	assert.Equal(1024, g.D.BitLen())
+	assert.True(new(big.Int).Neg(g.D).ProbablyPrime(20))
+	assert.True(g.IsElement(g.G))
+	assert.True(g.IsElement(g.Identity()))
+
+	// the discriminant is a function of the seed
+	g2, err := New([]byte("seed"), 1024)
+	assert.NoError(err)
+	assert.Equal(g.D, g2.D)
+	g2, err = New([]byte("seed "), 1024)
+	assert.NoError(err)
+	assert.NotEqual(g.D, g2.D)
+
+	_, err = New([]byte("seed"), 512)
+	assert.ErrorIs(err, ErrInvalidDiscriminantSize)
+
+	random := func() *big.Int {
+		r, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 512))
+		assert.NoError(err)
+		return r
+	}
+	a, b, c := random(), random(), random()
+	x, y, z := g.Exp(g.G, a), g.Exp(g.G, b), g.Exp(g.G, c)
+	for _, f := range []*Form{x, y, z} {
+		assert.True(g.IsElement(f))
+	}
+
+	// group laws
+	assert.True(g.Equal(g.Mul(g.Mul(x, y), z), g.Mul(x, g.Mul(y, z))))
+	assert.True(g.Equal(g.Mul(x, y), g.Mul(y, x)))
+	assert.True(g.Equal(g.Mul(x, g.Identity()), x))
+	assert.True(g.Equal(g.Mul(x, g.Inverse(x)), g.Identity()))
+	assert.True(g.Equal(g.Mul(x, x), g.Exp(x, big.NewInt(2))))
+
+	// exponentiations
+	ab := new(big.Int).Add(a, b)
+	assert.True(g.Equal(g.Exp(g.G, ab), g.Mul(x, y)))
+	assert.True(g.Equal(g.Exp(x, b), g.Exp(y, a)))
+	assert.True(g.Equal(g.Exp(x, new(big.Int).Neg(c)), g.Inverse(g.Exp(x, c))))
+	assert.True(g.Equal(g.Exp(x, big.NewInt(0)), g.Identity()))
+
+	// an equivalent form which is not reduced reduces to the same element:
+	// (a, b, c) ~ (a, b + 2a, a + b + c)
+	b2 := new(big.Int).Lsh(x.A, 1)
+	b2.Add(b2, x.B)
+	c2 := new(big.Int).Add(x.A, x.B)
+	c2.Add(c2, x.C)
+	equivalent := &Form{A: new(big.Int).Set(x.A), B: b2, C: c2}
+	assert.False(g.IsElement(equivalent))
+	assert.True(g.Equal(g.reduce(equivalent.A, equivalent.B, equivalent.C), x))
+
+	// invalid elements
+	assert.False(g.IsElement(nil))
+	assert.False(g.IsElement(&Form{A: x.A, B: x.B, C: new(big.Int).Add(x.C, big.NewInt(1))}))
+	assert.False(g.IsElement(&Form{A: new(big.Int).Neg(x.A), B: x.B, C: new(big.Int).Neg(x.C)}))
+}
+
+func BenchmarkExp(b *testing.B) {
+	g, err := New([]byte("seed"), 2048)
+	if err != nil {
+		b.Fatal(err)
+	}
+	e := new(big.Int).Lsh(big.NewInt(1), 256)
+	e.Sub(e, big.NewInt(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Exp(g.G, e)
+	}
+}
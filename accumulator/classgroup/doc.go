@@ -0,0 +1,18 @@
+// Package classgroup provides the class group of an imaginary quadratic
+// order, a group of unknown order without trusted setup for the accumulators
+// of package unknownorder.
+//
+// The elements are the classes of the positive definite binary quadratic
+// forms ax² + bxy + cy² of discriminant D = b² - 4ac < 0, each represented by
+// its unique reduced form. The group law is the composition of forms (Gauss,
+// see Cohen, "A Course in Computational Algebraic Number Theory", 5.4).
+//
+// The discriminant D = -p is derived from a public seed, with p ≡ 7 mod 8 a
+// prime, so that the order of the group, the class number h(D), is unknown to
+// everyone. The generator is the form (2, 1, (1 - D)/8). The security of the
+// accumulators relies on the hardness of computing h(D) and roots in the
+// group, and is set by the size of the discriminant.
+//
+//	group, err := classgroup.New([]byte("seed"), 2048)
+//	acc := unknownorder.New(group)
+package classgroup
@@ -0,0 +1,16 @@
+// Package imt provides an indexed Merkle tree: a set of values, stored as a
+// sorted linked list in the leaves of a sparse Merkle tree, which are appended
+// in insertion order.
+//
+// Each leaf holds a value, and the index and value of the next leaf in the
+// sorted order, 0 marking the end of the list. The first leaf is the sentinel
+// of value 0. A value x is not in the set if and only if a leaf, its low leaf,
+// has value < x and x < next value, or a next value of 0: a proof of
+// non-membership is a proof of inclusion of the low leaf, of the size of a
+// single Merkle path.
+//
+// The values are big-endian integers of the size of the digests of the hash
+// function, which with a field-native hash function such as MiMC must be
+// canonical encodings of field elements: the insertion of other values returns
+// the error of the hash function, and leaves the tree unchanged.
+package imt
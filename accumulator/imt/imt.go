@@ -0,0 +1,315 @@
+package imt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/accumulator/smt"
+)
+
+var (
+	ErrInvalidDepth = errors.New("the depth must be between 1 and 64")
+	ErrInvalidValue = errors.New("the value must be a non-zero integer of the size of the digests")
+	ErrExists       = errors.New("the value is already in the tree")
+	ErrNotExists    = errors.New("the value is not in the tree")
+	ErrFull         = errors.New("the tree is full")
+
+	ErrInvalidEncoding = errors.New("invalid encoding of the proof")
+)
+
+// Leaf is a leaf of an indexed Merkle tree.
+type Leaf struct {
+	Value     []byte
+	NextIndex uint64
+	NextValue []byte
+}
+
+// Tree is an indexed Merkle tree.
+type Tree struct {
+	h    hash.Hash
+	tree *smt.Tree
+
+	// leaves are the leaves in insertion order, and sorted their indexes in
+	// increasing order of their values
+	leaves []Leaf
+	sorted []uint64
+}
+
+// New returns an indexed Merkle tree of the given depth, which holds at most
+// 2^depth-1 values besides the sentinel.
+func New(h hash.Hash, depth int) (*Tree, error) {
+	if depth < 1 || depth > 64 {
+		return nil, ErrInvalidDepth
+	}
+	tree, err := smt.New(h, depth)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tree{
+		h:    h,
+		tree: tree,
+	}
+
+	// the sentinel
+	zero := make([]byte, h.Size())
+	t.leaves = []Leaf{{Value: zero, NextValue: zero}}
+	t.sorted = []uint64{0}
+	if err = t.tree.Set(t.key(0), encodeLeaf(h, &t.leaves[0])); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() []byte {
+	return t.tree.Root()
+}
+
+// Len returns the number of leaves, including the sentinel.
+func (t *Tree) Len() int {
+	return len(t.leaves)
+}
+
+// Leaf returns the leaf of the given index.
+func (t *Tree) Leaf(index uint64) (Leaf, bool) {
+	if index >= uint64(len(t.leaves)) {
+		return Leaf{}, false
+	}
+	return t.leaves[index], true
+}
+
+// Contains returns true if the value is in the tree.
+func (t *Tree) Contains(value []byte) bool {
+	_, found := t.search(value)
+	return found
+}
+
+// Insert inserts the values in the tree: for each of them, the next value of
+// its low leaf becomes the new value, and the new leaf is appended with the
+// previous next value of the low leaf. The tree is unchanged if an error is
+// returned, including the error of the hash function if it rejects a value.
+func (t *Tree) Insert(values ...[]byte) error {
+	// the values are inserted in a copy of the leaves, and the modified
+	// leaves are written in the sparse Merkle tree in a single update
+	staged := &Tree{
+		h:      t.h,
+		tree:   t.tree,
+		leaves: slices.Clone(t.leaves),
+		sorted: slices.Clone(t.sorted),
+	}
+	modified := make(map[uint64]struct{}, 2*len(values))
+	for _, value := range values {
+		if err := staged.checkValue(value); err != nil {
+			return err
+		}
+		if depth := t.tree.Depth(); depth < 64 && uint64(len(staged.leaves))>>depth != 0 {
+			return ErrFull
+		}
+		pos, found := staged.search(value)
+		if found {
+			return ErrExists
+		}
+		lowIndex := staged.sorted[pos-1]
+		low := &staged.leaves[lowIndex]
+
+		index := uint64(len(staged.leaves))
+		leaf := Leaf{
+			Value:     bytes.Clone(value),
+			NextIndex: low.NextIndex,
+			NextValue: low.NextValue,
+		}
+		low.NextIndex, low.NextValue = index, leaf.Value
+		staged.leaves = append(staged.leaves, leaf)
+		staged.sorted = slices.Insert(staged.sorted, pos, index)
+		modified[lowIndex] = struct{}{}
+		modified[index] = struct{}{}
+	}
+
+	keys := make([][]byte, 0, len(modified))
+	encodings := make([][]byte, 0, len(modified))
+	for index := range modified {
+		keys = append(keys, t.key(index))
+		encodings = append(encodings, encodeLeaf(t.h, &staged.leaves[index]))
+	}
+	if err := t.tree.Update(keys, encodings); err != nil {
+		return err
+	}
+	t.leaves, t.sorted = staged.leaves, staged.sorted
+	return nil
+}
+
+// search returns the position of value in sorted, or the position at which it
+// would be inserted, which is never 0 for a non-zero value.
+func (t *Tree) search(value []byte) (int, bool) {
+	return slices.BinarySearchFunc(t.sorted, value, func(index uint64, value []byte) int {
+		return bytes.Compare(t.leaves[index].Value, value)
+	})
+}
+
+func (t *Tree) checkValue(value []byte) error {
+	if len(value) != t.h.Size() || isZero(value) {
+		return ErrInvalidValue
+	}
+	return nil
+}
+
+// key returns the key of the leaf of the given index in the sparse Merkle tree.
+func (t *Tree) key(index uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], index)
+	return b[8-t.tree.KeySize():]
+}
+
+// Proof is a proof of inclusion of a leaf: of a value for a proof of
+// membership, and of its low leaf for a proof of non-membership.
+type Proof struct {
+	Index uint64
+	Leaf  Leaf
+	Path  smt.Proof
+}
+
+// MarshalBinary returns the encoding of the proof: the index and the next
+// index on 8 bytes, the size of the values on 1 byte, the value, the next
+// value and the encoding of the path.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	size := len(p.Leaf.Value)
+	if size > 255 || len(p.Leaf.NextValue) != size {
+		return nil, ErrInvalidEncoding
+	}
+	path, err := p.Path.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, 17, 17+2*size+len(path))
+	binary.BigEndian.PutUint64(res, p.Index)
+	binary.BigEndian.PutUint64(res[8:], p.Leaf.NextIndex)
+	res[16] = byte(size)
+	res = append(res, p.Leaf.Value...)
+	res = append(res, p.Leaf.NextValue...)
+	return append(res, path...), nil
+}
+
+// UnmarshalBinary sets p from the encoding of MarshalBinary.
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	if len(data) < 17 {
+		return ErrInvalidEncoding
+	}
+	size := int(data[16])
+	if len(data) < 17+2*size {
+		return ErrInvalidEncoding
+	}
+	var path smt.Proof
+	if err := path.UnmarshalBinary(data[17+2*size:]); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidEncoding, err)
+	}
+	p.Index = binary.BigEndian.Uint64(data)
+	p.Leaf = Leaf{
+		Value:     bytes.Clone(data[17 : 17+size]),
+		NextIndex: binary.BigEndian.Uint64(data[8:]),
+		NextValue: bytes.Clone(data[17+size : 17+2*size]),
+	}
+	p.Path = path
+	return nil
+}
+
+// ProveMembership returns a proof that the value is in the tree.
+func (t *Tree) ProveMembership(value []byte) (Proof, error) {
+	if err := t.checkValue(value); err != nil {
+		return Proof{}, err
+	}
+	pos, found := t.search(value)
+	if !found {
+		return Proof{}, ErrNotExists
+	}
+	return t.prove(t.sorted[pos])
+}
+
+// ProveNonMembership returns a proof that the value is not in the tree.
+func (t *Tree) ProveNonMembership(value []byte) (Proof, error) {
+	if err := t.checkValue(value); err != nil {
+		return Proof{}, err
+	}
+	pos, found := t.search(value)
+	if found {
+		return Proof{}, ErrExists
+	}
+	return t.prove(t.sorted[pos-1])
+}
+
+func (t *Tree) prove(index uint64) (Proof, error) {
+	path, err := t.tree.Prove(t.key(index))
+	if err != nil {
+		return Proof{}, err
+	}
+	return Proof{
+		Index: index,
+		Leaf:  t.leaves[index],
+		Path:  path,
+	}, nil
+}
+
+// VerifyMembership returns true if the proof proves that the value is in the
+// tree of the given root. It returns the error of the hash function if it
+// rejects the leaf of the proof.
+func VerifyMembership(h hash.Hash, root, value []byte, proof *Proof) (bool, error) {
+	if !bytes.Equal(proof.Leaf.Value, value) || isZero(value) {
+		return false, nil
+	}
+	return verifyLeaf(h, root, proof)
+}
+
+// VerifyNonMembership returns true if the proof proves that the value is not
+// in the tree of the given root: the low leaf in the proof is in the tree,
+// and its value and next value surround the value. It returns the error of
+// the hash function if it rejects the leaf of the proof.
+func VerifyNonMembership(h hash.Hash, root, value []byte, proof *Proof) (bool, error) {
+	leaf := &proof.Leaf
+	if len(value) != h.Size() || len(leaf.Value) != h.Size() || len(leaf.NextValue) != h.Size() {
+		return false, nil
+	}
+	if bytes.Compare(leaf.Value, value) >= 0 {
+		return false, nil
+	}
+	if !isZero(leaf.NextValue) && bytes.Compare(value, leaf.NextValue) >= 0 {
+		return false, nil
+	}
+	return verifyLeaf(h, root, proof)
+}
+
+func verifyLeaf(h hash.Hash, root []byte, proof *Proof) (bool, error) {
+	depth := proof.Path.Depth
+	if depth < 1 || depth > 64 || (depth < 64 && proof.Index>>depth != 0) {
+		return false, nil
+	}
+	if len(proof.Leaf.Value) != h.Size() || len(proof.Leaf.NextValue) != h.Size() {
+		return false, nil
+	}
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], proof.Index)
+	key := b[8-(depth+7)/8:]
+	return smt.VerifyProof(h, root, key, encodeLeaf(h, &proof.Leaf), &proof.Path)
+}
+
+// encodeLeaf returns the value of the leaf in the sparse Merkle tree: the
+// value, the next index and the next value, each on the size of the digests.
+func encodeLeaf(h hash.Hash, leaf *Leaf) []byte {
+	size := h.Size()
+	res := make([]byte, 3*size)
+	copy(res, leaf.Value)
+	binary.BigEndian.PutUint64(res[2*size-8:2*size], leaf.NextIndex)
+	copy(res[2*size:], leaf.NextValue)
+	return res
+}
+
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
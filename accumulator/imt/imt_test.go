@@ -0,0 +1,166 @@
+package imt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"hash"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+)
+
+func value(i uint64) []byte {
+	var x fr.Element
+	x.SetUint64(i)
+	b := x.Bytes()
+	return b[:]
+}
+
+// verifyMembership and verifyNonMembership call VerifyMembership and
+// VerifyNonMembership, which must not fail.
+func verifyMembership(t *testing.T, h hash.Hash, root, value []byte, proof *Proof) bool {
+	ok, err := VerifyMembership(h, root, value, proof)
+	require.NoError(t, err)
+	return ok
+}
+
+func verifyNonMembership(t *testing.T, h hash.Hash, root, value []byte, proof *Proof) bool {
+	ok, err := VerifyNonMembership(h, root, value, proof)
+	require.NoError(t, err)
+	return ok
+}
+
+func TestIndexedMerkleTree(t *testing.T) {
+	for name, h := range map[string]hash.Hash{"sha256": sha256.New(), "mimc": mimc.NewMiMC()} {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			tree, err := New(h, 8)
+			assert.NoError(err)
+			assert.NoError(tree.Insert(value(30), value(10), value(20), value(50)))
+			assert.Equal(5, tree.Len())
+			root := tree.Root()
+
+			// the leaves form a sorted linked list from the sentinel
+			var values []uint64
+			for leaf, _ := tree.Leaf(0); leaf.NextIndex != 0; leaf, _ = tree.Leaf(leaf.NextIndex) {
+				next, ok := tree.Leaf(leaf.NextIndex)
+				assert.True(ok)
+				assert.Equal(leaf.NextValue, next.Value)
+				var x fr.Element
+				x.SetBytes(next.Value)
+				values = append(values, x.Uint64())
+			}
+			assert.Equal([]uint64{10, 20, 30, 50}, values)
+
+			// membership
+			for _, v := range []uint64{10, 20, 30, 50} {
+				assert.True(tree.Contains(value(v)))
+				proof, err := tree.ProveMembership(value(v))
+				assert.NoError(err)
+				assert.True(verifyMembership(t, h, root, value(v), &proof))
+				assert.False(verifyMembership(t, h, root, value(v+1), &proof))
+				assert.False(verifyNonMembership(t, h, root, value(v), &proof))
+
+				_, err = tree.ProveNonMembership(value(v))
+				assert.ErrorIs(err, ErrExists)
+			}
+
+			// non-membership, below, between and above the values
+			for _, v := range []uint64{5, 15, 40, 100} {
+				assert.False(tree.Contains(value(v)))
+				proof, err := tree.ProveNonMembership(value(v))
+				assert.NoError(err)
+				assert.True(verifyNonMembership(t, h, root, value(v), &proof))
+				assert.False(verifyMembership(t, h, root, value(v), &proof))
+
+				_, err = tree.ProveMembership(value(v))
+				assert.ErrorIs(err, ErrNotExists)
+			}
+
+			// a low leaf does not prove the non-membership of the values outside
+			// of its range
+			proof, err := tree.ProveNonMembership(value(15))
+			assert.NoError(err)
+			assert.False(verifyNonMembership(t, h, root, value(25), &proof))
+			assert.False(verifyNonMembership(t, h, root, value(5), &proof))
+
+			// nor a tampered one
+			proof.Leaf.NextValue = value(30)
+			assert.False(verifyNonMembership(t, h, root, value(25), &proof))
+
+			assert.ErrorIs(tree.Insert(value(20)), ErrExists)
+			assert.ErrorIs(tree.Insert(value(0)), ErrInvalidValue)
+			assert.ErrorIs(tree.Insert(value(1)[1:]), ErrInvalidValue)
+			assert.Equal(root, tree.Root())
+		})
+	}
+}
+
+func TestIndexedMerkleTreeFull(t *testing.T) {
+	assert := require.New(t)
+
+	tree, err := New(sha256.New(), 2)
+	assert.NoError(err)
+	assert.NoError(tree.Insert(value(3), value(2), value(1)))
+	assert.ErrorIs(tree.Insert(value(4)), ErrFull)
+
+	_, err = New(sha256.New(), 65)
+	assert.ErrorIs(err, ErrInvalidDepth)
+}
+
+func TestIndexedMerkleTreeHashError(t *testing.T) {
+	assert := require.New(t)
+
+	// MiMC rejects the values which are not canonical encodings of field
+	// elements
+	h := mimc.NewMiMC()
+	tree, err := New(h, 8)
+	assert.NoError(err)
+	assert.NoError(tree.Insert(value(10)))
+	root := tree.Root()
+
+	invalid := bytes.Repeat([]byte{0xff}, fr.Bytes)
+	assert.Error(tree.Insert(invalid))
+	assert.Error(tree.Insert(value(20), invalid))
+
+	// the tree is unchanged
+	assert.Equal(root, tree.Root())
+	assert.Equal(2, tree.Len())
+	assert.False(tree.Contains(value(20)))
+	assert.False(tree.Contains(invalid))
+	proof, err := tree.ProveNonMembership(value(20))
+	assert.NoError(err)
+	assert.True(verifyNonMembership(t, h, root, value(20), &proof))
+
+	proof.Leaf.Value = invalid
+	_, err = VerifyMembership(h, root, invalid, &proof)
+	assert.Error(err)
+}
+
+func TestProofSerialization(t *testing.T) {
+	assert := require.New(t)
+
+	h := mimc.NewMiMC()
+	tree, err := New(h, 32)
+	assert.NoError(err)
+	for i := uint64(1); i <= 100; i++ {
+		assert.NoError(tree.Insert(value(i * 7)))
+	}
+	root := tree.Root()
+
+	proof, err := tree.ProveNonMembership(value(50))
+	assert.NoError(err)
+	b, err := proof.MarshalBinary()
+	assert.NoError(err)
+
+	var decoded Proof
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.Equal(proof, decoded)
+	assert.True(verifyNonMembership(t, h, root, value(50), &decoded))
+
+	assert.ErrorIs(decoded.UnmarshalBinary(b[:len(b)-1]), ErrInvalidEncoding)
+	assert.ErrorIs(decoded.UnmarshalBinary(b[:20]), ErrInvalidEncoding)
+}
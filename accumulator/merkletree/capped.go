@@ -0,0 +1,92 @@
+package merkletree
+
+import (
+	"errors"
+	"hash"
+	"slices"
+)
+
+var ErrInvalidCapHeight = errors.New("the cap height is negative")
+
+// CappedTree is a Merkle tree which keeps all its nodes in memory, and is
+// committed to with its cap instead of its root: the level capHeight levels
+// below the root, of 2^capHeight nodes when the number of leaves is a power of
+// 2. The paths of the openings stop at the cap, which saves capHeight nodes
+// per path.
+//
+// Its levels are those of the MaterializedTree of the same leaves, hashed with
+// a single hash function, so that a cap of height 0 is the Merkle root.
+type CappedTree struct {
+	numLeaves uint64
+
+	// levels[0] are the hashes of the leaves, and the last level is the cap.
+	levels [][][]byte
+}
+
+// NewCappedTree returns the Merkle tree of the leaves, with a cap of height
+// capHeight, or the hashes of the leaves if the tree is not as deep.
+func NewCappedTree(h hash.Hash, leaves [][]byte, capHeight int) (*CappedTree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	if capHeight < 0 {
+		return nil, ErrInvalidCapHeight
+	}
+	t := &CappedTree{numLeaves: uint64(len(leaves))}
+
+	level := make([][]byte, len(leaves))
+	for i := range leaves {
+		level[i] = leafSum(h, leaves[i])
+	}
+	t.levels = append(t.levels, level)
+
+	for len(level) > 1 {
+		next := make([][]byte, (len(level)+1)/2)
+		for i := 0; i < len(level)/2; i++ {
+			next[i] = nodeSum(h, level[2*i], level[2*i+1])
+		}
+		if len(level)%2 == 1 {
+			next[len(next)-1] = level[len(level)-1]
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	t.levels = t.levels[:max(1, len(t.levels)-capHeight)]
+	return t, nil
+}
+
+// Cap returns the nodes of the cap, which commit to the tree.
+func (t *CappedTree) Cap() [][]byte {
+	return slices.Clone(t.levels[len(t.levels)-1])
+}
+
+// NumLeaves returns the number of leaves.
+func (t *CappedTree) NumLeaves() uint64 {
+	return t.numLeaves
+}
+
+// ProveMultiple returns a proof that the leaves at indices are in the tree,
+// verified by VerifyCappedMultiProof: the siblings of their paths up to the
+// cap, in the order of MaterializedTree.ProveMultiple.
+func (t *CappedTree) ProveMultiple(indices []uint64) (proofSet [][]byte, err error) {
+	if len(indices) == 0 {
+		return nil, ErrInvalidIndex
+	}
+	known := make([]int, len(indices))
+	for i, index := range indices {
+		if index >= t.numLeaves {
+			return nil, ErrInvalidIndex
+		}
+		known[i] = int(index)
+	}
+	return proveMultiple(t.levels, known), nil
+}
+
+// VerifyCappedMultiProof returns true if the proof set, returned by
+// CappedTree.ProveMultiple, proves that the leaves are at indices in the tree
+// of numLeaves leaves whose cap is capNodes. The height of the cap is given by
+// its number of nodes. The leaves are given in the order of the indices, which
+// may be in any order.
+func VerifyCappedMultiProof(h hash.Hash, capNodes [][]byte, leaves [][]byte, indices []uint64, proofSet [][]byte, numLeaves uint64) bool {
+	return verifyMultiProof(h, capNodes, leaves, indices, proofSet, numLeaves)
+}
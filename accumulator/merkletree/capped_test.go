@@ -0,0 +1,69 @@
+package merkletree
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCappedTree(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 7, 16, 100} {
+		data := leaves(n)
+		ref, err := NewMaterializedTree(sha256.New, data)
+		assert.NoError(err)
+
+		for _, capHeight := range []int{0, 1, 3, 8} {
+			tree, err := NewCappedTree(sha256.New(), data, capHeight)
+			assert.NoError(err)
+
+			// the cap is a level of the materialized tree
+			capNodes := tree.Cap()
+			level := ref.levels[max(0, len(ref.levels)-1-capHeight)]
+			assert.Equal(level, capNodes, "n = %d, capHeight = %d", n, capHeight)
+			if n == 16 {
+				assert.Len(capNodes, min(1<<capHeight, n))
+			}
+
+			for _, indices := range [][]uint64{
+				{0},
+				{uint64(n - 1), 0},
+				{uint64(n / 2), uint64(n / 3), uint64(n / 2)},
+			} {
+				opened := make([][]byte, len(indices))
+				for i, index := range indices {
+					opened[i] = data[index]
+				}
+
+				proofSet, err := tree.ProveMultiple(indices)
+				assert.NoError(err)
+				assert.True(VerifyCappedMultiProof(sha256.New(), capNodes, opened, indices, proofSet, uint64(n)), "n = %d, capHeight = %d, indices = %v", n, capHeight, indices)
+				if capHeight == 0 {
+					assert.True(VerifyMultiProof(sha256.New(), ref.Root(), opened, indices, proofSet, uint64(n)))
+				}
+
+				// wrong data, truncated or extended proof, or wrong cap
+				opened[0] = []byte("wrong")
+				assert.False(VerifyCappedMultiProof(sha256.New(), capNodes, opened, indices, proofSet, uint64(n)))
+				opened[0] = data[indices[0]]
+				if len(proofSet) > 0 {
+					assert.False(VerifyCappedMultiProof(sha256.New(), capNodes, opened, indices, proofSet[1:], uint64(n)))
+				}
+				assert.False(VerifyCappedMultiProof(sha256.New(), capNodes, opened, indices, append(proofSet, capNodes[0]), uint64(n)))
+				if len(capNodes) > 1 {
+					assert.False(VerifyCappedMultiProof(sha256.New(), capNodes[1:], opened, indices, proofSet, uint64(n)))
+				}
+			}
+
+			_, err = tree.ProveMultiple([]uint64{0, uint64(n)})
+			assert.ErrorIs(err, ErrInvalidIndex)
+		}
+	}
+
+	_, err := NewCappedTree(sha256.New(), nil, 0)
+	assert.ErrorIs(err, ErrEmptyTree)
+	_, err = NewCappedTree(sha256.New(), leaves(4), -1)
+	assert.ErrorIs(err, ErrInvalidCapHeight)
+}
@@ -0,0 +1,263 @@
+package merkletree
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+	"runtime"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrEmptyTree    = errors.New("the tree has no leaves")
+	ErrInvalidIndex = errors.New("the leaf index is out of range")
+)
+
+// MaterializedTree is a Merkle tree which keeps all its nodes in memory, so
+// that it can open any set of leaves, and update leaves in place.
+//
+// It has the same root as a Tree in which the same leaves are pushed: the
+// nodes of each level are the hashes of the pairs of nodes of the level below,
+// and the last node of a level of odd size is promoted to the level above.
+type MaterializedTree struct {
+	hash hash.Hash
+
+	// leaves are the data of the leaves, and levels[0] their hashes. The last
+	// level holds the root.
+	leaves [][]byte
+	levels [][][]byte
+}
+
+// NewMaterializedTree returns the Merkle tree of the leaves. The hashes of the
+// leaves, and of the nodes of the large levels, are computed in parallel, each
+// task using its own hash function returned by newHash.
+func NewMaterializedTree(newHash func() hash.Hash, leaves [][]byte) (*MaterializedTree, error) {
+	if len(leaves) == 0 {
+		return nil, ErrEmptyTree
+	}
+	t := &MaterializedTree{
+		hash:   newHash(),
+		leaves: make([][]byte, len(leaves)),
+	}
+	for i := range leaves {
+		t.leaves[i] = bytes.Clone(leaves[i])
+	}
+
+	level := make([][]byte, len(leaves))
+	parallel.Execute(len(leaves), func(start, end int) {
+		h := newHash()
+		for i := start; i < end; i++ {
+			level[i] = leafSum(h, t.leaves[i])
+		}
+	}, maxTasks(len(leaves)))
+	t.levels = append(t.levels, level)
+
+	for len(level) > 1 {
+		next := make([][]byte, (len(level)+1)/2)
+		parallel.Execute(len(level)/2, func(start, end int) {
+			h := newHash()
+			for i := start; i < end; i++ {
+				next[i] = nodeSum(h, level[2*i], level[2*i+1])
+			}
+		}, maxTasks(len(level)/2))
+		if len(level)%2 == 1 {
+			next[len(next)-1] = level[len(level)-1]
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// maxTasks bounds the number of parallel tasks hashing n nodes, so that the
+// small levels near the root are not split between goroutines.
+func maxTasks(n int) int {
+	const minNodesPerTask = 256
+	return max(1, min(n/minNodesPerTask, runtime.NumCPU()))
+}
+
+// Root returns the Merkle root.
+func (t *MaterializedTree) Root() []byte {
+	root := t.levels[len(t.levels)-1][0]
+	return append(root[:0:0], root...)
+}
+
+// NumLeaves returns the number of leaves.
+func (t *MaterializedTree) NumLeaves() uint64 {
+	return uint64(len(t.leaves))
+}
+
+// Update sets the data of the leaf at index, and recomputes the nodes on its
+// path to the root.
+func (t *MaterializedTree) Update(index uint64, data []byte) error {
+	if index >= t.NumLeaves() {
+		return ErrInvalidIndex
+	}
+	t.leaves[index] = bytes.Clone(data)
+	t.levels[0][index] = leafSum(t.hash, data)
+
+	i := int(index)
+	for l := 0; l < len(t.levels)-1; l++ {
+		level := t.levels[l]
+		if i == len(level)-1 && len(level)%2 == 1 {
+			t.levels[l+1][i/2] = level[i]
+		} else {
+			j := i &^ 1
+			t.levels[l+1][i/2] = nodeSum(t.hash, level[j], level[j+1])
+		}
+		i /= 2
+	}
+	return nil
+}
+
+// Prove returns a proof that the leaf at index is in the tree, in the format
+// of Tree.Prove: the data of the leaf followed by its siblings, which is
+// verified by VerifyProof.
+func (t *MaterializedTree) Prove(index uint64) (proofSet [][]byte, err error) {
+	if index >= t.NumLeaves() {
+		return nil, ErrInvalidIndex
+	}
+	proofSet = [][]byte{t.leaves[index]}
+	i := int(index)
+	for l := 0; l < len(t.levels)-1; l++ {
+		level := t.levels[l]
+		if !(i == len(level)-1 && len(level)%2 == 1) {
+			proofSet = append(proofSet, level[i^1])
+		}
+		i /= 2
+	}
+	return proofSet, nil
+}
+
+// ProveMultiple returns a proof that the leaves at indices are in the tree,
+// verified by VerifyMultiProof: the siblings of their paths which are not on
+// the path of another of the leaves, level by level from the leaves, and in
+// increasing order in each level. Each of them appears once, so that the proof
+// is smaller than the separate proofs of the leaves.
+func (t *MaterializedTree) ProveMultiple(indices []uint64) (proofSet [][]byte, err error) {
+	if len(indices) == 0 {
+		return nil, ErrInvalidIndex
+	}
+	known := make([]int, len(indices))
+	for i, index := range indices {
+		if index >= t.NumLeaves() {
+			return nil, ErrInvalidIndex
+		}
+		known[i] = int(index)
+	}
+	return proveMultiple(t.levels, known), nil
+}
+
+// proveMultiple returns the siblings of the paths of the leaves at indices up
+// to the last level, which are not on the path of another of the leaves. It
+// sorts known, the indices.
+func proveMultiple(levels [][][]byte, known []int) (proofSet [][]byte) {
+	slices.Sort(known)
+	known = slices.Compact(known)
+
+	for l := 0; l < len(levels)-1; l++ {
+		level := levels[l]
+		var next []int
+		for k := 0; k < len(known); k++ {
+			i := known[k]
+			switch {
+			case i == len(level)-1 && len(level)%2 == 1:
+				// promoted
+			case i%2 == 0 && k+1 < len(known) && known[k+1] == i+1:
+				// the sibling is known
+				k++
+			default:
+				proofSet = append(proofSet, level[i^1])
+			}
+			next = append(next, i/2)
+		}
+		known = next
+	}
+	return proofSet
+}
+
+// VerifyMultiProof returns true if the proof set, returned by ProveMultiple,
+// proves that the leaves are at indices in the tree of numLeaves leaves whose
+// root is merkleRoot. The leaves are given in the order of the indices, which
+// may be in any order.
+func VerifyMultiProof(h hash.Hash, merkleRoot []byte, leaves [][]byte, indices []uint64, proofSet [][]byte, numLeaves uint64) bool {
+	if merkleRoot == nil {
+		return false
+	}
+	return verifyMultiProof(h, [][]byte{merkleRoot}, leaves, indices, proofSet, numLeaves)
+}
+
+// verifyMultiProof returns true if the proof set proves that the leaves are at
+// indices in the tree of numLeaves leaves, whose level of len(top) nodes is
+// top.
+func verifyMultiProof(h hash.Hash, top [][]byte, leaves [][]byte, indices []uint64, proofSet [][]byte, numLeaves uint64) bool {
+	if len(top) == 0 || len(indices) == 0 || len(leaves) != len(indices) {
+		return false
+	}
+
+	type node struct {
+		index int
+		sum   []byte
+	}
+	known := make([]node, len(indices))
+	for i, index := range indices {
+		if index >= numLeaves {
+			return false
+		}
+		known[i] = node{int(index), leafSum(h, leaves[i])}
+	}
+	slices.SortStableFunc(known, func(a, b node) int {
+		return a.index - b.index
+	})
+
+	// the same leaf may be given several times, with the same data
+	k := 0
+	for i := 1; i < len(known); i++ {
+		if known[i].index != known[k].index {
+			k++
+			known[k] = known[i]
+		} else if !bytes.Equal(known[i].sum, known[k].sum) {
+			return false
+		}
+	}
+	known = known[:k+1]
+
+	size := int(numLeaves)
+	for size > len(top) {
+		var next []node
+		for k := 0; k < len(known); k++ {
+			i, sum := known[k].index, known[k].sum
+			switch {
+			case i == size-1 && size%2 == 1:
+				// promoted
+			case i%2 == 0 && k+1 < len(known) && known[k+1].index == i+1:
+				sum = nodeSum(h, sum, known[k+1].sum)
+				k++
+			default:
+				if len(proofSet) == 0 {
+					return false
+				}
+				if i%2 == 0 {
+					sum = nodeSum(h, sum, proofSet[0])
+				} else {
+					sum = nodeSum(h, proofSet[0], sum)
+				}
+				proofSet = proofSet[1:]
+			}
+			next = append(next, node{i / 2, sum})
+		}
+		known = next
+		size = (size + 1) / 2
+	}
+	if size != len(top) || len(proofSet) != 0 {
+		return false
+	}
+	for _, n := range known {
+		if !bytes.Equal(n.sum, top[n.index]) {
+			return false
+		}
+	}
+	return true
+}
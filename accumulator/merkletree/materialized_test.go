@@ -0,0 +1,140 @@
+package merkletree
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func leaves(n int) [][]byte {
+	res := make([][]byte, n)
+	for i := range res {
+		res[i] = binary.BigEndian.AppendUint64(nil, uint64(i)*0x9e3779b97f4a7c15)
+	}
+	return res
+}
+
+func TestMaterializedTree(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 3, 5, 8, 13, 100, 1000} {
+		data := leaves(n)
+		tree, err := NewMaterializedTree(sha256.New, data)
+		assert.NoError(err)
+
+		// same root as the streaming tree
+		ref := New(sha256.New())
+		for _, d := range data {
+			ref.Push(d)
+		}
+		assert.Equal(ref.Root(), tree.Root(), "n = %d", n)
+
+		for i := uint64(0); i < uint64(n); i += 1 + uint64(n)/7 {
+			proofSet, err := tree.Prove(i)
+			assert.NoError(err)
+			assert.True(VerifyProof(sha256.New(), tree.Root(), proofSet, i, uint64(n)), "n = %d, i = %d", n, i)
+		}
+	}
+
+	_, err := NewMaterializedTree(sha256.New, nil)
+	assert.ErrorIs(err, ErrEmptyTree)
+}
+
+func TestMaterializedTreeUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 37
+	data := leaves(n)
+	tree, err := NewMaterializedTree(sha256.New, data)
+	assert.NoError(err)
+
+	for _, i := range []uint64{0, 17, 35, 36} {
+		data[i] = []byte("updated")
+		assert.NoError(tree.Update(i, data[i]))
+
+		expected, err := NewMaterializedTree(sha256.New, data)
+		assert.NoError(err)
+		assert.Equal(expected.Root(), tree.Root())
+
+		proofSet, err := tree.Prove(i)
+		assert.NoError(err)
+		assert.True(VerifyProof(sha256.New(), tree.Root(), proofSet, i, n))
+	}
+	assert.ErrorIs(tree.Update(n, nil), ErrInvalidIndex)
+}
+
+func TestMultiProof(t *testing.T) {
+	assert := require.New(t)
+
+	for _, n := range []int{1, 2, 7, 16, 100} {
+		data := leaves(n)
+		tree, err := NewMaterializedTree(sha256.New, data)
+		assert.NoError(err)
+		root := tree.Root()
+
+		for _, indices := range [][]uint64{
+			{0},
+			{uint64(n - 1)},
+			{uint64(n - 1), 0},
+			{0, 1, 2, 3},
+			{uint64(n / 2), uint64(n / 3), uint64(n / 2)},
+		} {
+			if slices.Max(indices) >= uint64(n) {
+				continue
+			}
+			opened := make([][]byte, len(indices))
+			for i, index := range indices {
+				opened[i] = data[index]
+			}
+
+			proofSet, err := tree.ProveMultiple(indices)
+			assert.NoError(err)
+			assert.True(VerifyMultiProof(sha256.New(), root, opened, indices, proofSet, uint64(n)), "n = %d, indices = %v", n, indices)
+
+			// wrong data or truncated proof
+			opened[0] = []byte("wrong")
+			assert.False(VerifyMultiProof(sha256.New(), root, opened, indices, proofSet, uint64(n)))
+			opened[0] = data[indices[0]]
+			if len(proofSet) > 0 {
+				assert.False(VerifyMultiProof(sha256.New(), root, opened, indices, proofSet[1:], uint64(n)))
+			}
+		}
+
+		_, err = tree.ProveMultiple([]uint64{0, uint64(n)})
+		assert.ErrorIs(err, ErrInvalidIndex)
+	}
+}
+
+func TestMultiProofDeduplication(t *testing.T) {
+	assert := require.New(t)
+
+	const n = 1 << 10
+	tree, err := NewMaterializedTree(sha256.New, leaves(n))
+	assert.NoError(err)
+
+	// the siblings of the first 8 leaves are the leaves themselves, up to the
+	// subtree of height 3
+	indices := []uint64{0, 1, 2, 3, 4, 5, 6, 7}
+	proofSet, err := tree.ProveMultiple(indices)
+	assert.NoError(err)
+	assert.Len(proofSet, 10-3)
+
+	total := 0
+	for _, i := range indices {
+		single, err := tree.Prove(i)
+		assert.NoError(err)
+		total += len(single) - 1
+	}
+	assert.Less(len(proofSet), total)
+}
+
+func BenchmarkNewMaterializedTree(b *testing.B) {
+	data := leaves(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = NewMaterializedTree(sha256.New, data)
+	}
+}
@@ -0,0 +1,12 @@
+// Package rsa provides the group ℤₙ* of an RSA modulus N, a group of unknown
+// order for the accumulators of package unknownorder.
+//
+// The modulus is either the RSA-2048 challenge number, whose factorization is
+// unknown, or a modulus generated by a trusted party, who knows the trapdoor
+// φ(N) and can then delete elements and compute witnesses with short
+// exponentiations:
+//
+//	acc := unknownorder.New(params, unknownorder.WithOrder(trapdoor.Order()))
+//
+// See package classgroup for a group of unknown order without trusted setup.
+package rsa
@@ -0,0 +1,121 @@
+package rsa
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+)
+
+// rsa2048 is the RSA-2048 number of the RSA Factoring Challenge.
+const rsa2048 = "25195908475657893494027183240048398571429282126204032027777137836043662020707595556264018525880784406918290641249515082189298559149176184502808489120072844992687392807287776735971418347270261896375014971824691165077613379859095700097330459748808428401797429100642458691817195118746121515172654632282216869987549182422433637259085141865462043576798423387184774447920739934236584823824281198163815010674810451660377306056201619676256133844143603833904414952634432190114657544454178424020924616515723350778707749817125772467962926386356373289912154831438167899885040445364023527381951378636564391212010397122822120720357"
+
+// generator is the base of the accumulators.
+const generator = 3
+
+var ErrInvalidModulusSize = errors.New("the modulus must be of at least 1024 bits")
+
+// Params are the public parameters of an accumulator: an RSA modulus N and a
+// base G in ℤₙ*. They implement the group ℤₙ* for package unknownorder.
+type Params struct {
+	N *big.Int
+	G *big.Int
+}
+
+// Trapdoor is the factorization of the modulus, known by a trusted party.
+type Trapdoor struct {
+	P, Q *big.Int
+}
+
+// RSA2048 returns the parameters of the RSA-2048 challenge number, whose
+// factorization is unknown, with G = 3.
+func RSA2048() *Params {
+	n, _ := new(big.Int).SetString(rsa2048, 10)
+	return &Params{
+		N: n,
+		G: big.NewInt(generator),
+	}
+}
+
+// GenerateParams returns parameters with a modulus of the given size, product
+// of two random primes, and its factorization. The trapdoor must be discarded,
+// or kept by the trusted party managing the accumulator.
+func GenerateParams(rnd io.Reader, bits int) (*Params, *Trapdoor, error) {
+	if bits < 1024 {
+		return nil, nil, ErrInvalidModulusSize
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	for {
+		p, err := rand.Prime(rnd, bits/2)
+		if err != nil {
+			return nil, nil, err
+		}
+		q, err := rand.Prime(rnd, bits-bits/2)
+		if err != nil {
+			return nil, nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		n := new(big.Int).Mul(p, q)
+		if n.BitLen() != bits {
+			continue
+		}
+		return &Params{N: n, G: big.NewInt(generator)}, &Trapdoor{P: p, Q: q}, nil
+	}
+}
+
+// Order returns the order φ(N) = (P-1)(Q-1) of ℤₙ*.
+func (t *Trapdoor) Order() *big.Int {
+	one := big.NewInt(1)
+	p := new(big.Int).Sub(t.P, one)
+	q := new(big.Int).Sub(t.Q, one)
+	return p.Mul(p, q)
+}
+
+// Generator returns G.
+func (p *Params) Generator() *big.Int {
+	return new(big.Int).Set(p.G)
+}
+
+// Exp returns xᵉ mod N, where e may be negative.
+func (p *Params) Exp(x, e *big.Int) *big.Int {
+	if e.Sign() >= 0 {
+		return new(big.Int).Exp(x, e, p.N)
+	}
+	inv := new(big.Int).ModInverse(x, p.N)
+	if inv == nil {
+		// x is not invertible: a factor of N is found
+		panic("rsa: element not invertible modulo N")
+	}
+	return inv.Exp(inv, new(big.Int).Neg(e), p.N)
+}
+
+// Mul returns x⋅y mod N.
+func (p *Params) Mul(x, y *big.Int) *big.Int {
+	res := new(big.Int).Mul(x, y)
+	return res.Mod(res, p.N)
+}
+
+// Equal returns true if x = y.
+func (p *Params) Equal(x, y *big.Int) bool {
+	return x.Cmp(y) == 0
+}
+
+// IsElement returns true if x is in [1, N).
+func (p *Params) IsElement(x *big.Int) bool {
+	return x != nil && x.Sign() > 0 && x.Cmp(p.N) < 0
+}
+
+// Bytes returns N ‖ G, G on the size of N.
+func (p *Params) Bytes() []byte {
+	n := p.N.Bytes()
+	return append(n, p.G.FillBytes(make([]byte, len(n)))...)
+}
+
+// ElementBytes returns the big-endian encoding of x.
+func (p *Params) ElementBytes(x *big.Int) []byte {
+	return x.Bytes()
+}
@@ -0,0 +1,36 @@
+package rsa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/unknownorder"
+	"github.com/stretchr/testify/require"
+)
+
+var _ unknownorder.Group[*big.Int] = (*Params)(nil)
+
+func TestRSA2048(t *testing.T) {
+	assert := require.New(t)
+
+	params := RSA2048()
+	assert.Equal(2048, params.N.BitLen())
+	assert.False(params.N.ProbablyPrime(10))
+}
+
+func TestGenerateParams(t *testing.T) {
+	assert := require.New(t)
+
+	params, trapdoor, err := GenerateParams(nil, 1024)
+	assert.NoError(err)
+	assert.Equal(1024, params.N.BitLen())
+	assert.Equal(0, new(big.Int).Mul(trapdoor.P, trapdoor.Q).Cmp(params.N))
+
+	// x^φ(N) = 1
+	x := params.Exp(params.G, big.NewInt(12345))
+	assert.True(params.Equal(params.Exp(x, trapdoor.Order()), big.NewInt(1)))
+	assert.True(params.Equal(params.Mul(x, params.Exp(x, big.NewInt(-1))), big.NewInt(1)))
+
+	_, _, err = GenerateParams(nil, 512)
+	assert.ErrorIs(err, ErrInvalidModulusSize)
+}
@@ -0,0 +1,18 @@
+// Package smt provides a sparse Merkle tree: a Merkle tree of fixed depth
+// whose 2^depth leaves are addressed by keys, and which stores only the
+// non-empty subtrees.
+//
+// The leaf of a key is empty, or holds a value. The hashes of the empty
+// subtrees are the default nodes, so that a proof of the path of a key proves
+// either that the key holds a value (inclusion), or that its leaf is empty
+// (non-inclusion). The proofs omit the default siblings, and are serialized
+// with a bitmap of the non-default ones.
+//
+// The tree is parameterized by a hash.Hash, which can be a field-native hash
+// function such as MiMC: the nodes are the digests of the concatenation of a
+// domain separation byte and their children, and the leaves are the digests of
+// a domain separation byte, the key and the value, each written separately. With
+// MiMC, the keys and the values must then be encodings of field elements: the
+// errors of the hash function are returned by the methods of the tree, which is
+// then left unchanged, and by VerifyProof.
+package smt
@@ -0,0 +1,79 @@
+package smt
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidEncoding = errors.New("invalid encoding of the proof")
+
+// Proof is a proof of the path of a key: the siblings of the nodes of the
+// path which are not default nodes, from the leaf to the root.
+type Proof struct {
+
+	// Depth is the depth of the tree.
+	Depth int
+
+	// Bitmap has its ℓ-th least significant bit set if the sibling of height
+	// ℓ is not the default node, in which case it is in Siblings.
+	Bitmap []byte
+
+	// Siblings are the non-default siblings, from the leaf to the root.
+	Siblings [][]byte
+}
+
+// MarshalBinary returns the compact encoding of the proof: the depth on 2
+// bytes, the size of the digests on 1 byte, the bitmap and the siblings.
+func (p *Proof) MarshalBinary() ([]byte, error) {
+	digestSize := 0
+	if len(p.Siblings) > 0 {
+		digestSize = len(p.Siblings[0])
+	}
+	if p.Depth < 1 || p.Depth > 256 || len(p.Bitmap) != keySize(p.Depth) || digestSize > 255 {
+		return nil, ErrInvalidEncoding
+	}
+
+	res := make([]byte, 3, 3+len(p.Bitmap)+digestSize*len(p.Siblings))
+	binary.BigEndian.PutUint16(res, uint16(p.Depth))
+	res[2] = byte(digestSize)
+	res = append(res, p.Bitmap...)
+	for _, s := range p.Siblings {
+		if len(s) != digestSize {
+			return nil, ErrInvalidEncoding
+		}
+		res = append(res, s...)
+	}
+	return res, nil
+}
+
+// UnmarshalBinary sets p from the encoding of MarshalBinary.
+func (p *Proof) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 {
+		return ErrInvalidEncoding
+	}
+	depth := int(binary.BigEndian.Uint16(data))
+	digestSize := int(data[2])
+	data = data[3:]
+	if depth < 1 || depth > 256 || len(data) < keySize(depth) {
+		return ErrInvalidEncoding
+	}
+	bitmap := data[:keySize(depth)]
+	data = data[keySize(depth):]
+
+	nbSiblings := 0
+	for _, b := range bitmap {
+		nbSiblings += bits.OnesCount8(b)
+	}
+	if len(data) != nbSiblings*digestSize || (nbSiblings > 0 && digestSize == 0) {
+		return ErrInvalidEncoding
+	}
+
+	p.Depth = depth
+	p.Bitmap = append([]byte(nil), bitmap...)
+	p.Siblings = make([][]byte, nbSiblings)
+	for i := range p.Siblings {
+		p.Siblings[i] = append([]byte(nil), data[i*digestSize:(i+1)*digestSize]...)
+	}
+	return nil
+}
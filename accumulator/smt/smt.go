@@ -0,0 +1,317 @@
+package smt
+
+import (
+	"bytes"
+	"errors"
+	"hash"
+)
+
+var (
+	ErrInvalidDepth  = errors.New("the depth must be between 1 and 256")
+	ErrInvalidKey    = errors.New("the key must be of KeySize bytes and smaller than 2^depth")
+	ErrInvalidUpdate = errors.New("the numbers of keys and of values differ")
+)
+
+// the domain separation bytes of the hashes of the leaves and of the nodes
+var (
+	leafPrefix = []byte{0}
+	nodePrefix = []byte{1}
+)
+
+// Tree is a sparse Merkle tree of a given depth. The leaves are addressed by
+// keys of KeySize bytes, the big-endian encodings of the integers in
+// [0, 2^depth), whose bits from the most significant are the path from the
+// root to the leaf.
+type Tree struct {
+	h     hash.Hash
+	depth int
+
+	// defaults[ℓ] is the hash of an empty subtree of height ℓ
+	defaults [][]byte
+
+	// values are the values of the non-empty leaves, and nodes[ℓ] the
+	// non-default nodes of height ℓ, indexed by the key of their leftmost
+	// leaf. nodes[depth] holds the root, if it is not the default one.
+	values map[string][]byte
+	nodes  []map[string][]byte
+}
+
+// New returns an empty sparse Merkle tree of the given depth.
+func New(h hash.Hash, depth int) (*Tree, error) {
+	if depth < 1 || depth > 256 {
+		return nil, ErrInvalidDepth
+	}
+	defaults, err := defaultNodes(h, depth)
+	if err != nil {
+		return nil, err
+	}
+	t := &Tree{
+		h:        h,
+		depth:    depth,
+		defaults: defaults,
+		values:   make(map[string][]byte),
+		nodes:    make([]map[string][]byte, depth+1),
+	}
+	for i := range t.nodes {
+		t.nodes[i] = make(map[string][]byte)
+	}
+	return t, nil
+}
+
+// defaultNodes returns the hashes of the empty subtrees of height 0 to depth:
+// the empty leaf is the zero digest, and the empty subtree of height ℓ+1 the
+// node of two empty subtrees of height ℓ.
+func defaultNodes(h hash.Hash, depth int) ([][]byte, error) {
+	res := make([][]byte, depth+1)
+	res[0] = make([]byte, h.Size())
+	for i := 1; i <= depth; i++ {
+		var err error
+		if res[i], err = nodeSum(h, res[i-1], res[i-1]); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Depth returns the depth of the tree.
+func (t *Tree) Depth() int {
+	return t.depth
+}
+
+// KeySize returns the size in bytes of the keys, ⌈depth/8⌉.
+func (t *Tree) KeySize() int {
+	return keySize(t.depth)
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() []byte {
+	return t.node(t.depth, make([]byte, t.KeySize()))
+}
+
+// Get returns the value of the leaf of key, or nil if it is empty.
+func (t *Tree) Get(key []byte) ([]byte, error) {
+	if !isValidKey(key, t.depth) {
+		return nil, ErrInvalidKey
+	}
+	return t.values[string(key)], nil
+}
+
+// Set sets the value of the leaf of key. A nil value empties the leaf. It
+// returns the error of the hash function if it rejects the key or the value,
+// in which case the tree is unchanged.
+func (t *Tree) Set(key, value []byte) error {
+	return t.Update([][]byte{key}, [][]byte{value})
+}
+
+// Delete empties the leaf of key.
+func (t *Tree) Delete(key []byte) error {
+	return t.Set(key, nil)
+}
+
+// Update sets the values of the leaves of keys, nil values emptying their
+// leaves, and recomputes each node above them once. If a key appears several
+// times, its last value is kept. The tree is modified only once all the nodes
+// are computed, so that it is unchanged if an error is returned.
+func (t *Tree) Update(keys, values [][]byte) error {
+	if len(keys) != len(values) {
+		return ErrInvalidUpdate
+	}
+	for _, key := range keys {
+		if !isValidKey(key, t.depth) {
+			return ErrInvalidKey
+		}
+	}
+
+	// changes[ℓ] are the new nodes of height ℓ, nil for the default node
+	changes := make([]map[string][]byte, t.depth+1)
+	changes[0] = make(map[string][]byte, len(keys))
+	for i, key := range keys {
+		var leaf []byte
+		if values[i] != nil {
+			var err error
+			if leaf, err = leafSum(t.h, key, values[i]); err != nil {
+				return err
+			}
+		}
+		changes[0][string(key)] = leaf
+	}
+
+	// recompute the parents of the changed nodes, level by level
+	for level := 0; level < t.depth; level++ {
+		changes[level+1] = make(map[string][]byte, len(changes[level]))
+		for k := range changes[level] {
+			parent := []byte(k)
+			clearBit(parent, level)
+			if _, ok := changes[level+1][string(parent)]; ok {
+				continue
+			}
+			right := bytes.Clone(parent)
+			setBit(right, level)
+			node, err := nodeSum(t.h, t.newNode(changes, level, parent), t.newNode(changes, level, right))
+			if err != nil {
+				return err
+			}
+			if bytes.Equal(node, t.defaults[level+1]) {
+				node = nil
+			}
+			changes[level+1][string(parent)] = node
+		}
+	}
+
+	for i, key := range keys {
+		if values[i] == nil {
+			delete(t.values, string(key))
+		} else {
+			t.values[string(key)] = bytes.Clone(values[i])
+		}
+	}
+	for level, nodes := range changes {
+		for k, node := range nodes {
+			if node == nil {
+				delete(t.nodes[level], k)
+			} else {
+				t.nodes[level][k] = node
+			}
+		}
+	}
+	return nil
+}
+
+// node returns the node of height level whose leftmost leaf is key.
+func (t *Tree) node(level int, key []byte) []byte {
+	if n, ok := t.nodes[level][string(key)]; ok {
+		return n
+	}
+	return t.defaults[level]
+}
+
+// newNode returns the node of height level whose leftmost leaf is key, after
+// the changes of an update.
+func (t *Tree) newNode(changes []map[string][]byte, level int, key []byte) []byte {
+	if n, ok := changes[level][string(key)]; ok {
+		if n == nil {
+			return t.defaults[level]
+		}
+		return n
+	}
+	return t.node(level, key)
+}
+
+// Prove returns the proof of the path of key, which proves its value, or that
+// its leaf is empty.
+func (t *Tree) Prove(key []byte) (Proof, error) {
+	if !isValidKey(key, t.depth) {
+		return Proof{}, ErrInvalidKey
+	}
+	proof := Proof{
+		Depth:  t.depth,
+		Bitmap: make([]byte, keySize(t.depth)),
+	}
+	current := bytes.Clone(key)
+	for level := 0; level < t.depth; level++ {
+		sibling := bytes.Clone(current)
+		flipBit(sibling, level)
+		if n, ok := t.nodes[level][string(sibling)]; ok {
+			setBit(proof.Bitmap, level)
+			proof.Siblings = append(proof.Siblings, bytes.Clone(n))
+		}
+		clearBit(current, level)
+	}
+	return proof, nil
+}
+
+// VerifyProof returns true if the proof proves that the leaf of key holds
+// value in the tree of the given root, or that it is empty if value is nil.
+// It returns the error of the hash function if it rejects the key, the value
+// or a sibling.
+func VerifyProof(h hash.Hash, root, key, value []byte, proof *Proof) (bool, error) {
+	if proof.Depth < 1 || proof.Depth > 256 || !isValidKey(key, proof.Depth) || len(proof.Bitmap) != keySize(proof.Depth) {
+		return false, nil
+	}
+	defaults, err := defaultNodes(h, proof.Depth)
+	if err != nil {
+		return false, err
+	}
+
+	node := defaults[0]
+	if value != nil {
+		if node, err = leafSum(h, key, value); err != nil {
+			return false, err
+		}
+	}
+	siblings := proof.Siblings
+	for level := 0; level < proof.Depth; level++ {
+		sibling := defaults[level]
+		if bit(proof.Bitmap, level) == 1 {
+			if len(siblings) == 0 {
+				return false, nil
+			}
+			sibling, siblings = siblings[0], siblings[1:]
+		}
+		if bit(key, level) == 0 {
+			node, err = nodeSum(h, node, sibling)
+		} else {
+			node, err = nodeSum(h, sibling, node)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	return len(siblings) == 0 && bytes.Equal(node, root), nil
+}
+
+// leafSum returns H(0x00 ‖ key ‖ value).
+func leafSum(h hash.Hash, key, value []byte) ([]byte, error) {
+	return sum(h, leafPrefix, key, value)
+}
+
+// nodeSum returns H(0x01 ‖ left ‖ right).
+func nodeSum(h hash.Hash, left, right []byte) ([]byte, error) {
+	return sum(h, nodePrefix, left, right)
+}
+
+// sum returns the hash of data, written separately. Field-native hash
+// functions such as MiMC return an error on inputs which do not encode field
+// elements.
+func sum(h hash.Hash, data ...[]byte) ([]byte, error) {
+	h.Reset()
+	for _, d := range data {
+		if _, err := h.Write(d); err != nil {
+			return nil, err
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// keySize returns the size in bytes of the keys of a tree of the given depth.
+func keySize(depth int) int {
+	return (depth + 7) / 8
+}
+
+// isValidKey returns true if key is the encoding of an integer in [0, 2^depth).
+func isValidKey(key []byte, depth int) bool {
+	if len(key) != keySize(depth) {
+		return false
+	}
+	if r := depth % 8; r != 0 && key[0]>>r != 0 {
+		return false
+	}
+	return true
+}
+
+// bit returns the i-th least significant bit of the big-endian integer b.
+func bit(b []byte, i int) byte {
+	return b[len(b)-1-i/8] >> (i % 8) & 1
+}
+
+func setBit(b []byte, i int) {
+	b[len(b)-1-i/8] |= 1 << (i % 8)
+}
+
+func clearBit(b []byte, i int) {
+	b[len(b)-1-i/8] &^= 1 << (i % 8)
+}
+
+func flipBit(b []byte, i int) {
+	b[len(b)-1-i/8] ^= 1 << (i % 8)
+}
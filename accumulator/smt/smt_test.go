@@ -0,0 +1,246 @@
+package smt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/mimc"
+	"github.com/stretchr/testify/require"
+)
+
+func key(depth int, i uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], i)
+	return b[8-keySize(depth):]
+}
+
+func value(i uint64) []byte {
+	var x fr.Element
+	x.SetUint64(i)
+	b := x.Bytes()
+	return b[:]
+}
+
+// verify calls VerifyProof, which must not fail.
+func verify(t *testing.T, h hash.Hash, root, key, value []byte, proof *Proof) bool {
+	ok, err := VerifyProof(h, root, key, value, proof)
+	require.NoError(t, err)
+	return ok
+}
+
+func TestSparseMerkleTree(t *testing.T) {
+	for name, h := range map[string]hash.Hash{"sha256": sha256.New(), "mimc": mimc.NewMiMC()} {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+
+			const depth = 20
+			tree, err := New(h, depth)
+			assert.NoError(err)
+			empty := tree.Root()
+
+			for i := uint64(0); i < 10; i++ {
+				assert.NoError(tree.Set(key(depth, 3*i), value(i+1)))
+			}
+			root := tree.Root()
+			assert.NotEqual(empty, root)
+
+			// inclusion
+			for i := uint64(0); i < 10; i++ {
+				v, err := tree.Get(key(depth, 3*i))
+				assert.NoError(err)
+				assert.Equal(value(i+1), v)
+
+				proof, err := tree.Prove(key(depth, 3*i))
+				assert.NoError(err)
+				assert.True(verify(t, h, root, key(depth, 3*i), value(i+1), &proof))
+				assert.False(verify(t, h, root, key(depth, 3*i), value(i+2), &proof))
+				assert.False(verify(t, h, root, key(depth, 3*i), nil, &proof))
+			}
+
+			// non-inclusion
+			for _, k := range []uint64{1, 2, 1000, 1<<depth - 1} {
+				v, err := tree.Get(key(depth, k))
+				assert.NoError(err)
+				assert.Nil(v)
+
+				proof, err := tree.Prove(key(depth, k))
+				assert.NoError(err)
+				assert.True(verify(t, h, root, key(depth, k), nil, &proof))
+				assert.False(verify(t, h, root, key(depth, k), value(1), &proof))
+			}
+
+			// the proofs are bound to their key
+			proof, err := tree.Prove(key(depth, 3))
+			assert.NoError(err)
+			assert.False(verify(t, h, root, key(depth, 6), value(2), &proof))
+
+			// deleting the leaves restores the empty tree
+			for i := uint64(0); i < 10; i++ {
+				assert.NoError(tree.Delete(key(depth, 3*i)))
+			}
+			assert.Equal(empty, tree.Root())
+			for level := 1; level <= depth; level++ {
+				assert.Empty(tree.nodes[level])
+			}
+		})
+	}
+}
+
+func TestSparseMerkleTreeUpdate(t *testing.T) {
+	assert := require.New(t)
+
+	const depth = 16
+	h := sha256.New()
+	batched, err := New(h, depth)
+	assert.NoError(err)
+	sequential, err := New(h, depth)
+	assert.NoError(err)
+
+	var keys, values [][]byte
+	for i := uint64(0); i < 64; i++ {
+		keys = append(keys, key(depth, i*i%(1<<depth)))
+		values = append(values, value(i))
+		assert.NoError(sequential.Set(keys[i], values[i]))
+	}
+	assert.NoError(batched.Update(keys, values))
+	assert.Equal(sequential.Root(), batched.Root())
+
+	// a batch can mix insertions, modifications and deletions
+	keys = [][]byte{key(depth, 0), key(depth, 1), key(depth, 7), key(depth, 7)}
+	values = [][]byte{nil, value(100), value(1), value(2)}
+	for i := range keys {
+		assert.NoError(sequential.Set(keys[i], values[i]))
+	}
+	assert.NoError(batched.Update(keys, values))
+	assert.Equal(sequential.Root(), batched.Root())
+
+	v, err := batched.Get(key(depth, 7))
+	assert.NoError(err)
+	assert.Equal(value(2), v)
+
+	assert.ErrorIs(batched.Update(keys, values[:1]), ErrInvalidUpdate)
+}
+
+func TestSparseMerkleTreeInvalidKeys(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := New(sha256.New(), 0)
+	assert.ErrorIs(err, ErrInvalidDepth)
+	_, err = New(sha256.New(), 257)
+	assert.ErrorIs(err, ErrInvalidDepth)
+
+	tree, err := New(sha256.New(), 12)
+	assert.NoError(err)
+	assert.Equal(2, tree.KeySize())
+	assert.ErrorIs(tree.Set([]byte{1}, value(1)), ErrInvalidKey)
+	assert.ErrorIs(tree.Set([]byte{0x10, 0}, value(1)), ErrInvalidKey)
+	assert.NoError(tree.Set([]byte{0x0f, 0xff}, value(1)))
+	_, err = tree.Prove([]byte{0x10, 0})
+	assert.ErrorIs(err, ErrInvalidKey)
+
+	// full depth
+	tree, err = New(sha256.New(), 256)
+	assert.NoError(err)
+	k := sha256.Sum256([]byte("key"))
+	assert.NoError(tree.Set(k[:], value(1)))
+	proof, err := tree.Prove(k[:])
+	assert.NoError(err)
+	assert.True(verify(t, sha256.New(), tree.Root(), k[:], value(1), &proof))
+}
+
+func TestSparseMerkleTreeHashError(t *testing.T) {
+	assert := require.New(t)
+
+	// MiMC rejects the keys and the values which are not canonical encodings
+	// of field elements, and the keys of a tree of depth 256 are of their size
+	const depth = 256
+	tree, err := New(mimc.NewMiMC(), depth)
+	assert.NoError(err)
+	assert.NoError(tree.Set(value(1), value(1)))
+	root := tree.Root()
+
+	invalid := bytes.Repeat([]byte{0xff}, fr.Bytes)
+	assert.Error(tree.Set(invalid, value(2)))
+	assert.Error(tree.Set(value(2), invalid))
+	assert.Error(tree.Update([][]byte{value(2), invalid}, [][]byte{value(2), value(3)}))
+
+	// the tree is unchanged
+	assert.Equal(root, tree.Root())
+	for _, k := range [][]byte{invalid, value(2)} {
+		v, err := tree.Get(k)
+		assert.NoError(err)
+		assert.Nil(v)
+	}
+	for level := range tree.nodes {
+		assert.Len(tree.nodes[level], 1)
+	}
+
+	proof, err := tree.Prove(invalid)
+	assert.NoError(err)
+	_, err = VerifyProof(mimc.NewMiMC(), root, invalid, value(2), &proof)
+	assert.Error(err)
+	proof, err = tree.Prove(value(2))
+	assert.NoError(err)
+	_, err = VerifyProof(mimc.NewMiMC(), root, value(2), invalid, &proof)
+	assert.Error(err)
+	assert.True(verify(t, mimc.NewMiMC(), root, value(2), nil, &proof))
+}
+
+func TestProofSerialization(t *testing.T) {
+	assert := require.New(t)
+
+	const depth = 32
+	h := mimc.NewMiMC()
+	tree, err := New(h, depth)
+	assert.NoError(err)
+	for i := uint64(0); i < 100; i++ {
+		assert.NoError(tree.Set(key(depth, i<<20), value(i)))
+	}
+
+	proof, err := tree.Prove(key(depth, 5<<20))
+	assert.NoError(err)
+	b, err := proof.MarshalBinary()
+	assert.NoError(err)
+
+	// only the siblings in the populated subtrees are encoded
+	assert.Equal(7, len(proof.Siblings))
+	assert.Equal(3+4+7*h.Size(), len(b))
+
+	var decoded Proof
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.Equal(proof, decoded)
+	assert.True(verify(t, h, tree.Root(), key(depth, 5<<20), value(5), &decoded))
+
+	assert.ErrorIs(decoded.UnmarshalBinary(b[:len(b)-1]), ErrInvalidEncoding)
+	assert.ErrorIs(decoded.UnmarshalBinary(append(b, 0)), ErrInvalidEncoding)
+	assert.ErrorIs(decoded.UnmarshalBinary(b[:2]), ErrInvalidEncoding)
+
+	// a proof in an empty tree has no siblings
+	empty, err := New(h, depth)
+	assert.NoError(err)
+	proof, err = empty.Prove(key(depth, 5))
+	assert.NoError(err)
+	b, err = proof.MarshalBinary()
+	assert.NoError(err)
+	assert.Equal(3+4, len(b))
+	assert.NoError(decoded.UnmarshalBinary(b))
+	assert.True(verify(t, h, empty.Root(), key(depth, 5), nil, &decoded))
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	const depth, n = 32, 1 << 10
+	keys, values := make([][]byte, n), make([][]byte, n)
+	for i := range keys {
+		keys[i] = key(depth, uint64(i)*2654435761%(1<<depth))
+		values[i] = value(uint64(i))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree, _ := New(mimc.NewMiMC(), depth)
+		_ = tree.Update(keys, values)
+	}
+}
@@ -0,0 +1,303 @@
+package unknownorder
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrExists    = errors.New("the element is already in the accumulator")
+	ErrNotExists = errors.New("the element is not in the accumulator")
+	ErrEmpty     = errors.New("no element given")
+)
+
+// Accumulator is the state of the manager of an accumulator: the set of the
+// primes of its elements, and its value.
+type Accumulator[E any] struct {
+	group Group[E]
+	order *big.Int
+
+	value  E
+	primes map[string]*big.Int
+}
+
+// Option configures an Accumulator.
+type Option func(*config)
+
+type config struct {
+	order *big.Int
+}
+
+// WithOrder sets the order of the group, or a multiple of it, known by the
+// trusted party who generated an RSA modulus. The deletions and the membership
+// witnesses are then computed with exponentiations by a single prime instead
+// of by the product of all the primes.
+func WithOrder(order *big.Int) Option {
+	return func(c *config) {
+		c.order = order
+	}
+}
+
+// New returns an empty accumulator, whose value is the generator of the group.
+func New[E any](group Group[E], opts ...Option) *Accumulator[E] {
+	var c config
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &Accumulator[E]{
+		group:  group,
+		order:  c.order,
+		value:  group.Generator(),
+		primes: make(map[string]*big.Int),
+	}
+}
+
+// Value returns the value of the accumulator.
+func (a *Accumulator[E]) Value() E {
+	return a.value
+}
+
+// Len returns the number of elements in the accumulator.
+func (a *Accumulator[E]) Len() int {
+	return len(a.primes)
+}
+
+// Contains returns true if the element is in the accumulator.
+func (a *Accumulator[E]) Contains(element []byte) bool {
+	_, ok := a.primes[string(HashToPrime(element).Bytes())]
+	return ok
+}
+
+// Update is a batch of additions or deletions, published by the manager of
+// the accumulator so that the holders of witnesses can update them.
+type Update[E any] struct {
+	// Added and Deleted are the primes of the added or deleted elements,
+	// one of them being empty.
+	Added, Deleted []*big.Int
+
+	// Previous and Value are the values of the accumulator before and after
+	// the update.
+	Previous, Value E
+
+	// Proof is the proof of exponentiation that Previous^(∏ Added) = Value,
+	// or that Value^(∏ Deleted) = Previous.
+	Proof E
+}
+
+// Add adds the elements to the accumulator, and returns the update.
+func (a *Accumulator[E]) Add(elements ...[]byte) (*Update[E], error) {
+	primes, err := a.hashElements(elements, false)
+	if err != nil {
+		return nil, err
+	}
+	previous := a.value
+	a.value = a.group.Exp(previous, product(primes))
+	for _, x := range primes {
+		a.primes[string(x.Bytes())] = x
+	}
+	return &Update[E]{
+		Added:    primes,
+		Previous: previous,
+		Value:    a.value,
+		Proof:    ProveExponentiation(a.group, previous, primes, a.value),
+	}, nil
+}
+
+// Delete deletes the elements from the accumulator, and returns the update.
+// Without the order of the group, the new value is recomputed from the
+// remaining elements.
+func (a *Accumulator[E]) Delete(elements ...[]byte) (*Update[E], error) {
+	primes, err := a.hashElements(elements, true)
+	if err != nil {
+		return nil, err
+	}
+	previous := a.value
+	for _, x := range primes {
+		delete(a.primes, string(x.Bytes()))
+	}
+	if a.order != nil {
+		e := new(big.Int).ModInverse(product(primes), a.order)
+		a.value = a.group.Exp(previous, e)
+	} else {
+		a.value = a.group.Exp(a.group.Generator(), a.product())
+	}
+	return &Update[E]{
+		Deleted:  primes,
+		Previous: previous,
+		Value:    a.value,
+		Proof:    ProveExponentiation(a.group, a.value, primes, previous),
+	}, nil
+}
+
+// VerifyUpdate returns true if the proof of exponentiation of the update is
+// valid.
+func VerifyUpdate[E any](group Group[E], update *Update[E]) bool {
+	switch {
+	case len(update.Added) > 0 && len(update.Deleted) == 0:
+		return VerifyExponentiation(group, update.Previous, update.Added, update.Value, update.Proof)
+	case len(update.Deleted) > 0 && len(update.Added) == 0:
+		return VerifyExponentiation(group, update.Value, update.Deleted, update.Previous, update.Proof)
+	}
+	return false
+}
+
+// MembershipWitness returns the membership witness of the element, the value
+// of the accumulator without it.
+func (a *Accumulator[E]) MembershipWitness(element []byte) (E, error) {
+	x := HashToPrime(element)
+	if _, ok := a.primes[string(x.Bytes())]; !ok {
+		var zero E
+		return zero, ErrNotExists
+	}
+	if a.order != nil {
+		e := new(big.Int).ModInverse(x, a.order)
+		return a.group.Exp(a.value, e), nil
+	}
+	return a.group.Exp(a.group.Generator(), a.product(x)), nil
+}
+
+// VerifyMembership returns true if the witness proves that the element is in
+// the accumulator of the given value: wˣ = A.
+func VerifyMembership[E any](group Group[E], value E, element []byte, witness E) bool {
+	if !group.IsElement(witness) {
+		return false
+	}
+	x := HashToPrime(element)
+	return group.Equal(group.Exp(witness, x), value)
+}
+
+// BatchMembershipProof proves that several elements are in an accumulator.
+type BatchMembershipProof[E any] struct {
+	// Witness is the value of the accumulator without the elements.
+	Witness E
+
+	// Proof is the proof of exponentiation that Witness^(∏ xᵢ) = A.
+	Proof E
+}
+
+// BatchMembershipWitness returns an aggregated membership witness of the
+// elements, of constant size, whose verification costs an exponentiation by a
+// single prime.
+func (a *Accumulator[E]) BatchMembershipWitness(elements ...[]byte) (*BatchMembershipProof[E], error) {
+	primes, err := a.hashElements(elements, true)
+	if err != nil {
+		return nil, err
+	}
+	var witness E
+	if a.order != nil {
+		e := new(big.Int).ModInverse(product(primes), a.order)
+		witness = a.group.Exp(a.value, e)
+	} else {
+		witness = a.group.Exp(a.group.Generator(), a.product(primes...))
+	}
+	return &BatchMembershipProof[E]{
+		Witness: witness,
+		Proof:   ProveExponentiation(a.group, witness, primes, a.value),
+	}, nil
+}
+
+// VerifyBatchMembership returns true if the proof proves that the elements
+// are in the accumulator of the given value.
+func VerifyBatchMembership[E any](group Group[E], value E, elements [][]byte, proof *BatchMembershipProof[E]) bool {
+	if len(elements) == 0 {
+		return false
+	}
+	primes := make([]*big.Int, len(elements))
+	seen := make(map[string]struct{}, len(elements))
+	for i, element := range elements {
+		primes[i] = HashToPrime(element)
+		if _, ok := seen[string(primes[i].Bytes())]; ok {
+			return false
+		}
+		seen[string(primes[i].Bytes())] = struct{}{}
+	}
+	return VerifyExponentiation(group, proof.Witness, primes, value, proof.Proof)
+}
+
+// NonMembershipWitness proves that an element y is not in an accumulator A:
+// Aᵃ⋅Bʸ = g.
+type NonMembershipWitness[E any] struct {
+	A *big.Int // in [0, y)
+	B E
+}
+
+// NonMembershipWitness returns the non-membership witness of the element:
+// (a, gᵇ) where a⋅∏ xᵢ + b⋅y = 1 for y the prime of the element.
+func (a *Accumulator[E]) NonMembershipWitness(element []byte) (*NonMembershipWitness[E], error) {
+	y := HashToPrime(element)
+	if _, ok := a.primes[string(y.Bytes())]; ok {
+		return nil, ErrExists
+	}
+	u := a.product()
+	var s, t big.Int
+	new(big.Int).GCD(&s, &t, u, y)
+
+	// s⋅u + t⋅y = 1, with s reduced modulo y
+	k := reduce(&s, y)
+	t.Add(&t, k.Mul(k, u))
+	return &NonMembershipWitness[E]{
+		A: &s,
+		B: a.group.Exp(a.group.Generator(), &t),
+	}, nil
+}
+
+// VerifyNonMembership returns true if the witness proves that the element is
+// not in the accumulator of the given value.
+func VerifyNonMembership[E any](group Group[E], value E, element []byte, witness *NonMembershipWitness[E]) bool {
+	y := HashToPrime(element)
+	if witness.A == nil || witness.A.Sign() < 0 || witness.A.Cmp(y) >= 0 || !group.IsElement(witness.B) {
+		return false
+	}
+	lhs := group.Mul(group.Exp(value, witness.A), group.Exp(witness.B, y))
+	return group.Equal(lhs, group.Generator())
+}
+
+// product returns the product of the primes of the accumulator, except the
+// given ones.
+func (a *Accumulator[E]) product(except ...*big.Int) *big.Int {
+	excluded := make(map[string]struct{}, len(except))
+	for _, x := range except {
+		excluded[string(x.Bytes())] = struct{}{}
+	}
+	primes := make([]*big.Int, 0, len(a.primes))
+	for k, x := range a.primes {
+		if _, ok := excluded[k]; !ok {
+			primes = append(primes, x)
+		}
+	}
+	return product(primes)
+}
+
+// hashElements returns the distinct primes of the elements, which must all be
+// in the accumulator if members is set, and none otherwise.
+func (a *Accumulator[E]) hashElements(elements [][]byte, members bool) ([]*big.Int, error) {
+	if len(elements) == 0 {
+		return nil, ErrEmpty
+	}
+	primes := make([]*big.Int, 0, len(elements))
+	seen := make(map[string]struct{}, len(elements))
+	for _, element := range elements {
+		x := HashToPrime(element)
+		k := string(x.Bytes())
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		if _, ok := a.primes[k]; ok != members {
+			if members {
+				return nil, ErrNotExists
+			}
+			return nil, ErrExists
+		}
+		primes = append(primes, x)
+	}
+	return primes, nil
+}
+
+// reduce sets s to s mod y in [0, y), and returns k such that the previous s
+// is s + k⋅y.
+func reduce(s, y *big.Int) *big.Int {
+	k := new(big.Int)
+	k.DivMod(new(big.Int).Set(s), y, s)
+	return k
+}
@@ -0,0 +1,211 @@
+package unknownorder
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/classgroup"
+	"github.com/consensys/gnark-crypto/accumulator/rsa"
+	"github.com/stretchr/testify/require"
+)
+
+func elements(start, n int) [][]byte {
+	res := make([][]byte, n)
+	for i := range res {
+		res[i] = []byte(fmt.Sprintf("element %d", start+i))
+	}
+	return res
+}
+
+// classGroup is the class group of a discriminant of 1024 bits, shared by the
+// tests.
+var classGroup = sync.OnceValue(func() *classgroup.Group {
+	g, err := classgroup.New([]byte("unknownorder"), 1024)
+	if err != nil {
+		panic(err)
+	}
+	return g
+})
+
+func TestAccumulator(t *testing.T) {
+	params, trapdoor, err := rsa.GenerateParams(nil, 1024)
+	require.NoError(t, err)
+
+	t.Run("rsa2048", func(t *testing.T) {
+		testAccumulator(t, New(rsa.RSA2048()))
+	})
+	t.Run("trapdoor", func(t *testing.T) {
+		testAccumulator(t, New(params, WithOrder(trapdoor.Order())))
+	})
+	t.Run("classgroup", func(t *testing.T) {
+		testAccumulator(t, New(classGroup()))
+	})
+}
+
+func testAccumulator[E any](t *testing.T, acc *Accumulator[E]) {
+	assert := require.New(t)
+	group := acc.group
+
+	members, others := elements(0, 8), elements(8, 2)
+	update, err := acc.Add(members...)
+	assert.NoError(err)
+	assert.True(VerifyUpdate(group, update))
+	assert.Equal(8, acc.Len())
+	value := acc.Value()
+
+	for _, e := range members {
+		assert.True(acc.Contains(e))
+		w, err := acc.MembershipWitness(e)
+		assert.NoError(err)
+		assert.True(VerifyMembership(group, value, e, w))
+		assert.False(VerifyMembership(group, value, others[0], w))
+
+		_, err = acc.NonMembershipWitness(e)
+		assert.ErrorIs(err, ErrExists)
+	}
+	for _, e := range others {
+		assert.False(acc.Contains(e))
+		w, err := acc.NonMembershipWitness(e)
+		assert.NoError(err)
+		assert.True(VerifyNonMembership(group, value, e, w))
+		assert.False(VerifyNonMembership(group, value, members[0], w))
+
+		_, err = acc.MembershipWitness(e)
+		assert.ErrorIs(err, ErrNotExists)
+	}
+
+	// batch membership
+	proof, err := acc.BatchMembershipWitness(members[2:6]...)
+	assert.NoError(err)
+	assert.True(VerifyBatchMembership(group, value, members[2:6], proof))
+	assert.False(VerifyBatchMembership(group, value, members[2:5], proof))
+	assert.False(VerifyBatchMembership(group, value, append(members[2:5:5], others[0]), proof))
+
+	// deletions
+	update, err = acc.Delete(members[:3]...)
+	assert.NoError(err)
+	assert.True(VerifyUpdate(group, update))
+	assert.Equal(5, acc.Len())
+	w, err := acc.NonMembershipWitness(members[0])
+	assert.NoError(err)
+	assert.True(VerifyNonMembership(group, acc.Value(), members[0], w))
+
+	// deleting everything restores the empty accumulator
+	_, err = acc.Delete(members[3:]...)
+	assert.NoError(err)
+	assert.True(group.Equal(group.Generator(), acc.Value()))
+
+	_, err = acc.Delete(members[0])
+	assert.ErrorIs(err, ErrNotExists)
+	_, err = acc.Add()
+	assert.ErrorIs(err, ErrEmpty)
+}
+
+func TestWitnessUpdates(t *testing.T) {
+	t.Run("rsa2048", func(t *testing.T) {
+		testWitnessUpdates(t, rsa.RSA2048())
+	})
+	t.Run("classgroup", func(t *testing.T) {
+		testWitnessUpdates(t, classGroup())
+	})
+}
+
+func testWitnessUpdates[E any](t *testing.T, group Group[E]) {
+	assert := require.New(t)
+
+	acc := New(group)
+	_, err := acc.Add(elements(0, 4)...)
+	assert.NoError(err)
+
+	member, nonMember := []byte("element 1"), []byte("not a member")
+	w, err := acc.MembershipWitness(member)
+	assert.NoError(err)
+	nw, err := acc.NonMembershipWitness(nonMember)
+	assert.NoError(err)
+
+	// the holders follow the updates without knowing the set
+	for _, update := range []func() (*Update[E], error){
+		func() (*Update[E], error) { return acc.Add(elements(10, 5)...) },
+		func() (*Update[E], error) { return acc.Delete(elements(2, 2)...) },
+		func() (*Update[E], error) { return acc.Delete(elements(0, 1)...) },
+		func() (*Update[E], error) { return acc.Add(elements(20, 1)...) },
+		func() (*Update[E], error) { return acc.Delete(elements(10, 3)...) },
+	} {
+		upd, err := update()
+		assert.NoError(err)
+		assert.True(VerifyUpdate(group, upd))
+
+		w, err = UpdateMembershipWitness(group, member, w, upd)
+		assert.NoError(err)
+		assert.True(VerifyMembership(group, acc.Value(), member, w))
+
+		nw, err = UpdateNonMembershipWitness(group, nonMember, nw, upd)
+		assert.NoError(err)
+		assert.True(VerifyNonMembership(group, acc.Value(), nonMember, nw))
+	}
+
+	// the witnesses of the elements in an update can't be updated
+	upd, err := acc.Delete(member)
+	assert.NoError(err)
+	_, err = UpdateMembershipWitness(group, member, w, upd)
+	assert.ErrorIs(err, ErrNotExists)
+
+	upd, err = acc.Add(nonMember)
+	assert.NoError(err)
+	_, err = UpdateNonMembershipWitness(group, nonMember, nw, upd)
+	assert.ErrorIs(err, ErrExists)
+}
+
+func TestProofOfExponentiation(t *testing.T) {
+	t.Run("rsa2048", func(t *testing.T) {
+		testProofOfExponentiation(t, rsa.RSA2048())
+	})
+	t.Run("classgroup", func(t *testing.T) {
+		testProofOfExponentiation(t, classGroup())
+	})
+}
+
+func testProofOfExponentiation[E any](t *testing.T, group Group[E]) {
+	assert := require.New(t)
+
+	u := group.Exp(group.Generator(), big.NewInt(12345))
+	exponents := []*big.Int{HashToPrime([]byte("a")), HashToPrime([]byte("b")), big.NewInt(1 << 40)}
+	w := group.Exp(u, product(exponents))
+
+	proof := ProveExponentiation(group, u, exponents, w)
+	assert.True(VerifyExponentiation(group, u, exponents, w, proof))
+
+	assert.False(VerifyExponentiation(group, u, exponents[:2], w, proof))
+	assert.False(VerifyExponentiation(group, u, exponents, group.Mul(w, group.Generator()), proof))
+	assert.False(VerifyExponentiation(group, u, exponents, w, group.Mul(proof, group.Generator())))
+}
+
+func TestHashToPrime(t *testing.T) {
+	assert := require.New(t)
+
+	x := HashToPrime([]byte("element"))
+	assert.Equal(primeBits, x.BitLen())
+	assert.True(x.ProbablyPrime(20))
+	assert.Equal(0, x.Cmp(HashToPrime([]byte("element"))))
+	assert.NotEqual(0, x.Cmp(HashToPrime([]byte("element "))))
+}
+
+func BenchmarkVerifyBatchMembership(b *testing.B) {
+	params := rsa.RSA2048()
+	acc := New(params)
+	members := elements(0, 64)
+	if _, err := acc.Add(members...); err != nil {
+		b.Fatal(err)
+	}
+	proof, err := acc.BatchMembershipWitness(members[:32]...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	value := acc.Value()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		VerifyBatchMembership(params, value, members[:32], proof)
+	}
+}
@@ -0,0 +1,25 @@
+// Package unknownorder provides a dynamic accumulator in a group of unknown
+// order: a constant-size commitment to a set.
+//
+// The elements are hashed to primes xᵢ, and the set is accumulated as
+// A = g^(∏ xᵢ). A membership witness of x is w = A^(1/x), the accumulator of
+// the other elements, such that wˣ = A. A non-membership witness of y is a
+// pair (a, B = gᵇ) such that a⋅∏ xᵢ + b⋅y = 1, and then Aᵃ⋅Bʸ = g.
+//
+// The accumulator is generic over the group, which is either ℤₙ* for an RSA
+// modulus N (package rsa), or the class group of an imaginary quadratic order
+// (package classgroup). The order of an RSA group is known by the trusted
+// party who generated the modulus, if any: it can then delete elements and
+// compute witnesses with short exponentiations, see [WithOrder]. Class groups
+// need no trusted setup, their order being unknown to everyone.
+//
+// The additions and deletions are batched in updates, which the holders of
+// witnesses use to update them without knowing the set, and which come with
+// proofs of exponentiation (Wesolowski) so that they are verified with short
+// exponentiations only. The same proofs make the verification of an
+// aggregated membership witness of several elements efficient.
+//
+// See "Batching Techniques for Accumulators with Applications to IOPs and
+// Stateless Blockchains", Boneh, Bünz and Fisch, and "Universal Accumulators
+// with Efficient Nonmembership Proofs", Li, Li and Xue.
+package unknownorder
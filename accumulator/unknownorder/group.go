@@ -0,0 +1,31 @@
+package unknownorder
+
+import "math/big"
+
+// Group is a group of unknown order, whose elements are of type E. The
+// elements are immutable: the methods return new values, and never modify
+// their arguments.
+type Group[E any] interface {
+	// Generator returns the base of the accumulators.
+	Generator() E
+
+	// Mul returns x⋅y.
+	Mul(x, y E) E
+
+	// Exp returns xᵉ, where e may be negative.
+	Exp(x E, e *big.Int) E
+
+	// Equal returns true if x = y.
+	Equal(x, y E) bool
+
+	// IsElement returns true if x is the canonical representation of an
+	// element of the group.
+	IsElement(x E) bool
+
+	// Bytes returns the encoding of the parameters of the group, to which the
+	// proofs of exponentiation are bound.
+	Bytes() []byte
+
+	// ElementBytes returns the encoding of an element.
+	ElementBytes(x E) []byte
+}
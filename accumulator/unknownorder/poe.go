@@ -0,0 +1,44 @@
+package unknownorder
+
+import "math/big"
+
+// ProveExponentiation returns a Wesolowski proof of exponentiation that
+// u^(∏ eᵢ) = w: Q = u^⌊x/ℓ⌋ for x = ∏ eᵢ and ℓ a prime derived from the
+// statement.
+func ProveExponentiation[E any](group Group[E], u E, exponents []*big.Int, w E) E {
+	l := poeChallenge(group, u, exponents, w)
+	q := product(exponents)
+	q.Quo(q, l)
+	return group.Exp(u, q)
+}
+
+// VerifyExponentiation returns true if the proof proves that u^(∏ eᵢ) = w,
+// checking that Q^ℓ⋅uʳ = w with r = ∏ eᵢ mod ℓ, with exponents of the size
+// of ℓ.
+func VerifyExponentiation[E any](group Group[E], u E, exponents []*big.Int, w, proof E) bool {
+	if !group.IsElement(u) || !group.IsElement(w) || !group.IsElement(proof) {
+		return false
+	}
+	for _, e := range exponents {
+		if e.Sign() <= 0 {
+			return false
+		}
+	}
+	l := poeChallenge(group, u, exponents, w)
+	r := big.NewInt(1)
+	for _, e := range exponents {
+		r.Mul(r, e).Mod(r, l)
+	}
+	lhs := group.Mul(group.Exp(proof, l), group.Exp(u, r))
+	return group.Equal(lhs, w)
+}
+
+// poeChallenge returns the prime ℓ, hash of the group and of the statement.
+func poeChallenge[E any](group Group[E], u E, exponents []*big.Int, w E) *big.Int {
+	data := make([][]byte, 0, 3+len(exponents))
+	data = append(data, group.Bytes(), group.ElementBytes(u), group.ElementBytes(w))
+	for _, e := range exponents {
+		data = append(data, e.Bytes())
+	}
+	return hashToPrime(poeDST, data...)
+}
@@ -0,0 +1,61 @@
+package unknownorder
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// primeBits is the size of the primes the elements are hashed to.
+const primeBits = 256
+
+// the domain separation tags of the hashes to primes
+var (
+	elementDST = []byte("UNKNOWN_ORDER_ACCUMULATOR_ELEMENT_V1_")
+	poeDST     = []byte("UNKNOWN_ORDER_ACCUMULATOR_POE_V1_")
+)
+
+// HashToPrime returns the prime of 256 bits to which an element is hashed.
+func HashToPrime(element []byte) *big.Int {
+	return hashToPrime(elementDST, element)
+}
+
+// hashToPrime returns the first prime of primeBits bits among the hashes of
+// the data with a counter: SHA-256(dst ‖ counter ‖ len(d₀) ‖ d₀ ‖ …), with its
+// most and least significant bits set.
+func hashToPrime(dst []byte, data ...[]byte) *big.Int {
+	h := sha256.New()
+	var buf [8]byte
+	res := new(big.Int)
+	for counter := uint64(0); ; counter++ {
+		h.Reset()
+		h.Write(dst)
+		binary.BigEndian.PutUint64(buf[:], counter)
+		h.Write(buf[:])
+		for _, d := range data {
+			binary.BigEndian.PutUint64(buf[:], uint64(len(d)))
+			h.Write(buf[:])
+			h.Write(d)
+		}
+		digest := h.Sum(nil)
+		digest[0] |= 0x80
+		digest[len(digest)-1] |= 1
+		res.SetBytes(digest)
+		if res.ProbablyPrime(20) {
+			return res
+		}
+	}
+}
+
+// product returns ∏ xᵢ, multiplied as a balanced tree so that the operands
+// have similar sizes.
+func product(x []*big.Int) *big.Int {
+	switch len(x) {
+	case 0:
+		return big.NewInt(1)
+	case 1:
+		return new(big.Int).Set(x[0])
+	}
+	res := product(x[:len(x)/2])
+	return res.Mul(res, product(x[len(x)/2:]))
+}
@@ -0,0 +1,66 @@
+package unknownorder
+
+import "math/big"
+
+// UpdateMembershipWitness returns the membership witness of the element after
+// the update, without knowledge of the set:
+//   - after additions, w' = w^(∏ Added)
+//   - after deletions, w' = wᵇ⋅A'ᵃ where a⋅x + b⋅∏ Deleted = 1.
+func UpdateMembershipWitness[E any](group Group[E], element []byte, witness E, update *Update[E]) (E, error) {
+	x := HashToPrime(element)
+	if len(update.Deleted) == 0 {
+		if contains(update.Added, x) {
+			var zero E
+			return zero, ErrExists
+		}
+		return group.Exp(witness, product(update.Added)), nil
+	}
+
+	if contains(update.Deleted, x) {
+		var zero E
+		return zero, ErrNotExists
+	}
+	var a, b big.Int
+	new(big.Int).GCD(&a, &b, x, product(update.Deleted))
+	return group.Mul(group.Exp(witness, &b), group.Exp(update.Value, &a)), nil
+}
+
+// UpdateNonMembershipWitness returns the non-membership witness of the element
+// after the update, without knowledge of the set. With a⋅u + b⋅y = 1 for u the
+// product of the primes of the set, and d the product of the primes of the
+// update:
+//   - after additions, a' = a⋅a₀ and b' = b + a⋅r₀⋅u where a₀⋅d + r₀⋅y = 1, so
+//     that B' = B⋅A^(a⋅r₀)
+//   - after deletions, a' = a⋅d and B' = B
+//
+// and a' is then reduced modulo y, which multiplies B' by a power of A'.
+func UpdateNonMembershipWitness[E any](group Group[E], element []byte, witness *NonMembershipWitness[E], update *Update[E]) (*NonMembershipWitness[E], error) {
+	y := HashToPrime(element)
+	a := new(big.Int)
+	b := witness.B
+	if len(update.Deleted) == 0 {
+		if contains(update.Added, y) {
+			return nil, ErrExists
+		}
+		var a0, r0 big.Int
+		new(big.Int).GCD(&a0, &r0, product(update.Added), y)
+		a.Mul(witness.A, &a0)
+		b = group.Mul(b, group.Exp(update.Previous, r0.Mul(&r0, witness.A)))
+	} else {
+		a.Mul(witness.A, product(update.Deleted))
+	}
+
+	k := reduce(a, y)
+	b = group.Mul(b, group.Exp(update.Value, k))
+	return &NonMembershipWitness[E]{A: a, B: b}, nil
+}
+
+// contains returns true if x is in primes.
+func contains(primes []*big.Int, x *big.Int) bool {
+	for _, p := range primes {
+		if p.Cmp(x) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SRS re-used across tests of the accumulator
+const testSrsSize = 16
+
+var testAlpha = big.NewInt(42)
+var testSrs *kzg.SRS
+
+func init() {
+	testSrs, _ = kzg.NewSRS(testSrsSize, testAlpha)
+}
+
+func elements(start, n int) [][]byte {
+	res := make([][]byte, n)
+	for i := range res {
+		res[i] = []byte(fmt.Sprintf("element %d", start+i))
+	}
+	return res
+}
+
+func TestAccumulator(t *testing.T) {
+	assert := require.New(t)
+
+	acc := New(testSrs.Pk)
+	withTrapdoor := New(testSrs.Pk, WithTrapdoor(testAlpha))
+	vk := testSrs.Vk
+
+	members, others := elements(0, 6), elements(6, 2)
+	_, err := acc.Add(members...)
+	assert.NoError(err)
+	_, err = withTrapdoor.Add(members...)
+	assert.NoError(err)
+	value := acc.Value()
+	assert.True(value.Equal(&withTrapdoor.value))
+	assert.Equal(6, acc.Len())
+
+	for _, e := range members {
+		assert.True(acc.Contains(e))
+		w, err := acc.MembershipWitness(e)
+		assert.NoError(err)
+		assert.True(VerifyMembership(vk, value, e, w))
+		assert.False(VerifyMembership(vk, value, others[0], w))
+
+		w2, err := withTrapdoor.MembershipWitness(e)
+		assert.NoError(err)
+		assert.True(w.Equal(&w2))
+
+		_, err = acc.NonMembershipWitness(e)
+		assert.ErrorIs(err, ErrExists)
+	}
+
+	for _, e := range others {
+		assert.False(acc.Contains(e))
+		w, err := acc.NonMembershipWitness(e)
+		assert.NoError(err)
+		assert.True(VerifyNonMembership(vk, value, e, w))
+		assert.False(VerifyNonMembership(vk, value, members[0], w))
+
+		w2, err := withTrapdoor.NonMembershipWitness(e)
+		assert.NoError(err)
+		assert.Equal(w, w2)
+
+		_, err = acc.MembershipWitness(e)
+		assert.ErrorIs(err, ErrNotExists)
+	}
+
+	// deletions
+	_, err = acc.Delete(members[:2]...)
+	assert.NoError(err)
+	_, err = withTrapdoor.Delete(members[:2]...)
+	assert.NoError(err)
+	value = acc.Value()
+	assert.True(value.Equal(&withTrapdoor.value))
+	w, err := acc.NonMembershipWitness(members[0])
+	assert.NoError(err)
+	assert.True(VerifyNonMembership(vk, value, members[0], w))
+
+	// deleting everything restores the empty accumulator
+	_, err = acc.Delete(members[2:]...)
+	assert.NoError(err)
+	value = acc.Value()
+	assert.True(value.Equal(&testSrs.Pk.G1[0]))
+
+	_, err = acc.Delete(members[0])
+	assert.ErrorIs(err, ErrNotExists)
+	_, err = acc.Add()
+	assert.ErrorIs(err, ErrEmpty)
+	_, err = acc.Add(elements(100, testSrsSize)...)
+	assert.ErrorIs(err, ErrFull)
+}
+
+func TestWitnessUpdates(t *testing.T) {
+	for name, acc := range map[string]*Accumulator{
+		"public":   New(testSrs.Pk),
+		"trapdoor": New(testSrs.Pk, WithTrapdoor(testAlpha)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := require.New(t)
+			vk := testSrs.Vk
+
+			_, err := acc.Add(elements(0, 4)...)
+			assert.NoError(err)
+
+			member, nonMember := []byte("element 1"), []byte("not a member")
+			w, err := acc.MembershipWitness(member)
+			assert.NoError(err)
+			nw, err := acc.NonMembershipWitness(nonMember)
+			assert.NoError(err)
+
+			// the holders follow the updates without knowing the set
+			for _, update := range []func() (*Update, error){
+				func() (*Update, error) { return acc.Add(elements(10, 5)...) },
+				func() (*Update, error) { return acc.Delete(elements(2, 2)...) },
+				func() (*Update, error) { return acc.Delete(elements(0, 1)...) },
+				func() (*Update, error) { return acc.Add(elements(20, 1)...) },
+				func() (*Update, error) { return acc.Delete(elements(10, 3)...) },
+			} {
+				upd, err := update()
+				assert.NoError(err)
+
+				w, err = UpdateMembershipWitness(member, w, upd)
+				assert.NoError(err)
+				assert.True(VerifyMembership(vk, acc.Value(), member, w))
+
+				nw, err = UpdateNonMembershipWitness(nonMember, nw, upd)
+				assert.NoError(err)
+				assert.True(VerifyNonMembership(vk, acc.Value(), nonMember, nw))
+			}
+
+			// the witnesses of the elements in an update can't be updated
+			upd, err := acc.Delete(member)
+			assert.NoError(err)
+			_, err = UpdateMembershipWitness(member, w, upd)
+			assert.ErrorIs(err, ErrNotExists)
+
+			upd, err = acc.Add(nonMember)
+			assert.NoError(err)
+			_, err = UpdateNonMembershipWitness(nonMember, nw, upd)
+			assert.ErrorIs(err, ErrExists)
+		})
+	}
+}
+
+func TestPolynomialDivision(t *testing.T) {
+	assert := require.New(t)
+
+	f := []fr.Element{fr.One()}
+	xs := make([]fr.Element, 5)
+	for i := range xs {
+		xs[i].SetUint64(uint64(3*i + 1))
+		f = mulByXPlus(f, xs[i])
+	}
+	for i := range xs {
+		q, r := divideByXPlus(f, xs[i])
+		assert.True(r.IsZero())
+		assert.Equal(f, mulByXPlus(q, xs[i]))
+	}
+
+	var y fr.Element
+	y.SetUint64(100)
+	q, r := divideByXPlus(f, y)
+	assert.False(r.IsZero())
+	g := mulByXPlus(q, y)
+	g[0].Add(&g[0], &r)
+	assert.Equal(f, g)
+}
@@ -0,0 +1,32 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package accumulator provides a dynamic bilinear-map accumulator, on the KZG
+// SRS.
+//
+// The elements are hashed to scalars xᵢ, and the set is accumulated as the
+// KZG commitment of f(X) = ∏ (X + xᵢ), A = [f(α)]G₁. A membership witness of x
+// is the accumulator of the other elements w = [f(α)/(α + x)]G₁, verified with
+//
+//	e(w, [α + x]G₂) = e(A, G₂)
+//
+// and a non-membership witness of y is the quotient and the non-zero remainder
+// of f by X + y, f = q⋅(X + y) + r, verified with
+//
+//	e([q(α)]G₁, [α + y]G₂)⋅e([r]G₁, G₂) = e(A, G₂).
+//
+// The number of elements is bounded by the size of the SRS. The manager of the
+// accumulator adds and deletes elements by recomputing the commitment, or,
+// if it knows the trapdoor α, with a scalar multiplication. The holders of
+// witnesses update them with the updates it publishes, without knowledge of
+// the set or of the trapdoor.
+//
+// See "Accumulators from Bilinear Pairings and Applications", Nguyen, and
+// "Universal Accumulators with Efficient Nonmembership Proofs", Li, Li and Xue.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package accumulator
@@ -0,0 +1,24 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package bls implements the BLS signature scheme on the bls12-377 curve.
+//
+// The implementation follows the IETF draft draft-irtf-cfrg-bls-signature-05.
+// Two variants are provided as sub-packages:
+//   - minpk: public keys in G1 and signatures in G2 (minimal-pubkey-size);
+//   - minsig: public keys in G2 and signatures in G1 (minimal-signature-size).
+//
+// This package holds what is common to both variants: the key generation
+// procedure and the ciphersuites (basic, message augmentation and
+// proof-of-possession).
+//
+// Documentation:
+//   - IETF draft: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+//   - Hash to curve: https://datatracker.ietf.org/doc/html/rfc9380
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package bls
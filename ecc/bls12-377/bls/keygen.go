@@ -0,0 +1,77 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrShortIKM is returned when the input keying material is too short.
+var ErrShortIKM = errors.New("input keying material must be at least 32 bytes")
+
+const (
+	// minIKMSize is the minimal size of the input keying material.
+	minIKMSize = 32
+	// okmSize is L = ceil((3 * ceil(log2(r))) / 16), the number of bytes
+	// expanded before the reduction modulo r, so that the bias is negligible.
+	okmSize    = (3*fr.Bits + 15) / 16
+	keygenSalt = "BLS-SIG-KEYGEN-SALT-"
+)
+
+// KeyGen derives a secret key from the input keying material ikm and the
+// optional key information keyInfo, as specified in section 2.3 of the IETF
+// draft. ikm must be at least 32 bytes long and must be secret and
+// uniformly random.
+//
+//	salt = "BLS-SIG-KEYGEN-SALT-", SK = 0
+//	while SK == 0:
+//		salt = SHA-256(salt)
+//		PRK = HKDF-Extract(salt, IKM || I2OSP(0, 1))
+//		OKM = HKDF-Expand(PRK, key_info || I2OSP(L, 2), L)
+//		SK = OS2IP(OKM) mod r
+func KeyGen(ikm, keyInfo []byte) (fr.Element, error) {
+	var sk fr.Element
+	if len(ikm) < minIKMSize {
+		return sk, ErrShortIKM
+	}
+
+	secret := make([]byte, len(ikm)+1)
+	copy(secret, ikm)
+	info := make([]byte, len(keyInfo)+2)
+	copy(info, keyInfo)
+	info[len(keyInfo)] = byte(okmSize >> 8)
+	info[len(keyInfo)+1] = byte(okmSize)
+
+	salt := []byte(keygenSalt)
+	okm := make([]byte, okmSize)
+	var b big.Int
+	for sk.IsZero() {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+		prk := hkdf.Extract(sha256.New, secret, salt)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), okm); err != nil {
+			return sk, err
+		}
+		b.SetBytes(okm)
+		sk.SetBigInt(&b)
+	}
+	return sk, nil
+}
+
+// GenerateIKM returns 32 bytes of input keying material read from rand.
+func GenerateIKM(rand io.Reader) ([]byte, error) {
+	ikm := make([]byte, minIKMSize)
+	if _, err := io.ReadFull(rand, ikm); err != nil {
+		return nil, err
+	}
+	return ikm, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKeyGen(t *testing.T) {
+	t.Parallel()
+
+	ikm := bytes.Repeat([]byte{0x2a}, 32)
+
+	sk1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.IsZero() {
+		t.Fatal("secret key should not be zero")
+	}
+
+	// deterministic
+	sk2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sk1.Equal(&sk2) {
+		t.Fatal("KeyGen should be deterministic")
+	}
+
+	// the key information is bound to the secret key
+	sk3, err := KeyGen(ikm, []byte("key info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.Equal(&sk3) {
+		t.Fatal("KeyGen should depend on the key information")
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err != ErrShortIKM {
+		t.Fatal("KeyGen should reject short input keying material")
+	}
+}
+
+func TestCiphersuiteDST(t *testing.T) {
+	t.Parallel()
+
+	const suiteID = "BLS12377G2_XMD:SHA-256_SSWU_RO_"
+	if got := string(ProofOfPossession.SignatureDST(suiteID)); got != "BLS_SIG_"+suiteID+"POP_" {
+		t.Fatal("unexpected signature DST", got)
+	}
+	if got := string(PossessionDST(suiteID)); got != "BLS_POP_"+suiteID+"POP_" {
+		t.Fatal("unexpected proof of possession DST", got)
+	}
+}
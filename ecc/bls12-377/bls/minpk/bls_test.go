@@ -0,0 +1,211 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minpk
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/bls"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestBLS(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 5
+	} else {
+		parameters.MinSuccessfulTests = 20
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] test the signing and verification", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.Public()
+
+			msg := []byte("testing BLS")
+			sig, _ := privKey.Sign(msg, nil)
+			flag, _ := publicKey.Verify(sig, msg, nil)
+
+			return flag
+		},
+	))
+
+	properties.Property("[BLS12-377] test the signing and verification (pre-hashed)", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.Public()
+
+			msg := []byte("testing BLS")
+			hFunc := sha256.New()
+			sig, _ := privKey.Sign(msg, hFunc)
+			flag, _ := publicKey.Verify(sig, msg, hFunc)
+
+			// the signature is of the digest, not of the message
+			raw, _ := publicKey.Verify(sig, msg, nil)
+			digest := sha256.Sum256(msg)
+			prehashed, _ := publicKey.Verify(sig, digest[:], nil)
+
+			return flag && !raw && prehashed
+		},
+	))
+
+	properties.Property("[BLS12-377] a signature should not verify another message", prop.ForAll(
+		func() bool {
+
+			privKey, _ := GenerateKey(rand.Reader)
+			publicKey := privKey.Public()
+
+			sig, _ := privKey.Sign([]byte("testing BLS"), nil)
+			flag, _ := publicKey.Verify(sig, []byte("testing BLS!"), nil)
+
+			return !flag
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestCiphersuites(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("testing BLS ciphersuites")
+
+	suites := []bls.Ciphersuite{bls.Basic, bls.MessageAugmentation, bls.ProofOfPossession}
+	sigs := make([]Signature, len(suites))
+	for i, cs := range suites {
+		sigs[i], err = privKey.SignWith(cs, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ok, err := privKey.PublicKey.VerifyWith(cs, &sigs[i], msg)
+		if err != nil || !ok {
+			t.Fatalf("%s: signature should verify", cs)
+		}
+	}
+
+	// domain separation: a signature must not verify under another ciphersuite
+	for i, cs := range suites {
+		for j := range sigs {
+			if i == j {
+				continue
+			}
+			if ok, _ := privKey.PublicKey.VerifyWith(cs, &sigs[j], msg); ok {
+				t.Fatalf("%s signature verified as %s", suites[j], cs)
+			}
+		}
+	}
+}
+
+func TestProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	privKey1, _ := GenerateKey(rand.Reader)
+	privKey2, _ := GenerateKey(rand.Reader)
+
+	proof, err := privKey1.ProvePossession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := privKey1.PublicKey.VerifyPossession(&proof); err != nil || !ok {
+		t.Fatal("proof of possession should verify")
+	}
+	if ok, _ := privKey2.PublicKey.VerifyPossession(&proof); ok {
+		t.Fatal("proof of possession should not verify for another key")
+	}
+
+	// a proof of possession is not a signature of the serialized public key
+	sig, _ := privKey1.SignWith(bls.ProofOfPossession, privKey1.PublicKey.Bytes())
+	if ok, _ := privKey1.PublicKey.VerifyPossession(&sig); ok {
+		t.Fatal("a signature should not be accepted as a proof of possession")
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	const n = 4
+	privKeys := make([]*PrivateKey, n)
+	publicKeys := make([]PublicKey, n)
+	for i := range privKeys {
+		privKeys[i], _ = GenerateKey(rand.Reader)
+		publicKeys[i] = privKeys[i].PublicKey
+	}
+
+	t.Run("fast aggregate verify", func(t *testing.T) {
+		msg := []byte("same message")
+		sigs := make([]Signature, n)
+		for i := range privKeys {
+			sigs[i], _ = privKeys[i].SignWith(bls.ProofOfPossession, msg)
+		}
+		aggSig, err := Aggregate(sigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := FastAggregateVerify(publicKeys, msg, &aggSig); err != nil || !ok {
+			t.Fatal("aggregate signature should verify")
+		}
+		if ok, _ := FastAggregateVerify(publicKeys[1:], msg, &aggSig); ok {
+			t.Fatal("aggregate signature should not verify for a subset of the signers")
+		}
+		if ok, _ := AggregateVerify(bls.ProofOfPossession, publicKeys, [][]byte{msg, msg, msg, msg}, &aggSig); !ok {
+			t.Fatal("aggregate verification should accept the same messages with proofs of possession")
+		}
+	})
+
+	for _, cs := range []bls.Ciphersuite{bls.Basic, bls.MessageAugmentation, bls.ProofOfPossession} {
+		cs := cs
+		t.Run("aggregate verify "+cs.String(), func(t *testing.T) {
+			msgs := make([][]byte, n)
+			sigs := make([]Signature, n)
+			for i := range privKeys {
+				msgs[i] = []byte{byte(i), 'm'}
+				sigs[i], _ = privKeys[i].SignWith(cs, msgs[i])
+			}
+			aggSig, _ := Aggregate(sigs)
+			if ok, err := AggregateVerify(cs, publicKeys, msgs, &aggSig); err != nil || !ok {
+				t.Fatal("aggregate signature should verify")
+			}
+			msgs[0], msgs[1] = msgs[1], msgs[0]
+			if ok, _ := AggregateVerify(cs, publicKeys, msgs, &aggSig); ok {
+				t.Fatal("aggregate signature should not verify with permuted messages")
+			}
+		})
+	}
+
+	t.Run("basic scheme requires distinct messages", func(t *testing.T) {
+		msg := []byte("same message")
+		sigs := make([]Signature, 2)
+		for i := range sigs {
+			sigs[i], _ = privKeys[i].SignWith(bls.Basic, msg)
+		}
+		aggSig, _ := Aggregate(sigs)
+		if _, err := AggregateVerify(bls.Basic, publicKeys[:2], [][]byte{msg, msg}, &aggSig); err != ErrMessagesNotUnique {
+			t.Fatal("expected ErrMessagesNotUnique")
+		}
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		if _, err := Aggregate(nil); err != ErrEmptyInput {
+			t.Fatal("expected ErrEmptyInput")
+		}
+		if _, err := AggregatePublicKeys(nil); err != ErrEmptyInput {
+			t.Fatal("expected ErrEmptyInput")
+		}
+	})
+}
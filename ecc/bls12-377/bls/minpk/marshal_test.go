@@ -0,0 +1,83 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minpk
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/prop"
+)
+
+func TestSerialization(t *testing.T) {
+
+	t.Parallel()
+	parameters := gopter.DefaultTestParameters()
+	if testing.Short() {
+		parameters.MinSuccessfulTests = 5
+	} else {
+		parameters.MinSuccessfulTests = 20
+	}
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("[BLS12-377] BLS serialization: SetBytes(Bytes()) should stay the same", prop.ForAll(
+		func() bool {
+			privKey, _ := GenerateKey(rand.Reader)
+
+			var end PrivateKey
+			buf := privKey.Bytes()
+			n, err := end.SetBytes(buf[:])
+			if err != nil || n != sizePrivateKey {
+				return false
+			}
+			if !end.PublicKey.Equal(&privKey.PublicKey) || end.scalar != privKey.scalar {
+				return false
+			}
+
+			// signatures are deterministic
+			sig, _ := privKey.Sign([]byte("testing BLS"), nil)
+			var s Signature
+			if n, err := s.SetBytes(sig); err != nil || n != sizeSignature {
+				return false
+			}
+			sigEnd, _ := end.Sign([]byte("testing BLS"), nil)
+			return bytes.Equal(s.Bytes(), sigEnd)
+		},
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestSerializationInvalid(t *testing.T) {
+	t.Parallel()
+
+	var pk PublicKey
+	// the identity is not a valid public key
+	buf := pk.Bytes()
+	if _, err := pk.SetBytes(buf); err == nil {
+		t.Fatal("identity should be rejected")
+	}
+	if _, err := pk.SetBytes(buf[:sizePublicKey-1]); err == nil {
+		t.Fatal("short buffer should be rejected")
+	}
+
+	var sig Signature
+	if _, err := sig.SetBytes(make([]byte, sizeSignature+1)); err != errWrongSize {
+		t.Fatal("expected errWrongSize")
+	}
+
+	privKey, _ := GenerateKey(rand.Reader)
+	buf = privKey.Bytes()
+	for i := sizePublicKey; i < sizePrivateKey; i++ {
+		buf[i] = 0xff
+	}
+	var end PrivateKey
+	if _, err := end.SetBytes(buf); err != errScalarOutOfRange {
+		t.Fatal("expected errScalarOutOfRange")
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package minsig
+
+import (
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/bls"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/signature"
+)
+
+const (
+	sizeFr         = fr.Bytes
+	sizePublicKey  = bls12377.SizeOfG2AffineCompressed
+	sizePrivateKey = sizePublicKey + sizeFr
+	sizeSignature  = bls12377.SizeOfG1AffineCompressed
+)
+
+// SuiteID is the identifier of the hash-to-curve suite used to hash messages
+// to G1.
+const SuiteID = "BLS12377G1_XMD:SHA-256_SSWU_RO_"
+
+var (
+	ErrInvalidPublicKey  = errors.New("invalid public key: identity or not in the subgroup")
+	ErrInvalidSignature  = errors.New("invalid signature: not in the subgroup")
+	ErrEmptyInput        = errors.New("empty list of public keys or signatures")
+	ErrInvalidInputSize  = errors.New("number of public keys and messages mismatch")
+	ErrMessagesNotUnique = errors.New("basic scheme requires distinct messages")
+	ErrNotPossession     = errors.New("fast aggregate verification requires the proof-of-possession ciphersuite")
+)
+
+// PublicKey represents a BLS public key, a point of G2
+type PublicKey struct {
+	A bls12377.G2Affine
+}
+
+// PrivateKey represents a BLS private key
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeFr]byte // secret scalar, in big Endian
+}
+
+// Signature represents a BLS signature, a point of G1
+type Signature struct {
+	S bls12377.G1Affine
+}
+
+// GenerateKey generates a public and private key pair from 32 bytes of input
+// keying material read from rand.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	ikm, err := bls.GenerateIKM(rand)
+	if err != nil {
+		return nil, err
+	}
+	return KeyGen(ikm, nil)
+}
+
+// KeyGen deterministically derives a key pair from the input keying material
+// ikm and the optional key information keyInfo (see [bls.KeyGen]).
+func KeyGen(ikm, keyInfo []byte) (*PrivateKey, error) {
+	sk, err := bls.KeyGen(ikm, keyInfo)
+	if err != nil {
+		return nil, err
+	}
+	var b big.Int
+	sk.BigInt(&b)
+
+	privateKey := new(PrivateKey)
+	b.FillBytes(privateKey.scalar[:])
+	privateKey.PublicKey.A.ScalarMultiplicationBase(&b)
+	return privateKey, nil
+}
+
+// Equal compares 2 public keys
+func (pub *PublicKey) Equal(x signature.PublicKey) bool {
+	xx, ok := x.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pub.A.Equal(&xx.A)
+}
+
+// Public returns the public key associated to the private key.
+func (privKey *PrivateKey) Public() signature.PublicKey {
+	var pub PublicKey
+	pub.A.Set(&privKey.PublicKey.A)
+	return &pub
+}
+
+// Validate implements KeyValidate: it returns an error if the public key is
+// the identity or is not in the prime order subgroup.
+func (pub *PublicKey) Validate() error {
+	if pub.A.IsInfinity() || !pub.A.IsInSubGroup() {
+		return ErrInvalidPublicKey
+	}
+	return nil
+}
+
+// Sign signs a message with the proof-of-possession ciphersuite and returns
+// the serialized signature.
+//
+// If hFunc is not nil, the message is first hashed with hFunc, the digest is
+// then hashed to G1.
+func (privKey *PrivateKey) Sign(message []byte, hFunc hash.Hash) ([]byte, error) {
+	sig, err := privKey.SignWith(bls.ProofOfPossession, prehash(message, hFunc))
+	if err != nil {
+		return nil, err
+	}
+	return sig.Bytes(), nil
+}
+
+// Verify verifies a serialized signature of a message with the
+// proof-of-possession ciphersuite.
+//
+// If hFunc is not nil, the message is first hashed with hFunc, as in
+// [PrivateKey.Sign].
+func (pub *PublicKey) Verify(sigBin, message []byte, hFunc hash.Hash) (bool, error) {
+	var sig Signature
+	if _, err := sig.SetBytes(sigBin); err != nil {
+		return false, err
+	}
+	return pub.VerifyWith(bls.ProofOfPossession, &sig, prehash(message, hFunc))
+}
+
+// SignWith signs a message with the given ciphersuite.
+//
+//	σ = sk ⋅ H(m)
+//
+// where m is prepended with the public key for the message augmentation
+// ciphersuite.
+func (privKey *PrivateKey) SignWith(cs bls.Ciphersuite, message []byte) (Signature, error) {
+	if cs == bls.MessageAugmentation {
+		message = augment(&privKey.PublicKey, message)
+	}
+	return privKey.sign(message, cs.SignatureDST(SuiteID))
+}
+
+// VerifyWith verifies a signature of a message with the given ciphersuite.
+//
+//	e(pk, H(m)) == e(g, σ)
+func (pub *PublicKey) VerifyWith(cs bls.Ciphersuite, sig *Signature, message []byte) (bool, error) {
+	if cs == bls.MessageAugmentation {
+		message = augment(pub, message)
+	}
+	return coreAggregateVerify([]PublicKey{*pub}, [][]byte{message}, sig, cs.SignatureDST(SuiteID))
+}
+
+// ProvePossession returns a proof of possession of the private key, that is
+// a signature of the serialized public key under a dedicated domain
+// separation tag.
+func (privKey *PrivateKey) ProvePossession() (Signature, error) {
+	pkBin := privKey.PublicKey.A.Bytes()
+	return privKey.sign(pkBin[:], bls.PossessionDST(SuiteID))
+}
+
+// VerifyPossession verifies a proof of possession of the private key
+// associated to pub.
+func (pub *PublicKey) VerifyPossession(proof *Signature) (bool, error) {
+	pkBin := pub.A.Bytes()
+	return coreAggregateVerify([]PublicKey{*pub}, [][]byte{pkBin[:]}, proof, bls.PossessionDST(SuiteID))
+}
+
+// Aggregate aggregates signatures into a single signature.
+func Aggregate(signatures []Signature) (Signature, error) {
+	var res Signature
+	if len(signatures) == 0 {
+		return res, ErrEmptyInput
+	}
+	var acc bls12377.G1Jac
+	acc.FromAffine(&signatures[0].S)
+	for i := 1; i < len(signatures); i++ {
+		acc.AddMixed(&signatures[i].S)
+	}
+	res.S.FromJacobian(&acc)
+	return res, nil
+}
+
+// AggregatePublicKeys aggregates public keys into a single public key. It
+// should only be used with public keys whose possession has been proven (see
+// [PublicKey.VerifyPossession]).
+func AggregatePublicKeys(publicKeys []PublicKey) (PublicKey, error) {
+	var res PublicKey
+	if len(publicKeys) == 0 {
+		return res, ErrEmptyInput
+	}
+	var acc bls12377.G2Jac
+	acc.FromAffine(&publicKeys[0].A)
+	for i := 1; i < len(publicKeys); i++ {
+		if err := publicKeys[i].Validate(); err != nil {
+			return res, err
+		}
+		acc.AddMixed(&publicKeys[i].A)
+	}
+	if err := publicKeys[0].Validate(); err != nil {
+		return res, err
+	}
+	res.A.FromJacobian(&acc)
+	return res, nil
+}
+
+// AggregateVerify verifies an aggregate signature of the messages, where
+// messages[i] has been signed by publicKeys[i] with the given ciphersuite.
+//
+//	e(pk₁, H(m₁)) ⋯ e(pkₙ, H(mₙ)) == e(g, σ)
+//
+// For the basic ciphersuite the messages must be distinct.
+func AggregateVerify(cs bls.Ciphersuite, publicKeys []PublicKey, messages [][]byte, sig *Signature) (bool, error) {
+	if len(publicKeys) == 0 {
+		return false, ErrEmptyInput
+	}
+	if len(publicKeys) != len(messages) {
+		return false, ErrInvalidInputSize
+	}
+	switch cs {
+	case bls.Basic:
+		seen := make(map[string]struct{}, len(messages))
+		for _, m := range messages {
+			if _, ok := seen[string(m)]; ok {
+				return false, ErrMessagesNotUnique
+			}
+			seen[string(m)] = struct{}{}
+		}
+	case bls.MessageAugmentation:
+		augmented := make([][]byte, len(messages))
+		for i := range messages {
+			augmented[i] = augment(&publicKeys[i], messages[i])
+		}
+		messages = augmented
+	}
+	return coreAggregateVerify(publicKeys, messages, sig, cs.SignatureDST(SuiteID))
+}
+
+// FastAggregateVerify verifies an aggregate signature of a single message
+// signed by all the public keys, with the proof-of-possession ciphersuite.
+// It costs two pairings regardless of the number of signers.
+func FastAggregateVerify(publicKeys []PublicKey, message []byte, sig *Signature) (bool, error) {
+	aggPk, err := AggregatePublicKeys(publicKeys)
+	if err != nil {
+		return false, err
+	}
+	return coreAggregateVerify([]PublicKey{aggPk}, [][]byte{message}, sig, bls.ProofOfPossession.SignatureDST(SuiteID))
+}
+
+// sign computes sk ⋅ H(message) where H hashes to G1 with the domain
+// separation tag dst.
+func (privKey *PrivateKey) sign(message, dst []byte) (Signature, error) {
+	var res Signature
+	h, err := bls12377.HashToG1(message, dst)
+	if err != nil {
+		return res, err
+	}
+	var s big.Int
+	s.SetBytes(privKey.scalar[:])
+	res.S.ScalarMultiplication(&h, &s)
+	return res, nil
+}
+
+// coreAggregateVerify checks
+//
+//	e(pk₁, H(m₁)) ⋯ e(pkₙ, H(mₙ)) ⋅ e(-g, σ) == 1
+//
+// with a single final exponentiation.
+func coreAggregateVerify(publicKeys []PublicKey, messages [][]byte, sig *Signature, dst []byte) (bool, error) {
+	if !sig.S.IsInSubGroup() {
+		return false, ErrInvalidSignature
+	}
+
+	n := len(publicKeys)
+	P := make([]bls12377.G1Affine, n+1)
+	Q := make([]bls12377.G2Affine, n+1)
+	for i := 0; i < n; i++ {
+		if err := publicKeys[i].Validate(); err != nil {
+			return false, err
+		}
+		h, err := bls12377.HashToG1(messages[i], dst)
+		if err != nil {
+			return false, err
+		}
+		P[i].Set(&h)
+		Q[i].Set(&publicKeys[i].A)
+	}
+	_, _, _, g2 := bls12377.Generators()
+	P[n].Neg(&sig.S)
+	Q[n].Set(&g2)
+
+	return bls12377.PairingCheck(P, Q)
+}
+
+// augment returns pk || message.
+func augment(pub *PublicKey, message []byte) []byte {
+	pkBin := pub.A.Bytes()
+	res := make([]byte, 0, len(pkBin)+len(message))
+	res = append(res, pkBin[:]...)
+	return append(res, message...)
+}
+
+// prehash returns hFunc(message), or message if hFunc is nil.
+func prehash(message []byte, hFunc hash.Hash) []byte {
+	if hFunc == nil {
+		return message
+	}
+	hFunc.Reset()
+	hFunc.Write(message)
+	return hFunc.Sum(nil)
+}
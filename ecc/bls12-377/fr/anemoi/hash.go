@@ -0,0 +1,171 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"errors"
+	stdhash "hash"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/hash"
+)
+
+func init() {
+	hash.RegisterHash(hash.ANEMOI_BLS12_377, func() stdhash.Hash {
+		return NewSponge()
+	})
+}
+
+const (
+	// BlockSize is the number of bytes of an element absorbed by the sponge
+	BlockSize = fr.Bytes
+
+	// DigestSize is the number of elements of a digest
+	DigestSize = 1
+
+	// Rate is the number of elements absorbed by a permutation of the sponge
+	Rate = 1
+)
+
+// sponge is the hash function of the sponge construction over the
+// permutation.
+type sponge struct {
+	h    Hash
+	data []fr.Element // data to hash
+}
+
+// NewSponge returns the sponge hash function over the Anemoi permutation of
+// width 2, with a rate and a capacity of 1 element.
+//
+// The input is a sequence of big endian encoded elements of fr. The
+// number of elements is added to the capacity of the initial state, so that
+// no padding is needed, and the digest is the first DigestSize elements of the
+// final state.
+func NewSponge() hash.StateStorer {
+	return &sponge{h: NewHash()}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *sponge) Reset() {
+	d.data = d.data[:0]
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *sponge) Sum(b []byte) []byte {
+	digest := d.checksum()
+	for i := range digest {
+		bytes := digest[i].Bytes()
+		b = append(b, bytes[:]...)
+	}
+	return b
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *sponge) Size() int {
+	return DigestSize * fr.Bytes
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *sponge) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element.
+//
+// If len(p) is not a multiple of BlockSize and any of the []byte in p represent an integer
+// larger than fr.Modulus, this function returns an error.
+//
+// To hash arbitrary data ([]byte not representing canonical field elements) use fr.Hash first
+func (d *sponge) Write(p []byte) (int, error) {
+	// we usually expect multiple of block size. But sometimes we hash short
+	// values (FS transcript). Instead of forcing to hash to field, we left-pad the
+	// input here.
+	if len(p) > 0 && len(p) < BlockSize {
+		pp := make([]byte, BlockSize)
+		copy(pp[len(pp)-len(p):], p)
+		p = pp
+	}
+
+	var start int
+	for start = 0; start < len(p); start += BlockSize {
+		if start+BlockSize > len(p) {
+			break
+		}
+		if elem, err := fr.BigEndian.Element((*[BlockSize]byte)(p[start : start+BlockSize])); err == nil {
+			d.data = append(d.data, elem)
+		} else {
+			return 0, err
+		}
+	}
+
+	if start != len(p) {
+		return 0, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	return len(p), nil
+}
+
+// WriteElements adds elements to the running hash.
+func (d *sponge) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
+
+// checksum absorbs the data in a fresh state and squeezes the digest.
+func (d *sponge) checksum() [DigestSize]fr.Element {
+	var state [Width]fr.Element
+	state[Rate].SetUint64(uint64(len(d.data)))
+
+	data := d.data
+	for {
+		n := min(Rate, len(data))
+		for i := 0; i < n; i++ {
+			state[i].Add(&state[i], &data[i])
+		}
+		data = data[n:]
+		if err := d.h.Permutation(state[:]); err != nil {
+			panic(err) // can't happen, the state has the width of the permutation
+		}
+		if len(data) == 0 {
+			break
+		}
+	}
+
+	var res [DigestSize]fr.Element
+	copy(res[:], state[:DigestSize])
+	return res
+}
+
+// State returns the internal state of the hasher, the elements written since
+// the last Reset.
+func (d *sponge) State() []byte {
+	res := make([]byte, 0, len(d.data)*fr.Bytes)
+	for i := range d.data {
+		bytes := d.data[i].Bytes()
+		res = append(res, bytes[:]...)
+	}
+	return res
+}
+
+// SetState manually sets the state of the hasher to an user-provided value,
+// previously returned by State.
+func (d *sponge) SetState(newState []byte) error {
+	if len(newState)%fr.Bytes != 0 {
+		return errors.New("the provided newState does not represent a valid state")
+	}
+	data := make([]fr.Element, len(newState)/fr.Bytes)
+	for i := range data {
+		if err := data[i].SetBytesCanonical(newState[i*fr.Bytes : (i+1)*fr.Bytes]); err != nil {
+			return errors.New("the provided newState does not represent a valid state")
+		}
+	}
+	d.data = data
+	return nil
+}
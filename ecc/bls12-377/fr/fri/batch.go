@@ -0,0 +1,604 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+)
+
+var (
+	ErrNoPolynomial = errors.New("no polynomial to prove")
+	ErrDegree       = errors.New("the size of the polynomial is larger than the degree bound")
+	ErrProofShape   = errors.New("the shape of the proof of proximity is invalid")
+	ErrGrinding     = errors.New("the proof of work is invalid")
+)
+
+// FRI is a batched FRI protocol with the parameters of [Parameters]. It proves
+// that polynomials, committed to as their evaluations on a domain of size
+// Blowup times the degree bound, are all of degree less than the degree bound.
+//
+// The polynomials are committed to in the leaves of a single Merkle tree, and
+// combined with a random linear combination. The combination is then folded
+// FoldingFactor to 1 until its degree is less than FoldingFactor, and the
+// final polynomial is sent in the clear.
+type FRI struct {
+	h      hash.Hash
+	params Parameters
+
+	// seed is bound to the transcript, see [WithTranscriptSeed]
+	seed []byte
+
+	// size is the degree bound, a power of 2 at least FoldingFactor, and
+	// nbRounds the number of foldings
+	size     uint64
+	nbRounds int
+
+	// logFolding is log₂(FoldingFactor)
+	logFolding int
+
+	// domains[i] is the evaluation domain of the i-th layer, of size
+	// Blowup⋅size/FoldingFactorⁱ
+	domains []*fft.Domain
+}
+
+// BatchProofOfProximity is a proof that polynomials are of degree less than
+// the degree bound, built by [FRI.BuildProofOfProximity].
+type BatchProofOfProximity struct {
+
+	// Commitments are the Merkle caps of the layers. The i-th layer is the
+	// evaluation of the i-th folded polynomial, and the leaves of the first
+	// layer contain the evaluations of all the polynomials.
+	Commitments [][][]byte
+
+	// FinalPolynomial is the fully folded polynomial, in canonical form.
+	FinalPolynomial []fr.Element
+
+	// Nonce is the proof of work of the prover.
+	Nonce uint64
+
+	// Layers are the openings of the layers at the queries.
+	Layers []LayerOpening
+}
+
+// LayerOpening is the opening of the leaves of a layer at the queries.
+type LayerOpening struct {
+
+	// Values are the values of the opened leaves, in increasing order of the
+	// leaves. The leaf r of a layer on a domain of size n contains the
+	// FoldingFactor values at the positions r + t⋅n/FoldingFactor, of each
+	// polynomial.
+	Values [][]fr.Element
+
+	// MultiProof is the Merkle multi-proof of the leaves.
+	MultiProof [][]byte
+}
+
+// New returns a batched FRI protocol for polynomials of size at most size,
+// with the parameters set by opts. The hash function h is used for the Merkle
+// trees and for Fiat-Shamir.
+func New(size uint64, h hash.Hash, opts ...Option) (*FRI, error) {
+	cfg, err := friOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	params := cfg.Parameters
+	f := &FRI{
+		h:          h,
+		params:     params,
+		seed:       cfg.seed,
+		size:       max(ecc.NextPowerOfTwo(size), uint64(params.FoldingFactor)),
+		logFolding: bits.TrailingZeros(uint(params.FoldingFactor)),
+	}
+	f.nbRounds = bits.TrailingZeros64(f.size) / f.logFolding
+
+	n := f.size * uint64(params.Blowup)
+	f.domains = make([]*fft.Domain, f.nbRounds+1)
+	for i := range f.domains {
+		f.domains[i] = fft.NewDomain(n)
+		n >>= f.logFolding
+	}
+	return f, nil
+}
+
+// Parameters returns the parameters of the protocol.
+func (f *FRI) Parameters() Parameters {
+	return f.params
+}
+
+// BuildProofOfProximity returns a proof that the polynomials, in canonical
+// form, are of size at most the degree bound.
+func (f *FRI) BuildProofOfProximity(polynomials ...[]fr.Element) (*BatchProofOfProximity, error) {
+	if len(polynomials) == 0 {
+		return nil, ErrNoPolynomial
+	}
+	for i := range polynomials {
+		if uint64(len(polynomials[i])) > f.size {
+			return nil, ErrDegree
+		}
+	}
+
+	fs, err := f.newTranscript(len(polynomials))
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &BatchProofOfProximity{
+		Commitments: make([][][]byte, f.nbRounds),
+		Layers:      make([]LayerOpening, f.nbRounds),
+	}
+	layers := make([][][]fr.Element, f.nbRounds)
+	trees := make([]*merkletree.CappedTree, f.nbRounds)
+
+	// the first layer contains the evaluations of all the polynomials, and
+	// is combined with powers of γ
+	layers[0] = make([][]fr.Element, len(polynomials))
+	for j := range polynomials {
+		layers[0][j] = f.evaluate(polynomials[j], 0)
+	}
+	if trees[0], err = f.commit(layers[0], 0); err != nil {
+		return nil, err
+	}
+	proof.Commitments[0] = trees[0].Cap()
+	gamma, err := deriveChallenge(fs, "g", proof.Commitments[0]...)
+	if err != nil {
+		return nil, err
+	}
+
+	p := make([]fr.Element, f.size)
+	for j := len(polynomials) - 1; j >= 0; j-- {
+		for l := range p {
+			p[l].Mul(&p[l], &gamma)
+		}
+		for l := range polynomials[j] {
+			p[l].Add(&p[l], &polynomials[j][l])
+		}
+	}
+
+	for i := 0; i < f.nbRounds; i++ {
+		if i > 0 {
+			layers[i] = [][]fr.Element{f.evaluate(p, i)}
+			if trees[i], err = f.commit(layers[i], i); err != nil {
+				return nil, err
+			}
+			proof.Commitments[i] = trees[i].Cap()
+		}
+		var beta fr.Element
+		if i > 0 {
+			beta, err = deriveChallenge(fs, "x"+strconv.Itoa(i), proof.Commitments[i]...)
+		} else {
+			beta, err = deriveChallenge(fs, "x0")
+		}
+		if err != nil {
+			return nil, err
+		}
+		p = fold(p, beta, f.params.FoldingFactor)
+	}
+	proof.FinalPolynomial = p
+
+	// proof of work
+	seed, err := f.grindingSeed(fs, proof.FinalPolynomial)
+	if err != nil {
+		return nil, err
+	}
+	for !f.checkProofOfWork(seed, proof.Nonce) {
+		proof.Nonce++
+	}
+
+	queries, err := f.deriveQueries(fs, proof.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	for i := range proof.Layers {
+		leaves := f.leafIndices(queries, i)
+		proof.Layers[i].Values = make([][]fr.Element, len(leaves))
+		for l, r := range leaves {
+			proof.Layers[i].Values[l] = f.leaf(layers[i], i, r)
+		}
+		if proof.Layers[i].MultiProof, err = trees[i].ProveMultiple(toUint64(leaves)); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
+
+// VerifyProofOfProximity verifies a proof built by
+// [FRI.BuildProofOfProximity].
+func (f *FRI) VerifyProofOfProximity(proof *BatchProofOfProximity) error {
+	if len(proof.Commitments) != f.nbRounds || len(proof.Layers) != f.nbRounds ||
+		uint64(len(proof.FinalPolynomial)) != f.size>>(f.nbRounds*f.logFolding) ||
+		len(proof.Layers[0].Values) == 0 {
+		return ErrProofShape
+	}
+	k := f.params.FoldingFactor
+	nbPolynomials := len(proof.Layers[0].Values[0]) / k
+	if nbPolynomials == 0 {
+		return ErrProofShape
+	}
+	for i := range proof.Commitments {
+		if len(proof.Commitments[i]) != f.capSize(i) {
+			return ErrProofShape
+		}
+	}
+
+	gamma, betas, queries, err := f.replay(proof, nbPolynomials)
+	if err != nil {
+		return err
+	}
+
+	// check the openings of the layers
+	leaves := make([][]int, f.nbRounds)
+	for i := range leaves {
+		leaves[i] = f.leafIndices(queries, i)
+		opening := &proof.Layers[i]
+		leafSize := k
+		if i == 0 {
+			leafSize *= nbPolynomials
+		}
+		if len(opening.Values) != len(leaves[i]) {
+			return ErrProofShape
+		}
+		data := make([][]byte, len(opening.Values))
+		for l := range opening.Values {
+			if len(opening.Values[l]) != leafSize {
+				return ErrProofShape
+			}
+			data[l] = marshal(opening.Values[l])
+		}
+		if err := f.verifyOpening(i, proof.Commitments[i], leaves[i], data, opening.MultiProof); err != nil {
+			return err
+		}
+	}
+
+	// check the foldings at the queries
+	var kInv fr.Element
+	kInv.SetUint64(uint64(k)).Inverse(&kInv)
+	v := make([]fr.Element, k)
+	for _, q := range queries {
+		var y fr.Element
+		var r int
+		for i := 0; i < f.nbRounds; i++ {
+			nbLeaves := f.nbLeaves(i)
+			r = q % nbLeaves
+			l, _ := slices.BinarySearch(leaves[i], r)
+			values := proof.Layers[i].Values[l]
+			if i == 0 {
+				for t := range v {
+					v[t].SetZero()
+					for j := nbPolynomials - 1; j >= 0; j-- {
+						v[t].Mul(&v[t], &gamma).Add(&v[t], &values[j*k+t])
+					}
+				}
+			} else {
+				// the folded value at the position q mod |domain|
+				if !values[(q%(nbLeaves*k))/nbLeaves].Equal(&y) {
+					return ErrProximityTestFolding
+				}
+				copy(v, values)
+			}
+
+			// x⁻¹ = ω⁻ʳ and ζ⁻¹ = ω^{-|domain|/k}
+			var xInv, zetaInv fr.Element
+			xInv.Exp(f.domains[i].GeneratorInv, big.NewInt(int64(r)))
+			zetaInv.Exp(f.domains[i].GeneratorInv, big.NewInt(int64(nbLeaves)))
+			y = foldFiber(v, xInv, zetaInv, betas[i], kInv)
+		}
+
+		// the last folded value is the final polynomial at ωʳ
+		var x fr.Element
+		x.Exp(f.domains[f.nbRounds].Generator, big.NewInt(int64(r)))
+		if e := evalPolynomial(proof.FinalPolynomial, x); !e.Equal(&y) {
+			return ErrProximityTestFolding
+		}
+	}
+
+	return nil
+}
+
+// Positions returns the positions, in the evaluation domain of the first
+// layer, of the values opened in the first layer: the value of the j-th
+// polynomial at positions[l⋅k+t] is proof.Layers[0].Values[l][j⋅k+t], where k
+// is the folding factor. It lets a caller check the opened values against other
+// commitments, and must be called on a verified proof.
+func (f *FRI) Positions(proof *BatchProofOfProximity) ([]uint64, error) {
+	k := f.params.FoldingFactor
+	if len(proof.Commitments) != f.nbRounds || len(proof.Layers) == 0 || len(proof.Layers[0].Values) == 0 {
+		return nil, ErrProofShape
+	}
+	_, _, queries, err := f.replay(proof, len(proof.Layers[0].Values[0])/k)
+	if err != nil {
+		return nil, err
+	}
+	return f.positions(queries), nil
+}
+
+// replay replays the transcript of the proof, and returns the challenges and
+// the queries.
+func (f *FRI) replay(proof *BatchProofOfProximity, nbPolynomials int) (fr.Element, []fr.Element, []int, error) {
+	var gamma fr.Element
+	fs, err := f.newTranscript(nbPolynomials)
+	if err != nil {
+		return gamma, nil, nil, err
+	}
+	gamma, err = deriveChallenge(fs, "g", proof.Commitments[0]...)
+	if err != nil {
+		return gamma, nil, nil, err
+	}
+	betas := make([]fr.Element, f.nbRounds)
+	for i := range betas {
+		if i > 0 {
+			betas[i], err = deriveChallenge(fs, "x"+strconv.Itoa(i), proof.Commitments[i]...)
+		} else {
+			betas[i], err = deriveChallenge(fs, "x0")
+		}
+		if err != nil {
+			return gamma, nil, nil, err
+		}
+	}
+	seed, err := f.grindingSeed(fs, proof.FinalPolynomial)
+	if err != nil {
+		return gamma, nil, nil, err
+	}
+	if !f.checkProofOfWork(seed, proof.Nonce) {
+		return gamma, nil, nil, ErrGrinding
+	}
+	queries, err := f.deriveQueries(fs, proof.Nonce)
+	return gamma, betas, queries, err
+}
+
+// positions returns the positions in the evaluation domain of the first layer
+// of the values of the leaves opened at the queries, in the order of the
+// values of the leaves.
+func (f *FRI) positions(queries []int) []uint64 {
+	k := f.params.FoldingFactor
+	nbLeaves := f.nbLeaves(0)
+	leaves := f.leafIndices(queries, 0)
+	res := make([]uint64, 0, len(leaves)*k)
+	for _, r := range leaves {
+		for t := 0; t < k; t++ {
+			res = append(res, uint64(r+t*nbLeaves))
+		}
+	}
+	return res
+}
+
+// evaluate returns the evaluation of p on the domain of the i-th layer, in
+// natural order.
+func (f *FRI) evaluate(p []fr.Element, i int) []fr.Element {
+	res := make([]fr.Element, f.domains[i].Cardinality)
+	copy(res, p)
+	f.domains[i].FFT(res, fft.DIF)
+	fft.BitReverse(res)
+	return res
+}
+
+// nbLeaves returns the number of leaves of the tree of the i-th layer.
+func (f *FRI) nbLeaves(i int) int {
+	return int(f.domains[i].Cardinality) / f.params.FoldingFactor
+}
+
+// capSize returns the number of nodes of the cap of the i-th layer.
+func (f *FRI) capSize(i int) int {
+	return min(f.nbLeaves(i), 1<<f.params.CapHeight)
+}
+
+// leaf returns the values of the r-th leaf of the i-th layer, whose
+// evaluations are in layer.
+func (f *FRI) leaf(layer [][]fr.Element, i, r int) []fr.Element {
+	nbLeaves := f.nbLeaves(i)
+	res := make([]fr.Element, 0, len(layer)*f.params.FoldingFactor)
+	for j := range layer {
+		for t := 0; t < f.params.FoldingFactor; t++ {
+			res = append(res, layer[j][r+t*nbLeaves])
+		}
+	}
+	return res
+}
+
+// commit returns the Merkle tree of the i-th layer.
+func (f *FRI) commit(layer [][]fr.Element, i int) (*merkletree.CappedTree, error) {
+	leaves := make([][]byte, f.nbLeaves(i))
+	for r := range leaves {
+		leaves[r] = marshal(f.leaf(layer, i, r))
+	}
+	return merkletree.NewCappedTree(f.h, leaves, f.params.CapHeight)
+}
+
+// verifyOpening checks the multi-proof of the leaves of the i-th layer, of
+// commitment capNodes, at the sorted indices.
+func (f *FRI) verifyOpening(i int, capNodes [][]byte, indices []int, leaves [][]byte, multiProof [][]byte) error {
+	if !merkletree.VerifyCappedMultiProof(f.h, capNodes, leaves, toUint64(indices), multiProof, uint64(f.nbLeaves(i))) {
+		return ErrMerklePath
+	}
+	return nil
+}
+
+// toUint64 returns the indices of leaves as the indices of the merkletree
+// package.
+func toUint64(indices []int) []uint64 {
+	res := make([]uint64, len(indices))
+	for i := range indices {
+		res[i] = uint64(indices[i])
+	}
+	return res
+}
+
+// leafIndices returns the leaves of the i-th layer opened at the queries, in
+// increasing order and without duplicates.
+func (f *FRI) leafIndices(queries []int, i int) []int {
+	nbLeaves := f.nbLeaves(i)
+	res := make([]int, len(queries))
+	for l, q := range queries {
+		res[l] = q % nbLeaves
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+// newTranscript returns the Fiat-Shamir transcript of a proof of proximity of
+// nbPolynomials polynomials, bound to the parameters.
+func (f *FRI) newTranscript(nbPolynomials int) (*fiatshamir.Transcript, error) {
+	// the names of the challenges are shorter than 4 bytes, so that they are
+	// absorbed as a single element by the field-native hash functions
+	challenges := make([]string, 0, f.nbRounds+3)
+	challenges = append(challenges, "g")
+	for i := 0; i < f.nbRounds; i++ {
+		challenges = append(challenges, "x"+strconv.Itoa(i))
+	}
+	challenges = append(challenges, "pow", "q")
+	fs := fiatshamir.NewTranscript(f.h, challenges...)
+
+	var buf [8]byte
+	for _, v := range []uint64{f.size, uint64(nbPolynomials), uint64(f.params.FoldingFactor), uint64(f.params.Blowup),
+		uint64(f.params.NbQueries), uint64(f.params.GrindingBits), uint64(f.params.CapHeight)} {
+		binary.BigEndian.PutUint64(buf[:], v)
+		if err := fs.Bind("g", buf[:]); err != nil {
+			return nil, err
+		}
+	}
+	if len(f.seed) > 0 {
+		if err := fs.Bind("g", f.seed); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// grindingSeed returns the seed of the proof of work, bound to the final
+// polynomial.
+func (f *FRI) grindingSeed(fs *fiatshamir.Transcript, finalPolynomial []fr.Element) ([]byte, error) {
+	for i := range finalPolynomial {
+		if err := fs.Bind("pow", finalPolynomial[i].Marshal()); err != nil {
+			return nil, err
+		}
+	}
+	return fs.ComputeChallenge("pow")
+}
+
+// checkProofOfWork returns true if H(seed ‖ nonce) ends with GrindingBits zero
+// bits. The nonce is encoded as an element, so that field-native hash
+// functions accept it.
+func (f *FRI) checkProofOfWork(seed []byte, nonce uint64) bool {
+	if f.params.GrindingBits == 0 {
+		return true
+	}
+	var e fr.Element
+	e.SetUint64(nonce)
+	d := sum(f.h, seed, e.Marshal())
+	return bits.TrailingZeros64(binary.BigEndian.Uint64(d[len(d)-8:])) >= f.params.GrindingBits
+}
+
+// deriveQueries returns NbQueries positions in [0, |domain₀|/FoldingFactor)
+// derived from the transcript, bound to the nonce.
+func (f *FRI) deriveQueries(fs *fiatshamir.Transcript, nonce uint64) ([]int, error) {
+	var e fr.Element
+	e.SetUint64(nonce)
+	if err := fs.Bind("q", e.Marshal()); err != nil {
+		return nil, err
+	}
+	seed, err := fs.ComputeChallenge("q")
+	if err != nil {
+		return nil, err
+	}
+
+	nbLeaves := uint64(f.nbLeaves(0))
+	res := make([]int, f.params.NbQueries)
+	for i := range res {
+		e.SetUint64(uint64(i))
+		d := sum(f.h, seed, e.Marshal())
+		res[i] = int(binary.BigEndian.Uint64(d[len(d)-8:]) % nbLeaves)
+	}
+	f.h.Reset()
+	return res, nil
+}
+
+// sum returns the hash of the concatenation of data.
+func sum(h hash.Hash, data ...[]byte) []byte {
+	h.Reset()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// deriveChallenge binds data to the challenge name, and returns it.
+func deriveChallenge(fs *fiatshamir.Transcript, name string, data ...[]byte) (fr.Element, error) {
+	var res fr.Element
+	for i := range data {
+		if err := fs.Bind(name, data[i]); err != nil {
+			return res, err
+		}
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
+
+// fold returns the folding of p, in canonical form, by k:
+//
+//	g(Y) = ∑ₜ βᵗ pₜ(Y), where p(X) = ∑ₜ Xᵗ pₜ(Xᵏ)
+func fold(p []fr.Element, beta fr.Element, k int) []fr.Element {
+	res := make([]fr.Element, len(p)/k)
+	for l := range res {
+		for t := k - 1; t >= 0; t-- {
+			res[l].Mul(&res[l], &beta).Add(&res[l], &p[l*k+t])
+		}
+	}
+	return res
+}
+
+// foldFiber returns the value at xᵏ of the folding of p by k, from the values
+// v of p on the fiber {x⋅ζᵗ}, where ζ is a primitive k-th root of unity:
+//
+//	g(xᵏ) = 1/k ∑ₜ vₜ ∑ⱼ (β/(x⋅ζᵗ))ʲ
+func foldFiber(v []fr.Element, xInv, zetaInv, beta, kInv fr.Element) fr.Element {
+	var res, u, s, tmp fr.Element
+	one := fr.One()
+	u.Mul(&beta, &xInv)
+	for t := range v {
+		s.SetZero()
+		for range v {
+			s.Mul(&s, &u).Add(&s, &one)
+		}
+		tmp.Mul(&s, &v[t])
+		res.Add(&res, &tmp)
+		u.Mul(&u, &zetaInv)
+	}
+	return *res.Mul(&res, &kInv)
+}
+
+// evalPolynomial returns p(x), p in canonical form.
+func evalPolynomial(p []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
+
+// marshal returns the concatenation of the encodings of v.
+func marshal(v []fr.Element) []byte {
+	res := make([]byte, 0, len(v)*fr.Bytes)
+	for i := range v {
+		res = append(res, v[i].Marshal()...)
+	}
+	return res
+}
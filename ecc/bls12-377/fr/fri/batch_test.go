@@ -0,0 +1,196 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPolynomials(nbPolynomials, size int) [][]fr.Element {
+	res := make([][]fr.Element, nbPolynomials)
+	for i := range res {
+		res[i] = make([]fr.Element, size)
+		for j := range res[i] {
+			res[i][j].SetRandom()
+		}
+	}
+	return res
+}
+
+func TestBatchFRI(t *testing.T) {
+	for _, k := range []int{2, 4, 8, 16} {
+		for _, capHeight := range []int{0, 2} {
+			for _, size := range []int{16, 32, 100} {
+				t.Run(fmt.Sprintf("k=%d/cap=%d/size=%d", k, capHeight, size), func(t *testing.T) {
+					assert := require.New(t)
+					f, err := New(uint64(size), sha256.New(), WithFoldingFactor(k), WithBlowup(4), WithMerkleCap(capHeight), WithNbQueries(20))
+					assert.NoError(err)
+					for _, nbPolynomials := range []int{1, 3} {
+						polynomials := randomPolynomials(nbPolynomials, size)
+						polynomials[0] = polynomials[0][:size/2]
+						proof, err := f.BuildProofOfProximity(polynomials...)
+						assert.NoError(err)
+						assert.NoError(f.VerifyProofOfProximity(proof))
+
+						// the opened values are the evaluations at the positions
+						positions, err := f.Positions(proof)
+						assert.NoError(err)
+						assert.Len(positions, len(proof.Layers[0].Values)*k)
+						for l, pos := range positions {
+							var x fr.Element
+							x.Exp(f.domains[0].Generator, new(big.Int).SetUint64(pos))
+							for j := range polynomials {
+								assert.Equal(evalPolynomial(polynomials[j], x), proof.Layers[0].Values[l/k][j*k+l%k])
+							}
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestBatchFRITampered(t *testing.T) {
+	assert := require.New(t)
+	const size = 64
+	f, err := New(size, sha256.New(), WithFoldingFactor(4), WithMerkleCap(1))
+	assert.NoError(err)
+
+	_, err = f.BuildProofOfProximity()
+	assert.ErrorIs(err, ErrNoPolynomial)
+	_, err = f.BuildProofOfProximity(randomPolynomials(1, size+1)...)
+	assert.ErrorIs(err, ErrDegree)
+
+	polynomials := randomPolynomials(2, size)
+	proof, err := f.BuildProofOfProximity(polynomials...)
+	assert.NoError(err)
+	assert.NoError(f.VerifyProofOfProximity(proof))
+
+	var one fr.Element
+	one.SetOne()
+	tamper := func(modify func(p *BatchProofOfProximity)) error {
+		tampered := *proof
+		tampered.FinalPolynomial = append([]fr.Element(nil), proof.FinalPolynomial...)
+		tampered.Commitments = append([][][]byte(nil), proof.Commitments...)
+		tampered.Layers = make([]LayerOpening, len(proof.Layers))
+		for i := range proof.Layers {
+			tampered.Layers[i].MultiProof = proof.Layers[i].MultiProof
+			for _, v := range proof.Layers[i].Values {
+				tampered.Layers[i].Values = append(tampered.Layers[i].Values, append([]fr.Element(nil), v...))
+			}
+		}
+		modify(&tampered)
+		return f.VerifyProofOfProximity(&tampered)
+	}
+
+	assert.ErrorIs(tamper(func(p *BatchProofOfProximity) {
+		p.Layers[0].Values[0][1].Add(&p.Layers[0].Values[0][1], &one)
+	}), ErrMerklePath)
+	assert.ErrorIs(tamper(func(p *BatchProofOfProximity) {
+		p.Layers[1].Values[0] = p.Layers[1].Values[0][1:]
+	}), ErrProofShape)
+	assert.ErrorIs(tamper(func(p *BatchProofOfProximity) {
+		p.Layers = p.Layers[1:]
+	}), ErrProofShape)
+	assert.Error(tamper(func(p *BatchProofOfProximity) {
+		p.FinalPolynomial[0].Add(&p.FinalPolynomial[0], &one)
+	}))
+	assert.Error(tamper(func(p *BatchProofOfProximity) {
+		p.Commitments[1] = [][]byte{p.Commitments[1][1], p.Commitments[1][0]}
+	}))
+}
+
+func TestBatchFRITranscriptSeed(t *testing.T) {
+	assert := require.New(t)
+	const size = 32
+	f, err := New(size, sha256.New(), WithTranscriptSeed([]byte("statement")))
+	assert.NoError(err)
+
+	proof, err := f.BuildProofOfProximity(randomPolynomials(2, size)...)
+	assert.NoError(err)
+	assert.NoError(f.VerifyProofOfProximity(proof))
+
+	// the proof is bound to the seed
+	other, err := New(size, sha256.New(), WithTranscriptSeed([]byte("statemenu")))
+	assert.NoError(err)
+	assert.Error(other.VerifyProofOfProximity(proof))
+	other, err = New(size, sha256.New())
+	assert.NoError(err)
+	assert.Error(other.VerifyProofOfProximity(proof))
+}
+
+func TestBatchFRIGrinding(t *testing.T) {
+	assert := require.New(t)
+	const size = 32
+	f, err := New(size, sha256.New(), WithGrinding(8))
+	assert.NoError(err)
+
+	proof, err := f.BuildProofOfProximity(randomPolynomials(2, size)...)
+	assert.NoError(err)
+	assert.NoError(f.VerifyProofOfProximity(proof))
+
+	proof.Nonce++
+	for f.VerifyProofOfProximity(proof) == nil {
+		proof.Nonce++
+	}
+	assert.ErrorIs(f.VerifyProofOfProximity(proof), ErrGrinding)
+}
+
+func TestFRIOptions(t *testing.T) {
+	assert := require.New(t)
+
+	cfg, err := friOptions()
+	assert.NoError(err)
+	assert.Equal(Parameters{FoldingFactor: 2, Blowup: rho, NbQueries: 43}, cfg.Parameters)
+
+	cfg, err = friOptions(WithBlowup(4), WithGrinding(16), WithFoldingFactor(8))
+	assert.NoError(err)
+	assert.Equal(56, cfg.NbQueries)
+
+	cfg, err = friOptions(WithBlowup(16), WithSecurityLevel(100), WithMerkleCap(4))
+	assert.NoError(err)
+	assert.Equal(Parameters{FoldingFactor: 2, Blowup: 16, NbQueries: 25, CapHeight: 4}, cfg.Parameters)
+
+	cfg, err = friOptions(WithSecurityLevel(100), WithNbQueries(3))
+	assert.NoError(err)
+	assert.Equal(3, cfg.NbQueries)
+
+	for _, opt := range []Option{WithFoldingFactor(3), WithFoldingFactor(32), WithBlowup(1), WithBlowup(12), WithGrinding(-1), WithMerkleCap(-1)} {
+		_, err = friOptions(opt)
+		assert.ErrorIs(err, ErrInvalidParameters)
+	}
+}
+
+func BenchmarkBatchFRI(b *testing.B) {
+	const size = 1 << 12
+	polynomials := randomPolynomials(4, size)
+	for _, k := range []int{2, 4, 8, 16} {
+		f, err := New(size, sha256.New(), WithFoldingFactor(k), WithMerkleCap(4))
+		if err != nil {
+			b.Fatal(err)
+		}
+		proof, err := f.BuildProofOfProximity(polynomials...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(fmt.Sprintf("prove/k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = f.BuildProofOfProximity(polynomials...)
+			}
+		})
+		b.Run(fmt.Sprintf("verify/k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = f.VerifyProofOfProximity(proof)
+			}
+		})
+	}
+}
@@ -4,4 +4,13 @@
 // Code generated by consensys/gnark-crypto DO NOT EDIT
 
 // Package fri provides the FRI (multiplicative) commitment scheme.
+//
+// [IOPP] is the radix 2 FRI of a single polynomial, with a fixed blowup factor
+// [GetRho]. [FRI] is a batched FRI of several polynomials, combined with a
+// random linear combination, whose folding factor (2, 4, 8 or 16), blowup
+// factor, number of queries or target security level, proof of work and Merkle
+// caps are set with options.
+//
+// The challenges of [IOPP] and [FRI] are drawn from the field, so the soundness
+// of the protocol is bounded by its size.
 package fri
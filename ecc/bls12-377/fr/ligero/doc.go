@@ -0,0 +1,36 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package ligero provides a hash-based commitment scheme for multilinear
+// polynomials, with the linear-time encoding of Ligero and Brakedown replaced
+// by a Reed-Solomon code.
+//
+// The evaluations of a multilinear polynomial f on the hypercube are laid out
+// as a matrix M of NbColumns columns, so that
+//
+//	f(z) = L(z₁, …, zₖ)ᵀ⋅M⋅R(zₖ₊₁, …, zₙ)
+//
+// where L and R are the tables of eq on the hypercube. Each row of M is
+// encoded with a Reed-Solomon code of rate 1/Blowup, and the commitment is the
+// root of the Merkle tree whose leaves are the columns of the encoded matrix.
+//
+// An opening proof at z contains the row u = LᵀM, from which the verifier
+// computes f(z) = ⟨u, R⟩, and random combinations of the rows of M, which test
+// the proximity of the encoded matrix to the code. The verifier then checks
+// that the encodings of these rows are consistent with the combinations of the
+// columns opened at random positions. The commitment and the proofs are of
+// size O(√N) for a polynomial of size N, and the scheme is transparent and
+// plausibly post-quantum.
+//
+// The polynomials are given by their evaluations on the hypercube, in the
+// order of polynomial.MultiLin, and [Scheme] implements the mlpcs.Scheme
+// interface. The commitments are binding but not hiding.
+//
+// See https://eprint.iacr.org/2022/1608.pdf and https://eprint.iacr.org/2021/1043.pdf.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package ligero
@@ -0,0 +1,542 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ligero
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/bits"
+	"slices"
+	"strconv"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+	"github.com/consensys/gnark-crypto/mlpcs"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (not a power of 2, smaller than the number of columns or not matching the point)")
+	ErrInvalidProof          = errors.New("the shape of the proof doesn't match the digests, the point and the parameters")
+	ErrMerkleProof           = errors.New("the opened columns are not in the committed matrix")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+)
+
+// Digest commitment of a multilinear polynomial, the Merkle root of the
+// columns of its encoded matrix.
+type Digest []byte
+
+// OpeningProof proves that a committed polynomial evaluates to ClaimedValue
+// at a point.
+type OpeningProof struct {
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+
+	// Row is the combination LᵀM of the rows of the matrix
+	Row []fr.Element
+
+	// ProximityRows are the random combinations of the rows of the matrix
+	ProximityRows [][]fr.Element
+
+	// Columns are the columns of the encoded matrix at the queries, in
+	// increasing order of the queries
+	Columns [][]fr.Element
+
+	// MultiProof is the Merkle multi-proof of the columns
+	MultiProof [][]byte
+}
+
+// BatchOpeningProof proves the evaluations of several committed polynomials
+// at the same point.
+type BatchOpeningProof struct {
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+
+	// Rows are the combinations LᵀMᵢ of the rows of the matrices
+	Rows [][]fr.Element
+
+	// ProximityRows are the random combinations of the rows of all the
+	// matrices
+	ProximityRows [][]fr.Element
+
+	// Columns[i] are the columns of the i-th encoded matrix at the queries, in
+	// increasing order of the queries
+	Columns [][][]fr.Element
+
+	// MultiProofs[i] is the Merkle multi-proof of Columns[i]
+	MultiProofs [][][]byte
+}
+
+// Scheme is the commitment scheme with the parameters of [Parameters]. It
+// commits to multilinear polynomials of size a power of 2 at least NbColumns.
+//
+// The prover does not keep a state between the commitment and the opening of
+// a polynomial: [Scheme.Open] and [Scheme.BatchOpen] encode the polynomials
+// again.
+type Scheme struct {
+	h      hash.Hash
+	params Parameters
+
+	// logColumns is log₂(NbColumns), and domain the evaluation domain of the
+	// code, of size Blowup⋅NbColumns
+	logColumns int
+	domain     *fft.Domain
+}
+
+var _ mlpcs.Scheme[fr.Element, Digest, OpeningProof, BatchOpeningProof] = (*Scheme)(nil)
+
+// New returns the commitment scheme of polynomials laid out in matrices of
+// nbColumns columns, a power of 2, using h for the Merkle trees and for
+// Fiat-Shamir.
+func New(nbColumns int, h hash.Hash, opts ...Option) (*Scheme, error) {
+	params, err := ligeroOptions(nbColumns, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Scheme{
+		h:          h,
+		params:     params,
+		logColumns: bits.TrailingZeros(uint(params.NbColumns)),
+		domain:     fft.NewDomain(uint64(params.NbColumns * params.Blowup)),
+	}, nil
+}
+
+// Parameters returns the parameters of the scheme.
+func (s *Scheme) Parameters() Parameters {
+	return s.params
+}
+
+// Commit commits to the multilinear polynomial of evaluations p.
+func (s *Scheme) Commit(p []fr.Element) (Digest, error) {
+	if !s.isValidSize(len(p)) {
+		return nil, ErrInvalidPolynomialSize
+	}
+	t, _, err := s.commit(p)
+	if err != nil {
+		return nil, err
+	}
+	return t.Cap()[0], nil
+}
+
+// Open computes an opening proof of the multilinear polynomial p at point.
+func (s *Scheme) Open(p []fr.Element, point []fr.Element) (OpeningProof, error) {
+	if !s.isValidSize(len(p)) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	t, encoded, err := s.commit(p)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	proof, err := s.batchOpen([][]fr.Element{p}, []*merkletree.CappedTree{t}, [][][]fr.Element{encoded}, []Digest{t.Cap()[0]}, point)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	return OpeningProof{
+		ClaimedValue:  proof.ClaimedValues[0],
+		Row:           proof.Rows[0],
+		ProximityRows: proof.ProximityRows,
+		Columns:       proof.Columns[0],
+		MultiProof:    proof.MultiProofs[0],
+	}, nil
+}
+
+// Verify verifies an opening proof of the polynomial committed to in digest
+// at point.
+func (s *Scheme) Verify(digest *Digest, proof *OpeningProof, point []fr.Element) error {
+	batchProof := BatchOpeningProof{
+		ClaimedValues: []fr.Element{proof.ClaimedValue},
+		Rows:          [][]fr.Element{proof.Row},
+		ProximityRows: proof.ProximityRows,
+		Columns:       [][][]fr.Element{proof.Columns},
+		MultiProofs:   [][][]byte{proof.MultiProof},
+	}
+	return s.BatchVerify([]Digest{*digest}, &batchProof, point)
+}
+
+// BatchOpen creates a batch opening proof at point of a list of polynomials.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * polynomials is the list of polynomials to open, they are supposed to be of the same size.
+// * digests is the list of committed polynomials to open, need to derive the challenges using Fiat Shamir.
+// * point is the point at which the polynomials are opened.
+// * dataTranscript extra data that might be needed to derive the challenges
+func (s *Scheme) BatchOpen(polynomials [][]fr.Element, digests []Digest, point []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	if len(polynomials) == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	if len(digests) != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	trees := make([]*merkletree.CappedTree, len(polynomials))
+	encoded := make([][][]fr.Element, len(polynomials))
+	for i := range polynomials {
+		if !s.isValidSize(len(polynomials[i])) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+		var err error
+		if trees[i], encoded[i], err = s.commit(polynomials[i]); err != nil {
+			return BatchOpeningProof{}, err
+		}
+	}
+	return s.batchOpen(polynomials, trees, encoded, digests, point, dataTranscript...)
+}
+
+func (s *Scheme) batchOpen(polynomials [][]fr.Element, trees []*merkletree.CappedTree, encoded [][][]fr.Element, digests []Digest, point []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	for i := range polynomials {
+		if len(polynomials[i]) != 1<<len(point) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+	logRows := len(point) - s.logColumns
+	left, right := eqTable(point[:logRows]), eqTable(point[logRows:])
+
+	res := BatchOpeningProof{
+		ClaimedValues: make([]fr.Element, len(polynomials)),
+		Rows:          make([][]fr.Element, len(polynomials)),
+		ProximityRows: make([][]fr.Element, s.params.NbProximityTests),
+		Columns:       make([][][]fr.Element, len(polynomials)),
+		MultiProofs:   make([][][]byte, len(polynomials)),
+	}
+	for i := range polynomials {
+		res.Rows[i] = combineRows(polynomials[i], left)
+		res.ClaimedValues[i] = innerProduct(res.Rows[i], right)
+	}
+
+	fs, err := s.newTranscript(digests, point, res.ClaimedValues, dataTranscript...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// the proximity tests combine the rows of all the matrices, stacked
+	nbRows := 1 << logRows
+	for j := range res.ProximityRows {
+		gamma, err := deriveChallenge(fs, proximityChallenge(j))
+		if err != nil {
+			return BatchOpeningProof{}, err
+		}
+		coefficients := powers(gamma, len(polynomials)*nbRows)
+		res.ProximityRows[j] = make([]fr.Element, s.params.NbColumns)
+		for i := range polynomials {
+			row := combineRows(polynomials[i], coefficients[i*nbRows:(i+1)*nbRows])
+			for k := range row {
+				res.ProximityRows[j][k].Add(&res.ProximityRows[j][k], &row[k])
+			}
+		}
+	}
+
+	queries, err := s.deriveQueries(fs, res.Rows, res.ProximityRows)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+	for i := range polynomials {
+		res.Columns[i] = make([][]fr.Element, len(queries))
+		for k, q := range queries {
+			res.Columns[i][k] = column(encoded[i], int(q))
+		}
+		if res.MultiProofs[i], err = trees[i].ProveMultiple(queries); err != nil {
+			return BatchOpeningProof{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// BatchVerify verifies a batch opening proof at point of the polynomials
+// committed to in digests.
+//
+// * digests list of digests on which opening proof is done
+// * proof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenges
+func (s *Scheme) BatchVerify(digests []Digest, proof *BatchOpeningProof, point []fr.Element, dataTranscript ...[]byte) error {
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+	if len(proof.ClaimedValues) != len(digests) {
+		return ErrInvalidNbDigests
+	}
+	if len(point) < s.logColumns || len(point)-s.logColumns >= bits.UintSize-1 {
+		return ErrInvalidPolynomialSize
+	}
+	logRows := len(point) - s.logColumns
+	nbRows := 1 << logRows
+	if len(proof.Rows) != len(digests) || len(proof.Columns) != len(digests) || len(proof.MultiProofs) != len(digests) ||
+		len(proof.ProximityRows) != s.params.NbProximityTests {
+		return ErrInvalidProof
+	}
+	for _, row := range append(slices.Clip(proof.Rows), proof.ProximityRows...) {
+		if len(row) != s.params.NbColumns {
+			return ErrInvalidProof
+		}
+	}
+
+	// derive the challenges of the prover
+	fs, err := s.newTranscript(digests, point, proof.ClaimedValues, dataTranscript...)
+	if err != nil {
+		return err
+	}
+	gammas := make([]fr.Element, s.params.NbProximityTests)
+	for j := range gammas {
+		if gammas[j], err = deriveChallenge(fs, proximityChallenge(j)); err != nil {
+			return err
+		}
+	}
+	queries, err := s.deriveQueries(fs, proof.Rows, proof.ProximityRows)
+	if err != nil {
+		return err
+	}
+
+	// the columns are in the committed matrices. Their size bounds the size
+	// of the tables of the verifier.
+	for i := range digests {
+		if len(proof.Columns[i]) != len(queries) {
+			return ErrInvalidProof
+		}
+		leaves := make([][]byte, len(queries))
+		for k := range leaves {
+			if len(proof.Columns[i][k]) != nbRows {
+				return ErrInvalidProof
+			}
+			leaves[k] = marshalColumn(proof.Columns[i][k])
+		}
+		if !merkletree.VerifyMultiProof(s.h, digests[i], leaves, queries, proof.MultiProofs[i], s.domain.Cardinality) {
+			return ErrMerkleProof
+		}
+	}
+
+	// the encodings of the rows are consistent with the columns
+	for j := range proof.ProximityRows {
+		coefficients := powers(gammas[j], len(digests)*nbRows)
+		encoded := s.encodeRow(proof.ProximityRows[j])
+		for k, q := range queries {
+			var v fr.Element
+			for i := range digests {
+				c := innerProduct(proof.Columns[i][k], coefficients[i*nbRows:(i+1)*nbRows])
+				v.Add(&v, &c)
+			}
+			if !v.Equal(&encoded[q]) {
+				return ErrVerifyOpeningProof
+			}
+		}
+	}
+	left, right := eqTable(point[:logRows]), eqTable(point[logRows:])
+	for i := range digests {
+		encoded := s.encodeRow(proof.Rows[i])
+		for k, q := range queries {
+			if v := innerProduct(proof.Columns[i][k], left); !v.Equal(&encoded[q]) {
+				return ErrVerifyOpeningProof
+			}
+		}
+		if v := innerProduct(proof.Rows[i], right); !v.Equal(&proof.ClaimedValues[i]) {
+			return ErrVerifyOpeningProof
+		}
+	}
+
+	return nil
+}
+
+// isValidSize returns true if a polynomial of size n can be committed to.
+func (s *Scheme) isValidSize(n int) bool {
+	return n >= s.params.NbColumns && bits.OnesCount(uint(n)) == 1
+}
+
+// commit returns the Merkle tree of the columns of the encoded matrix of p,
+// and the encoded rows.
+func (s *Scheme) commit(p []fr.Element) (*merkletree.CappedTree, [][]fr.Element, error) {
+	encoded := make([][]fr.Element, len(p)/s.params.NbColumns)
+	parallel.Execute(len(encoded), func(start, end int) {
+		for i := start; i < end; i++ {
+			encoded[i] = s.encodeRow(p[i*s.params.NbColumns : (i+1)*s.params.NbColumns])
+		}
+	})
+	leaves := make([][]byte, s.domain.Cardinality)
+	for j := range leaves {
+		leaves[j] = marshalColumn(column(encoded, j))
+	}
+	t, err := merkletree.NewCappedTree(s.h, leaves, 0)
+	return t, encoded, err
+}
+
+// encodeRow returns the Reed-Solomon encoding of row, the evaluations on the
+// domain, in natural order, of the polynomial whose coefficients are row.
+func (s *Scheme) encodeRow(row []fr.Element) []fr.Element {
+	res := make([]fr.Element, s.domain.Cardinality)
+	copy(res, row)
+	s.domain.FFT(res, fft.DIF)
+	fft.BitReverse(res)
+	return res
+}
+
+// newTranscript returns the Fiat-Shamir transcript of an opening proof, bound
+// to the parameters, the digests, the point and the claimed values.
+func (s *Scheme) newTranscript(digests []Digest, point, claimedValues []fr.Element, dataTranscript ...[]byte) (*fiatshamir.Transcript, error) {
+	// the names of the challenges are shorter than 4 bytes, so that they are
+	// absorbed as a single element by the field-native hash functions
+	challenges := make([]string, 0, s.params.NbProximityTests+1)
+	for j := 0; j < s.params.NbProximityTests; j++ {
+		challenges = append(challenges, proximityChallenge(j))
+	}
+	challenges = append(challenges, "q")
+	fs := fiatshamir.NewTranscript(s.h, challenges...)
+
+	first := challenges[0]
+	var buf [8]byte
+	for _, v := range []uint64{uint64(len(point)), uint64(len(digests)), uint64(s.params.NbColumns), uint64(s.params.Blowup),
+		uint64(s.params.NbQueries), uint64(s.params.NbProximityTests)} {
+		binary.BigEndian.PutUint64(buf[:], v)
+		if err := fs.Bind(first, buf[:]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range digests {
+		if err := fs.Bind(first, digests[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, elements := range [][]fr.Element{point, claimedValues} {
+		for i := range elements {
+			if err := fs.Bind(first, elements[i].Marshal()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i := range dataTranscript {
+		if err := fs.Bind(first, dataTranscript[i]); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// deriveQueries returns NbQueries columns of the encoded matrices derived
+// from the transcript, bound to the rows, sorted in increasing order and
+// without duplicates.
+func (s *Scheme) deriveQueries(fs *fiatshamir.Transcript, rows, proximityRows [][]fr.Element) ([]uint64, error) {
+	for _, r := range append(slices.Clip(rows), proximityRows...) {
+		for i := range r {
+			if err := fs.Bind("q", r[i].Marshal()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	seed, err := fs.ComputeChallenge("q")
+	if err != nil {
+		return nil, err
+	}
+
+	var e fr.Element
+	res := make([]uint64, s.params.NbQueries)
+	for i := range res {
+		e.SetUint64(uint64(i))
+		d := sum(s.h, seed, e.Marshal())
+		res[i] = binary.BigEndian.Uint64(d[len(d)-8:]) % s.domain.Cardinality
+	}
+	s.h.Reset()
+	slices.Sort(res)
+	return slices.Compact(res), nil
+}
+
+// sum returns the hash of the concatenation of data.
+func sum(h hash.Hash, data ...[]byte) []byte {
+	h.Reset()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// proximityChallenge returns the name of the challenge of the j-th proximity
+// test.
+func proximityChallenge(j int) string {
+	return "p" + strconv.Itoa(j)
+}
+
+// deriveChallenge returns the challenge name.
+func deriveChallenge(fs *fiatshamir.Transcript, name string) (fr.Element, error) {
+	var res fr.Element
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	res.SetBytes(b)
+	return res, nil
+}
+
+// column returns the j-th column of the matrix of rows.
+func column(rows [][]fr.Element, j int) []fr.Element {
+	res := make([]fr.Element, len(rows))
+	for i := range rows {
+		res[i] = rows[i][j]
+	}
+	return res
+}
+
+// marshalColumn returns the leaf of a column, the concatenation of the
+// encodings of its elements.
+func marshalColumn(c []fr.Element) []byte {
+	res := make([]byte, 0, len(c)*fr.Bytes)
+	for i := range c {
+		res = append(res, c[i].Marshal()...)
+	}
+	return res
+}
+
+// eqTable returns the evaluations of eq(q, ⋅) on the hypercube, in the order
+// of polynomial.MultiLin.
+func eqTable(q []fr.Element) []fr.Element {
+	res := make([]fr.Element, 1<<len(q))
+	res[0].SetOne()
+	for i := range q {
+		// res[j] = eq((q₁, …, qᵢ), j) for j < 2ⁱ, extended with qᵢ₊₁
+		for j := (1 << i) - 1; j >= 0; j-- {
+			res[2*j+1].Mul(&res[j], &q[i])
+			res[2*j].Sub(&res[j], &res[2*j+1])
+		}
+	}
+	return res
+}
+
+// combineRows returns LᵀM, where the rows of M are the len(left) chunks of p.
+func combineRows(p, left []fr.Element) []fr.Element {
+	nbColumns := len(p) / len(left)
+	res := make([]fr.Element, nbColumns)
+	parallel.Execute(nbColumns, func(start, end int) {
+		var tmp fr.Element
+		for i := range left {
+			row := p[i*nbColumns : (i+1)*nbColumns]
+			for j := start; j < end; j++ {
+				tmp.Mul(&row[j], &left[i])
+				res[j].Add(&res[j], &tmp)
+			}
+		}
+	})
+	return res
+}
+
+// powers returns 1, x, …, xⁿ⁻¹.
+func powers(x fr.Element, n int) []fr.Element {
+	res := make([]fr.Element, n)
+	res[0].SetOne()
+	for i := 1; i < n; i++ {
+		res[i].Mul(&res[i-1], &x)
+	}
+	return res
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
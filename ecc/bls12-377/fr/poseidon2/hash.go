@@ -0,0 +1,268 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package poseidon2
+
+import (
+	"errors"
+	stdhash "hash"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/hash"
+)
+
+func init() {
+	hash.RegisterHash(hash.POSEIDON2_BLS12_377, func() stdhash.Hash {
+		return NewMerkleDamgardHasher()
+	})
+}
+
+// BlockSize is the number of bytes of an element absorbed by the hashers
+const BlockSize = fr.Bytes
+
+// merkleDamgardHasher is the Merkle-Damgård construction over the compression
+// function of the permutation of width 2.
+type merkleDamgardHasher struct {
+	h     Hash
+	state fr.Element
+	data  []fr.Element // data to hash
+}
+
+// NewMerkleDamgardHasher returns the Merkle-Damgård hash function over the
+// compression function of the default poseidon2 permutation of width 2:
+//
+//	hᵢ₊₁ = Compress(hᵢ, mᵢ) = P(hᵢ ‖ mᵢ)[0] + mᵢ
+//
+// from h₀ = 0, the digest being the last hᵢ.
+//
+// The input is a sequence of big endian encoded elements of fr, as for MiMC.
+func NewMerkleDamgardHasher() hash.StateStorer {
+	h, err := NewDefaultHash(2)
+	if err != nil {
+		panic(err)
+	}
+	return &merkleDamgardHasher{h: h}
+}
+
+// Reset resets the Hash to its initial state.
+func (d *merkleDamgardHasher) Reset() {
+	d.data = d.data[:0]
+	d.state.SetZero()
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *merkleDamgardHasher) Sum(b []byte) []byte {
+	state := d.checksum()
+	bytes := state.Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *merkleDamgardHasher) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *merkleDamgardHasher) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element.
+//
+// If len(p) is not a multiple of BlockSize and any of the []byte in p represent an integer
+// larger than fr.Modulus, this function returns an error.
+//
+// To hash arbitrary data ([]byte not representing canonical field elements) use fr.Hash first
+func (d *merkleDamgardHasher) Write(p []byte) (int, error) {
+	elems, err := parseElements(p)
+	if err != nil {
+		return 0, err
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// WriteElements adds elements to the running hash.
+func (d *merkleDamgardHasher) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
+
+// checksum compresses the data in the current state, and returns it.
+func (d *merkleDamgardHasher) checksum() fr.Element {
+	for i := range d.data {
+		res, err := d.h.Compress([]fr.Element{d.state}, d.data[i:i+1])
+		if err != nil {
+			panic(err) // can't happen, the permutation has width 2
+		}
+		d.state = res[0]
+	}
+	d.data = d.data[:0]
+	return d.state
+}
+
+// State returns the internal state of the hasher, the chaining value of the
+// data written since the last Reset.
+func (d *merkleDamgardHasher) State() []byte {
+	state := d.checksum()
+	bytes := state.Bytes()
+	return bytes[:]
+}
+
+// SetState manually sets the state of the hasher to an user-provided value,
+// previously returned by State.
+func (d *merkleDamgardHasher) SetState(newState []byte) error {
+	d.data = d.data[:0]
+	if err := d.state.SetBytesCanonical(newState); err != nil {
+		return errors.New("the provided newState does not represent a valid state")
+	}
+	return nil
+}
+
+// sponge is the sponge hash function over a default permutation.
+type sponge struct {
+	h    Hash
+	rate int
+	data []fr.Element // data to hash
+}
+
+// NewSponge returns the sponge hash function over the default poseidon2
+// permutation of width rate+capacity, with a rate of rate elements and a
+// capacity of capacity elements.
+//
+// The input is a sequence of big endian encoded elements of fr. The number of
+// elements is added to the capacity of the initial state, so that no padding
+// is needed, and the digest is the first element of the final state.
+func NewSponge(rate, capacity int) (hash.StateStorer, error) {
+	if rate < 1 || capacity < 1 {
+		return nil, errors.New("the rate and the capacity must be positive")
+	}
+	h, err := NewDefaultHash(rate + capacity)
+	if err != nil {
+		return nil, err
+	}
+	return &sponge{h: h, rate: rate}, nil
+}
+
+// Reset resets the Hash to its initial state.
+func (d *sponge) Reset() {
+	d.data = d.data[:0]
+}
+
+// Sum appends the current hash to b and returns the resulting slice.
+// It does not change the underlying hash state.
+func (d *sponge) Sum(b []byte) []byte {
+	digest := d.checksum()
+	bytes := digest.Bytes()
+	return append(b, bytes[:]...)
+}
+
+// Size returns the number of bytes Sum will return.
+func (d *sponge) Size() int {
+	return BlockSize
+}
+
+// BlockSize returns the hash's underlying block size.
+// The Write method must be able to accept any amount
+// of data, but it may operate more efficiently if all writes
+// are a multiple of the block size.
+func (d *sponge) BlockSize() int {
+	return BlockSize
+}
+
+// Write (via the embedded io.Writer interface) adds more data to the running hash.
+//
+// Each []byte block of size BlockSize represents a big endian fr.Element.
+//
+// If len(p) is not a multiple of BlockSize and any of the []byte in p represent an integer
+// larger than fr.Modulus, this function returns an error.
+//
+// To hash arbitrary data ([]byte not representing canonical field elements) use fr.Hash first
+func (d *sponge) Write(p []byte) (int, error) {
+	elems, err := parseElements(p)
+	if err != nil {
+		return 0, err
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// WriteElements adds elements to the running hash.
+func (d *sponge) WriteElements(elems ...fr.Element) {
+	d.data = append(d.data, elems...)
+}
+
+// checksum absorbs the data in a fresh state and squeezes the digest.
+func (d *sponge) checksum() fr.Element {
+	state := make([]fr.Element, d.h.Width())
+	state[d.rate].SetUint64(uint64(len(d.data)))
+
+	data := d.data
+	for {
+		n := min(d.rate, len(data))
+		for i := 0; i < n; i++ {
+			state[i].Add(&state[i], &data[i])
+		}
+		data = data[n:]
+		if err := d.h.Permutation(state); err != nil {
+			panic(err) // can't happen, the state has the width of the permutation
+		}
+		if len(data) == 0 {
+			break
+		}
+	}
+	return state[0]
+}
+
+// State returns the internal state of the hasher, the elements written since
+// the last Reset.
+func (d *sponge) State() []byte {
+	res := make([]byte, 0, len(d.data)*fr.Bytes)
+	for i := range d.data {
+		bytes := d.data[i].Bytes()
+		res = append(res, bytes[:]...)
+	}
+	return res
+}
+
+// SetState manually sets the state of the hasher to an user-provided value,
+// previously returned by State.
+func (d *sponge) SetState(newState []byte) error {
+	data, err := parseElements(newState)
+	if err != nil || len(newState)%fr.Bytes != 0 {
+		return errors.New("the provided newState does not represent a valid state")
+	}
+	d.data = data
+	return nil
+}
+
+// parseElements parses p as a sequence of big endian encoded elements. As
+// short values are hashed as well (FS transcript), instead of forcing to hash
+// to field, an input shorter than BlockSize is left-padded.
+func parseElements(p []byte) ([]fr.Element, error) {
+	if len(p) > 0 && len(p) < BlockSize {
+		pp := make([]byte, BlockSize)
+		copy(pp[len(pp)-len(p):], p)
+		p = pp
+	}
+
+	if len(p)%BlockSize != 0 {
+		return nil, errors.New("invalid input length: must represent a list of field elements, expects a []byte of len m*BlockSize")
+	}
+	res := make([]fr.Element, len(p)/BlockSize)
+	for i := range res {
+		elem, err := fr.BigEndian.Element((*[BlockSize]byte)(p[i*BlockSize : (i+1)*BlockSize]))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = elem
+	}
+	return res, nil
+}
@@ -7,18 +7,26 @@ package poseidon2
 
 import (
 	"errors"
+	"math/big"
+	"sync"
+
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"golang.org/x/crypto/sha3"
 )
 
 var (
 	ErrInvalidSizebuffer = errors.New("the size of the input should match the size of the hash buffer")
+	ErrUnsupportedWidth  = errors.New("unsupported width of the default poseidon2 permutation")
 )
 
 // reference implementation: https://github.com/HorizenLabs/poseidon2/blob/main/plain_implementations/src/poseidon2/poseidon2.rs
 // specifications: https://github.com/argumentcomputer/neptune/blob/main/spec/poseidon_spec.pdf
 // origina paper: https://eprint.iacr.org/2023/323.pdf
 
+// SBoxDegree is the degree d of the s-box x ↦ xᵈ, the smallest d such that
+// gcd(d, r-1) = 1.
+const SBoxDegree = 11
+
 // parameters describing the poseidon2 implementation
 type parameters struct {
 	// len(preimage)+len(digest)=len(preimage)+ceil(log(2*<security_level>/r))
@@ -45,14 +53,58 @@ type Hash struct {
 	params parameters
 }
 
+// defaultInstance is a parameter set of the reference implementation, whose
+// round keys are computed on first use.
+type defaultInstance struct {
+	rF, rP    int
+	once      sync.Once
+	roundKeys [][]fr.Element
+}
+
+// defaultInstances are the instances of the reference implementation for 128
+// bits of security, indexed by width.
+var defaultInstances = map[int]*defaultInstance{
+	2: {rF: 8, rP: 37},
+	3: {rF: 8, rP: 37},
+}
+
 // NewHash returns a new hash instance allowing to apply the poseidon2 permutation
+// of width t, with rf full rounds and rp partial rounds, whose round keys are
+// derived from seed with Keccak256. When t ≥ 4, t must be a multiple of 4 and
+// the diagonal of the internal matrix is derived from seed as well.
 func NewHash(t, rf, rp int, seed string) Hash {
 	params := parameters{t: t, rF: rf, rP: rp}
 	params.roundKeys = InitRC(seed, rf, rp, t)
+	if t >= 4 {
+		params.diagInternalMatrices = initDiagInternalMatrix(seed, t)
+	}
 	res := Hash{params: params}
 	return res
 }
 
+// NewDefaultHash returns the poseidon2 permutation of width t derived as in the
+// reference implementation, with the s-box x ↦ x¹¹ and the numbers of
+// rounds for 128 bits of security. The round keys are generated with the Grain
+// LFSR as in the reference implementation. The supported widths are 2 and 3.
+//
+// The reference implementation has no instance over fr of bls12-377, so the
+// permutations have no known answers to be checked against.
+func NewDefaultHash(t int) (Hash, error) {
+	instance, ok := defaultInstances[t]
+	if !ok {
+		return Hash{}, ErrUnsupportedWidth
+	}
+	instance.once.Do(func() {
+		instance.roundKeys = initRCGrain(t, instance.rF, instance.rP)
+	})
+	return Hash{params: parameters{t: t, rF: instance.rF, rP: instance.rP, roundKeys: instance.roundKeys}}, nil
+}
+
+// Width returns the width of the permutation.
+func (h *Hash) Width() int {
+	return h.params.t
+}
+
 // InitRC initiate round keys. Only one entry is non zero for the internal
 // rounds, cf https://eprint.iacr.org/2023/323.pdf page 9
 func InitRC(seed string, rf, rp, t int) [][]fr.Element {
@@ -65,29 +117,125 @@ func InitRC(seed string, rf, rp, t int) [][]fr.Element {
 	_, _ = hash.Write(rnd)
 
 	roundKeys := make([][]fr.Element, rf+rp)
-	for i := 0; i < rf/2; i++ {
-		roundKeys[i] = make([]fr.Element, t)
-		for j := 0; j < t; j++ {
+	for i := range roundKeys {
+		n := t
+		if i >= rf/2 && i < rf/2+rp {
+			n = 1
+		}
+		roundKeys[i] = make([]fr.Element, n)
+		for j := 0; j < n; j++ {
 			rnd = hash.Sum(nil)
 			roundKeys[i][j].SetBytes(rnd)
 			hash.Reset()
 			_, _ = hash.Write(rnd)
 		}
 	}
-	for i := 0; i < rp; i++ {
-		roundKeys[i] = make([]fr.Element, 1)
-		rnd = hash.Sum(nil)
-		roundKeys[i][0].SetBytes(rnd)
+	return roundKeys
+}
+
+// initDiagInternalMatrix derives the diagonal elements of the internal matrix
+// of width t, minus one, from seed with Keccak256.
+func initDiagInternalMatrix(seed string, t int) []fr.Element {
+	hash := sha3.NewLegacyKeccak256()
+	_, _ = hash.Write([]byte("internal matrix of " + seed))
+	rnd := hash.Sum(nil)
+
+	diag := make([]fr.Element, t)
+	for i := range diag {
 		hash.Reset()
 		_, _ = hash.Write(rnd)
+		rnd = hash.Sum(nil)
+		diag[i].SetBytes(rnd)
 	}
-	for i := 0; i < rf/2; i++ {
-		roundKeys[i] = make([]fr.Element, t)
-		for j := 0; j < t; j++ {
-			rnd = hash.Sum(nil)
-			roundKeys[i][j].SetBytes(rnd)
-			hash.Reset()
-			_, _ = hash.Write(rnd)
+	return diag
+}
+
+// grainLFSR is the Grain LFSR of the reference implementation, which
+// generates the round keys of the default instances.
+type grainLFSR struct {
+	state [80]bool
+	head  int
+}
+
+// newGrainLFSR returns the Grain LFSR initialized with the parameters of the
+// permutation, cf https://eprint.iacr.org/2019/458.pdf appendix E.
+func newGrainLFSR(t, rF, rP int) *grainLFSR {
+	var g grainLFSR
+	i := 0
+	appendBits := func(v, n int) {
+		for j := n - 1; j >= 0; j-- {
+			g.state[i] = (v>>j)&1 == 1
+			i++
+		}
+	}
+	appendBits(1, 2)        // prime field
+	appendBits(0, 4)        // s-box x ↦ xᵈ
+	appendBits(fr.Bits, 12) // size of the field
+	appendBits(t, 12)
+	appendBits(rF, 10)
+	appendBits(rP, 10)
+	appendBits(1<<30-1, 30)
+
+	for j := 0; j < 160; j++ {
+		g.step()
+	}
+	return &g
+}
+
+// step updates the state of the LFSR and returns the new bit.
+func (g *grainLFSR) step() bool {
+	s := func(i int) bool {
+		return g.state[(g.head+i)%80]
+	}
+	b := s(62) != s(51) != s(38) != s(23) != s(13) != s(0)
+	g.state[g.head] = b
+	g.head = (g.head + 1) % 80
+	return b
+}
+
+// bit returns the next output bit of the LFSR, with the self-shrinking
+// mechanism: pairs of bits starting with 0 are discarded.
+func (g *grainLFSR) bit() bool {
+	for !g.step() {
+		g.step()
+	}
+	return g.step()
+}
+
+// element returns the next element, sampled by rejection from fr.Bits big
+// endian bits.
+func (g *grainLFSR) element() fr.Element {
+	var v big.Int
+	modulus := fr.Modulus()
+	for {
+		v.SetUint64(0)
+		for i := 0; i < fr.Bits; i++ {
+			v.Lsh(&v, 1)
+			if g.bit() {
+				v.SetBit(&v, 0, 1)
+			}
+		}
+		if v.Cmp(modulus) < 0 {
+			var res fr.Element
+			res.SetBigInt(&v)
+			return res
+		}
+	}
+}
+
+// initRCGrain generates the round keys of the reference implementation. The
+// partial rounds have a single round key.
+func initRCGrain(t, rf, rp int) [][]fr.Element {
+	g := newGrainLFSR(t, rf, rp)
+	roundKeys := make([][]fr.Element, rf+rp)
+	for i := range roundKeys {
+		n := t
+		if i >= rf/2 && i < rf/2+rp {
+			n = 1
+		}
+		roundKeys[i] = make([]fr.Element, n)
+		for j := range roundKeys[i] {
+			roundKeys[i][j] = g.element()
 		}
 	}
 	return roundKeys
@@ -97,14 +245,13 @@ func InitRC(seed string, rf, rp, t int) [][]fr.Element {
 func (h *Hash) sBox(index int, input []fr.Element) {
 	var tmp fr.Element
 	tmp.Set(&input[index])
-
-	// sbox degree is 17
-	input[index].Square(&input[index]).
+	// sbox degree is 11
+	input[index].
 		Square(&input[index]).
 		Square(&input[index]).
+		Mul(&input[index], &tmp).
 		Square(&input[index]).
 		Mul(&input[index], &tmp)
-
 }
 
 // matMulM4 computes
@@ -169,9 +316,9 @@ func (h *Hash) matMulExternalInPlace(input []fr.Element) {
 		}
 		for i := 0; i < h.params.t/4; i++ {
 			input[4*i].Add(&input[4*i], &tmp[0])
-			input[4*i+1].Add(&input[4*i], &tmp[1])
-			input[4*i+2].Add(&input[4*i], &tmp[2])
-			input[4*i+3].Add(&input[4*i], &tmp[3])
+			input[4*i+1].Add(&input[4*i+1], &tmp[1])
+			input[4*i+2].Add(&input[4*i+2], &tmp[2])
+			input[4*i+3].Add(&input[4*i+3], &tmp[3])
 		}
 	}
 }
@@ -246,3 +393,25 @@ func (h *Hash) Permutation(input []fr.Element) error {
 
 	return nil
 }
+
+// Compress compresses two digests of h.Width()/2 elements into one, with the
+// permutation in feed-forward mode:
+//
+//	Compress(left, right) = P(left ‖ right)[:h.Width()/2] + right
+func (h *Hash) Compress(left, right []fr.Element) ([]fr.Element, error) {
+	n := h.params.t / 2
+	if h.params.t%2 != 0 || len(left) != n || len(right) != n {
+		return nil, ErrInvalidSizebuffer
+	}
+	state := make([]fr.Element, h.params.t)
+	copy(state, left)
+	copy(state[n:], right)
+	if err := h.Permutation(state); err != nil {
+		return nil, err
+	}
+	res := state[:n]
+	for i := range res {
+		res[i].Add(&res[i], &right[i])
+	}
+	return res, nil
+}
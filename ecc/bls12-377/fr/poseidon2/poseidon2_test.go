@@ -6,11 +6,17 @@
 package poseidon2
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/stretchr/testify/require"
 )
 
+var widths = []int{2, 3}
+
 func TestExternalMatrix(t *testing.T) {
 
 	var expected [4][4]fr.Element
@@ -52,6 +58,245 @@ func TestExternalMatrix(t *testing.T) {
 		}
 	}
 
+	// circ(2M4,M4)
+	h = NewHash(8, 8, 56, "seed")
+	var tmp8, e [8]fr.Element
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			tmp8[j].SetUint64(0)
+			if i == j {
+				tmp8[j].SetOne()
+			}
+		}
+		h.matMulExternalInPlace(tmp8[:])
+		for j := 0; j < 8; j++ {
+			e[j] = expected[i%4][j%4]
+			if i/4 == j/4 {
+				e[j].Double(&e[j])
+			}
+		}
+		if tmp8 != e {
+			t.Fatal("error matMulExternal")
+		}
+	}
+}
+
+// matrices returns the external and internal matrices of the permutation
+func matrices(h *Hash) (mE, mI [][]fr.Element) {
+	t := h.params.t
+	mE, mI = make([][]fr.Element, t), make([][]fr.Element, t)
+	for i := 0; i < t; i++ {
+		mE[i], mI[i] = make([]fr.Element, t), make([]fr.Element, t)
+		for j := 0; j < t; j++ {
+			mE[i][j].SetOne()
+			mI[i][j].SetOne()
+		}
+		mE[i][i].SetUint64(2)
+		mI[i][i].SetUint64(2)
+	}
+	// circ(2,1), circ(2,1,1) and [[2,1],[1,3]], [[2,1,1],[1,2,1],[1,1,3]]
+	mI[t-1][t-1].SetUint64(3)
+	return
+}
+
+func matMul(m [][]fr.Element, v []fr.Element) {
+	res := make([]fr.Element, len(v))
+	var tmp fr.Element
+	for i := range m {
+		for j := range v {
+			tmp.Mul(&m[i][j], &v[j])
+			res[i].Add(&res[i], &tmp)
+		}
+	}
+	copy(v, res)
+}
+
+// permutationReference is the permutation with explicit matrices and scalar
+// operations
+func permutationReference(h *Hash, input []fr.Element) {
+	mE, mI := matrices(h)
+	sBox := func(x *fr.Element) {
+		var y fr.Element
+		y.SetOne()
+		for i := 0; i < SBoxDegree; i++ {
+			y.Mul(&y, x)
+		}
+		*x = y
+	}
+	matMul(mE, input)
+	for i := 0; i < h.params.rF+h.params.rP; i++ {
+		partial := i >= h.params.rF/2 && i < h.params.rF/2+h.params.rP
+		for j := range h.params.roundKeys[i] {
+			input[j].Add(&input[j], &h.params.roundKeys[i][j])
+		}
+		if partial {
+			sBox(&input[0])
+			matMul(mI, input)
+		} else {
+			for j := range input {
+				sBox(&input[j])
+			}
+			matMul(mE, input)
+		}
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	assert := require.New(t)
+	for _, width := range widths {
+		h, err := NewDefaultHash(width)
+		assert.NoError(err)
+		assert.Equal(width, h.Width())
+
+		input := randomElements(width)
+		expected := make([]fr.Element, width)
+		copy(expected, input)
+		permutationReference(&h, expected)
+
+		assert.NoError(h.Permutation(input))
+		assert.Equal(expected, input, "width %d", width)
+
+		assert.ErrorIs(h.Permutation(input[1:]), ErrInvalidSizebuffer)
+	}
+
+	_, err := NewDefaultHash(4)
+	assert.ErrorIs(err, ErrUnsupportedWidth)
+}
+
+func TestCompress(t *testing.T) {
+	assert := require.New(t)
+	h, err := NewDefaultHash(2)
+	assert.NoError(err)
+
+	var left, right fr.Element
+	left.SetRandom()
+	right.SetRandom()
+	res, err := h.Compress([]fr.Element{left}, []fr.Element{right})
+	assert.NoError(err)
+
+	state := []fr.Element{left, right}
+	assert.NoError(h.Permutation(state))
+	state[0].Add(&state[0], &right)
+	assert.Equal(state[:1], res)
+
+	_, err = h.Compress([]fr.Element{left, right}, []fr.Element{right})
+	assert.ErrorIs(err, ErrInvalidSizebuffer)
+}
+
+func TestMerkleDamgardHasher(t *testing.T) {
+	assert := require.New(t)
+	elems := randomElements(5)
+
+	h, err := NewDefaultHash(2)
+	assert.NoError(err)
+	var expected fr.Element
+	for i := range elems {
+		res, err := h.Compress([]fr.Element{expected}, []fr.Element{elems[i]})
+		assert.NoError(err)
+		expected = res[0]
+	}
+
+	md := hash.POSEIDON2_BLS12_377.New()
+	assert.Equal(fr.Bytes, md.Size())
+	assert.Equal(hash.POSEIDON2_BLS12_377.Size(), md.Size())
+	for i := range elems {
+		b := elems[i].Bytes()
+		_, err := md.Write(b[:])
+		assert.NoError(err)
+	}
+	expectedBytes := expected.Bytes()
+	assert.Equal(expectedBytes[:], md.Sum(nil))
+
+	// the digest doesn't depend on the splitting of the writes
+	md.Reset()
+	_, err = md.Write(toBytes(elems[:2]))
+	assert.NoError(err)
+	md.(*merkleDamgardHasher).WriteElements(elems[2:]...)
+	assert.Equal(expectedBytes[:], md.Sum(nil))
+
+	// State and SetState
+	md.Reset()
+	_, err = md.Write(toBytes(elems[:3]))
+	assert.NoError(err)
+	state := md.(hash.StateStorer).State()
+	md2 := NewMerkleDamgardHasher()
+	assert.NoError(md2.SetState(state))
+	_, err = md2.Write(toBytes(elems[3:]))
+	assert.NoError(err)
+	assert.Equal(expectedBytes[:], md2.Sum(nil))
+
+	// short inputs are left-padded, and the length must be a multiple of
+	// BlockSize otherwise
+	md.Reset()
+	_, err = md.Write([]byte{1, 2})
+	assert.NoError(err)
+	var short fr.Element
+	short.SetUint64(0x0102)
+	res, err := h.Compress(make([]fr.Element, 1), []fr.Element{short})
+	assert.NoError(err)
+	resBytes := res[0].Bytes()
+	assert.Equal(resBytes[:], md.Sum(nil))
+	_, err = md.Write(make([]byte, BlockSize+1))
+	assert.Error(err)
+}
+
+func TestSponge(t *testing.T) {
+	assert := require.New(t)
+	elems := randomElements(7)
+
+	for _, width := range widths {
+		for capacity := 1; capacity < width; capacity++ {
+			rate := width - capacity
+			s, err := NewSponge(rate, capacity)
+			assert.NoError(err)
+			assert.Equal(fr.Bytes, s.Size())
+
+			// reference absorption
+			h, err := NewDefaultHash(width)
+			assert.NoError(err)
+			state := make([]fr.Element, width)
+			state[rate].SetUint64(uint64(len(elems)))
+			for i := 0; i < len(elems); i += rate {
+				for j := i; j < min(i+rate, len(elems)); j++ {
+					state[j-i].Add(&state[j-i], &elems[j])
+				}
+				assert.NoError(h.Permutation(state))
+			}
+			expected := state[0].Bytes()
+
+			_, err = s.Write(toBytes(elems))
+			assert.NoError(err)
+			assert.Equal(expected[:], s.Sum(nil), fmt.Sprintf("rate %d, capacity %d", rate, capacity))
+
+			// State and SetState
+			s2, err := NewSponge(rate, capacity)
+			assert.NoError(err)
+			assert.NoError(s2.SetState(s.State()))
+			assert.True(bytes.Equal(s.Sum(nil), s2.Sum(nil)))
+		}
+	}
+
+	_, err := NewSponge(3, 1)
+	assert.ErrorIs(err, ErrUnsupportedWidth)
+	_, err = NewSponge(2, 0)
+	assert.Error(err)
+}
+
+func randomElements(n int) []fr.Element {
+	res := make([]fr.Element, n)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func toBytes(elems []fr.Element) []byte {
+	res := make([]byte, 0, len(elems)*fr.Bytes)
+	for i := range elems {
+		b := elems[i].Bytes()
+		res = append(res, b[:]...)
+	}
+	return res
 }
 
 func BenchmarkPoseidon2(b *testing.B) {
@@ -65,3 +310,14 @@ func BenchmarkPoseidon2(b *testing.B) {
 		h.Permutation(tmp[:])
 	}
 }
+
+func BenchmarkMerkleDamgardHasher(b *testing.B) {
+	input := toBytes(randomElements(16))
+	md := NewMerkleDamgardHasher()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		md.Reset()
+		_, _ = md.Write(input)
+		md.Sum(nil)
+	}
+}
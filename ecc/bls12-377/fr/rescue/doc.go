@@ -0,0 +1,24 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package rescue implements the Rescue-Prime Optimized permutation over
+// the scalar field of bls12-377, and a sponge hash function built on it.
+//
+// The permutation has a width of 3 elements, 10 rounds and the s-box
+// x ↦ x^11, for 128 bits of security. The round constants are derived
+// as in the reference implementation from the SHAKE256 stream of the seed
+//
+//	RPO(8444461749428370424248824938781546531375899335154063827935233455917409239041,3,1,128)
+//
+// and the linear layer is the MDS matrix derived from a Vandermonde matrix of
+// the reference implementation of Rescue-Prime.
+//
+// The sponge hash function ([NewSponge]) is registered as hash.RESCUE_BLS12_377,
+// and has a rate of 2 elements and digests of 1 element.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package rescue
@@ -0,0 +1,178 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rescue
+
+import (
+	"bytes"
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func randomState(t int) []fr.Element {
+	res := make([]fr.Element, t)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+func TestSBox(t *testing.T) {
+	assert := require.New(t)
+	NewHash()
+	for _, x := range randomState(10) {
+		y := x
+		sBox(&y)
+		var expected fr.Element
+		expected.SetOne()
+		for i := 0; i < Alpha; i++ {
+			expected.Mul(&expected, &x)
+		}
+		assert.True(expected.Equal(&y))
+		sBoxInv(&y)
+		assert.True(x.Equal(&y))
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	assert := require.New(t)
+	h := NewHash()
+
+	// the permutation is invertible round by round
+	input := randomState(Width)
+	state := append([]fr.Element(nil), input...)
+	assert.NoError(h.Permutation(state))
+	assert.NotEqual(input, state)
+
+	var mInv [Width][Width]fr.Element
+	invertMDS(&mInv)
+	for r := NbRounds - 1; r >= 0; r-- {
+		for i := range state {
+			sBox(&state[i])
+		}
+		subRoundKey(2*r+1, state)
+		matMul(&mInv, state)
+		for i := range state {
+			sBoxInv(&state[i])
+		}
+		subRoundKey(2*r, state)
+		matMul(&mInv, state)
+	}
+	assert.Equal(input, state)
+
+	assert.ErrorIs(h.Permutation(input[1:]), ErrInvalidSizebuffer)
+}
+
+func subRoundKey(i int, state []fr.Element) {
+	for j := range state {
+		state[j].Sub(&state[j], &roundKeys[i][j])
+	}
+}
+
+func matMul(m *[Width][Width]fr.Element, state []fr.Element) {
+	var res [Width]fr.Element
+	var tmp fr.Element
+	for i := range res {
+		for j := range state {
+			tmp.Mul(&m[i][j], &state[j])
+			res[i].Add(&res[i], &tmp)
+		}
+	}
+	copy(state, res[:])
+}
+
+// invertMDS sets res to the inverse of the MDS matrix, with Gauss-Jordan
+// elimination
+func invertMDS(res *[Width][Width]fr.Element) {
+	a := mds
+	for i := range res {
+		res[i][i].SetOne()
+	}
+	var tmp fr.Element
+	for c := 0; c < Width; c++ {
+		pivot := c
+		for a[pivot][c].IsZero() {
+			pivot++
+		}
+		a[c], a[pivot] = a[pivot], a[c]
+		res[c], res[pivot] = res[pivot], res[c]
+		var inv fr.Element
+		inv.Inverse(&a[c][c])
+		for j := 0; j < Width; j++ {
+			a[c][j].Mul(&a[c][j], &inv)
+			res[c][j].Mul(&res[c][j], &inv)
+		}
+		for i := 0; i < Width; i++ {
+			if i == c {
+				continue
+			}
+			f := a[i][c]
+			for j := 0; j < Width; j++ {
+				tmp.Mul(&f, &a[c][j])
+				a[i][j].Sub(&a[i][j], &tmp)
+				tmp.Mul(&f, &res[c][j])
+				res[i][j].Sub(&res[i][j], &tmp)
+			}
+		}
+	}
+}
+
+func TestSponge(t *testing.T) {
+	assert := require.New(t)
+
+	assert.True(hash.RESCUE_BLS12_377.Available())
+	h := hash.RESCUE_BLS12_377.New()
+	assert.Equal(hash.RESCUE_BLS12_377.Size(), h.Size())
+
+	var digests [][]byte
+	for _, n := range []int{0, 1, Rate - 1, Rate, Rate + 1, 3 * Rate} {
+		elems := randomState(n)
+		var buf []byte
+		for i := range elems {
+			b := elems[i].Bytes()
+			buf = append(buf, b[:]...)
+		}
+
+		h.Reset()
+		_, err := h.Write(buf)
+		assert.NoError(err)
+		digest := h.Sum(nil)
+		assert.Equal(h.Size(), len(digest))
+		assert.Equal(digest, h.Sum(nil), "Sum should not change the state")
+
+		// writes can be split
+		h.Reset()
+		for i := 0; i < len(buf); i += fr.Bytes {
+			_, err = h.Write(buf[i : i+fr.Bytes])
+			assert.NoError(err)
+		}
+		assert.Equal(digest, h.Sum(nil))
+
+		// the state can be stored and restored
+		s := NewSponge()
+		assert.NoError(s.SetState(h.(hash.StateStorer).State()))
+		assert.Equal(digest, s.Sum(nil))
+
+		for _, d := range digests {
+			assert.False(bytes.Equal(d, digest))
+		}
+		digests = append(digests, digest)
+	}
+
+	_, err := h.Write(make([]byte, fr.Bytes+1))
+	assert.Error(err)
+}
+
+func BenchmarkRescue(b *testing.B) {
+	h := NewHash()
+	input := randomState(Width)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = h.Permutation(input)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"errors"
+	"math/bits"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+)
+
+var (
+	ErrInvalidAIR            = errors.New("invalid AIR")
+	ErrTraceShape            = errors.New("the trace does not have the shape of the AIR")
+	ErrUnsatisfiedConstraint = errors.New("the trace does not satisfy the constraints of the AIR")
+)
+
+// AIR is an algebraic intermediate representation of a computation: an
+// execution trace of NbColumns columns and NbRows rows, such that each pair of
+// consecutive rows satisfies the transition constraints, and whose cells
+// satisfy the boundary constraints.
+type AIR struct {
+
+	// NbColumns is the number of columns of the trace.
+	NbColumns int
+
+	// NbRows is the number of rows of the trace, a power of 2.
+	NbRows int
+
+	// NbTransitionConstraints is the number of transition constraints.
+	NbTransitionConstraints int
+
+	// TransitionDegree is the maximum total degree of the transition
+	// constraints, as polynomials in the cells of the two rows.
+	TransitionDegree int
+
+	// Transition evaluates the transition constraints on two consecutive rows
+	// current and next, and stores the results in res, of size
+	// NbTransitionConstraints. The constraints hold when all the results are
+	// zero. They are enforced on the rows (i, i+1) for i < NbRows-1.
+	Transition func(res, current, next []fr.Element)
+
+	// Boundaries are the boundary constraints, which are part of the statement
+	// of the proof.
+	Boundaries []Boundary
+}
+
+// Boundary constrains the cell of the trace at (Column, Row) to be equal to
+// Value.
+type Boundary struct {
+	Column, Row int
+	Value       fr.Element
+}
+
+// check checks that the parameters of the AIR are consistent.
+func (air *AIR) check() error {
+	if air.NbColumns < 1 || air.NbRows < 2 || bits.OnesCount(uint(air.NbRows)) != 1 {
+		return ErrInvalidAIR
+	}
+	if air.NbTransitionConstraints < 0 || air.TransitionDegree < 1 {
+		return ErrInvalidAIR
+	}
+	if air.NbTransitionConstraints > 0 && air.Transition == nil {
+		return ErrInvalidAIR
+	}
+	for _, b := range air.Boundaries {
+		if b.Column < 0 || b.Column >= air.NbColumns || b.Row < 0 || b.Row >= air.NbRows {
+			return ErrInvalidAIR
+		}
+	}
+	return nil
+}
+
+// Check returns an error if trace, given as a list of columns, does not
+// satisfy the constraints of the AIR.
+func (air *AIR) Check(trace [][]fr.Element) error {
+	if err := air.check(); err != nil {
+		return err
+	}
+	if len(trace) != air.NbColumns {
+		return ErrTraceShape
+	}
+	for i := range trace {
+		if len(trace[i]) != air.NbRows {
+			return ErrTraceShape
+		}
+	}
+
+	res := make([]fr.Element, air.NbTransitionConstraints)
+	current, next := make([]fr.Element, air.NbColumns), make([]fr.Element, air.NbColumns)
+	for i := 0; i < air.NbRows-1 && air.NbTransitionConstraints > 0; i++ {
+		for j := range trace {
+			current[j].Set(&trace[j][i])
+			next[j].Set(&trace[j][i+1])
+		}
+		air.Transition(res, current, next)
+		for k := range res {
+			if !res[k].IsZero() {
+				return ErrUnsatisfiedConstraint
+			}
+		}
+	}
+	for _, b := range air.Boundaries {
+		if !trace[b.Column][b.Row].Equal(&b.Value) {
+			return ErrUnsatisfiedConstraint
+		}
+	}
+	return nil
+}
+
+// nbSegments returns the number of polynomials of degree < NbRows of the
+// composition polynomial. The transition constraints divided by their
+// vanishing polynomial are of degree < (TransitionDegree-1)⋅NbRows, and the
+// boundary constraints divided by theirs of degree < NbRows.
+func (air *AIR) nbSegments() int {
+	return max(1, air.TransitionDegree-1)
+}
+
+// evaluateConstraints returns the random linear combination with the powers of
+// α of the constraints divided by their vanishing polynomials at a point x,
+// from the rows current and next of the trace at x and g⋅x:
+//
+//	∑ᵢ αⁱ⋅tᵢ(current, next)⋅(x - gⁿ⁻¹)/(xⁿ - 1) + ∑ⱼ αᵐ⁺ʲ⋅(current[cⱼ] - vⱼ)/(x - g^{rⱼ})
+//
+// where transitionFactor = (x - gⁿ⁻¹)/(xⁿ - 1), boundaryFactors[j] = 1/(x - g^{rⱼ})
+// and m is the number of transition constraints. scratch is a buffer of size
+// NbTransitionConstraints.
+func (air *AIR) evaluateConstraints(current, next []fr.Element, transitionFactor fr.Element, boundaryFactors []fr.Element, alpha fr.Element, scratch []fr.Element) fr.Element {
+	var res, coeff, tmp, value fr.Element
+	coeff.SetOne()
+	if air.NbTransitionConstraints > 0 {
+		air.Transition(scratch, current, next)
+		for i := range scratch {
+			tmp.Mul(&scratch[i], &coeff)
+			res.Add(&res, &tmp)
+			coeff.Mul(&coeff, &alpha)
+		}
+		res.Mul(&res, &transitionFactor)
+	}
+	for j, b := range air.Boundaries {
+		value.Set(&b.Value)
+		tmp.Sub(&current[b.Column], &value).
+			Mul(&tmp, &boundaryFactors[j]).
+			Mul(&tmp, &coeff)
+		res.Add(&res, &tmp)
+		coeff.Mul(&coeff, &alpha)
+	}
+	return res
+}
@@ -0,0 +1,278 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fri"
+	"github.com/stretchr/testify/require"
+)
+
+// fibonacci returns the AIR of the Fibonacci sequence of n terms starting
+// from (1, 1), on 2 columns (a, b) with the transition (a, b) → (b, a+b), and
+// its trace.
+func fibonacci(n int) (*AIR, [][]fr.Element) {
+	trace := [][]fr.Element{make([]fr.Element, n), make([]fr.Element, n)}
+	trace[0][0].SetOne()
+	trace[1][0].SetOne()
+	for i := 1; i < n; i++ {
+		trace[0][i] = trace[1][i-1]
+		trace[1][i].Add(&trace[0][i-1], &trace[1][i-1])
+	}
+
+	air := &AIR{
+		NbColumns:               2,
+		NbRows:                  n,
+		NbTransitionConstraints: 2,
+		TransitionDegree:        1,
+		Transition: func(res, current, next []fr.Element) {
+			res[0].Sub(&next[0], &current[1])
+			res[1].Add(&current[0], &current[1]).Sub(&next[1], &res[1])
+		},
+		Boundaries: []Boundary{
+			{Column: 0, Row: 0, Value: trace[0][0]},
+			{Column: 1, Row: 0, Value: trace[1][0]},
+			{Column: 1, Row: n - 1, Value: trace[1][n-1]},
+		},
+	}
+	return air, trace
+}
+
+// hashChain returns the AIR of n-1 iterations of x ↦ x³ + c from x₀, on one
+// column, and its trace.
+func hashChain(n int, x0 fr.Element) (*AIR, [][]fr.Element) {
+	var c fr.Element
+	c.SetUint64(42)
+	round := func(x fr.Element) fr.Element {
+		var res fr.Element
+		res.Square(&x).Mul(&res, &x).Add(&res, &c)
+		return res
+	}
+
+	trace := [][]fr.Element{make([]fr.Element, n)}
+	trace[0][0] = x0
+	for i := 1; i < n; i++ {
+		trace[0][i] = round(trace[0][i-1])
+	}
+
+	var cE fr.Element
+	cE.Set(&c)
+	air := &AIR{
+		NbColumns:               1,
+		NbRows:                  n,
+		NbTransitionConstraints: 1,
+		TransitionDegree:        3,
+		Transition: func(res, current, next []fr.Element) {
+			var r fr.Element
+			r.Square(&current[0]).Mul(&r, &current[0]).Add(&r, &cE)
+			res[0].Sub(&next[0], &r)
+		},
+		Boundaries: []Boundary{
+			{Column: 0, Row: 0, Value: x0},
+			{Column: 0, Row: n - 1, Value: trace[0][n-1]},
+		},
+	}
+	return air, trace
+}
+
+func TestFibonacci(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(64)
+
+	proof, err := Prove(air, trace, sha256.New())
+	assert.NoError(err)
+	assert.NoError(Verify(air, &proof, sha256.New()))
+
+	// wrong statement
+	air.Boundaries[2].Value.SetUint64(1)
+	assert.Error(Verify(air, &proof, sha256.New()))
+
+	// the prover can't prove it
+	_, err = Prove(air, trace, sha256.New())
+	assert.ErrorIs(err, ErrUnsatisfiedConstraint)
+}
+
+func TestHashChain(t *testing.T) {
+	assert := require.New(t)
+	var x0 fr.Element
+	x0.SetRandom()
+	air, trace := hashChain(32, x0)
+	assert.Equal(2, air.nbSegments())
+
+	proof, err := Prove(air, trace, sha256.New(), WithNbQueries(8))
+	assert.NoError(err)
+	assert.NoError(Verify(air, &proof, sha256.New(), WithNbQueries(8)))
+	assert.ErrorIs(Verify(air, &proof, sha256.New()), fri.ErrProofShape)
+
+	// wrong trace
+	trace[0][5].SetRandom()
+	_, err = Prove(air, trace, sha256.New())
+	assert.ErrorIs(err, ErrUnsatisfiedConstraint)
+}
+
+func TestBlowup(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(32)
+
+	proof, err := Prove(air, trace, sha256.New(), WithBlowup(4))
+	assert.NoError(err)
+	assert.NoError(Verify(air, &proof, sha256.New(), WithBlowup(4)))
+	assert.Error(Verify(air, &proof, sha256.New()))
+
+	_, err = Prove(air, trace, sha256.New(), WithBlowup(6))
+	assert.ErrorIs(err, fri.ErrInvalidParameters)
+	assert.ErrorIs(Verify(air, &proof, sha256.New(), WithBlowup(1)), fri.ErrInvalidParameters)
+}
+
+func TestProofOfProximityBinding(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(16)
+	h := sha256.New()
+	cfg := options()
+
+	proof, err := Prove(air, trace, h)
+	assert.NoError(err)
+	verifyProofOfProximity := func(air *AIR, proof *Proof) error {
+		_, _, _, seed, err := replay(air, proof, h)
+		assert.NoError(err)
+		iopp, err := cfg.newFRI(uint64(air.NbRows), h, seed)
+		assert.NoError(err)
+		return iopp.VerifyProofOfProximity(proof.ProofOfProximity)
+	}
+	assert.NoError(verifyProofOfProximity(air, &proof))
+
+	// the proof of proximity is bound to the commitments of the trace
+	other := proof
+	other.TraceRoot = append([]byte(nil), proof.TraceRoot...)
+	other.TraceRoot[0] ^= 1
+	assert.Error(verifyProofOfProximity(air, &other))
+
+	// and to the statement
+	otherAIR := *air
+	otherAIR.Boundaries = append([]Boundary(nil), air.Boundaries...)
+	otherAIR.Boundaries[2].Value.SetUint64(1)
+	assert.Error(verifyProofOfProximity(&otherAIR, &proof))
+}
+
+func TestTamperedProof(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(16)
+	h := sha256.New()
+
+	proof, err := Prove(air, trace, h)
+	assert.NoError(err)
+	assert.NoError(Verify(air, &proof, h))
+
+	var one fr.Element
+	one.SetOne()
+	var oneE fr.Element
+	oneE.SetOne()
+
+	// out-of-domain evaluations
+	proof.CompositionAtZ[0].Add(&proof.CompositionAtZ[0], &oneE)
+	assert.ErrorIs(Verify(air, &proof, h), ErrOutOfDomainCheck)
+	proof.CompositionAtZ[0].Sub(&proof.CompositionAtZ[0], &oneE)
+
+	// openings
+	q := &proof.Queries[3]
+	q.Trace.Values[1].Add(&q.Trace.Values[1], &one)
+	assert.ErrorIs(Verify(air, &proof, h), ErrMerklePath)
+	q.Trace.Values[1].Sub(&q.Trace.Values[1], &one)
+
+	// commitments
+	proof.TraceRoot[0] ^= 1
+	assert.Error(Verify(air, &proof, h))
+	proof.TraceRoot[0] ^= 1
+
+	// opened values of the DEEP composition polynomial
+	v := &proof.ProofOfProximity.Layers[0].Values[1][0]
+	v.Add(v, &one)
+	assert.Error(Verify(air, &proof, h))
+	v.Sub(v, &one)
+
+	assert.NoError(Verify(air, &proof, h))
+}
+
+func TestForgedTrace(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(16)
+
+	// a trace which does not satisfy the transition constraints, proven
+	// without the checks of Prove
+	trace[0][7].SetUint64(7)
+	assert.ErrorIs(air.Check(trace), ErrUnsatisfiedConstraint)
+	proof, err := prove(air, trace, sha256.New(), options())
+	assert.NoError(err)
+	assert.ErrorIs(Verify(air, &proof, sha256.New()), ErrOutOfDomainCheck)
+}
+
+func TestAIR(t *testing.T) {
+	assert := require.New(t)
+	air, trace := fibonacci(16)
+	assert.NoError(air.Check(trace))
+
+	assert.ErrorIs(air.Check(trace[:1]), ErrTraceShape)
+	assert.ErrorIs(air.Check([][]fr.Element{trace[0], trace[1][1:]}), ErrTraceShape)
+
+	air.NbRows = 12
+	assert.ErrorIs(air.Check(trace), ErrInvalidAIR)
+	air.NbRows = 16
+	air.Boundaries = append(air.Boundaries, Boundary{Column: 2})
+	assert.ErrorIs(air.Check(trace), ErrInvalidAIR)
+}
+
+func TestDivideByLinear(t *testing.T) {
+	assert := require.New(t)
+	p := make([]fr.Element, 9)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	var a, x fr.Element
+	a.SetRandom()
+	x.SetRandom()
+
+	// (p(X) - p(a))/(X - a) at x
+	pa := evalExtensionPolynomial(p, a)
+	px := evalExtensionPolynomial(p, x)
+	var expected, tmp fr.Element
+	expected.Sub(&px, &pa)
+	tmp.Sub(&x, &a).Inverse(&tmp)
+	expected.Mul(&expected, &tmp)
+
+	divideByLinear(p, a)
+	assert.True(p[len(p)-1].IsZero())
+	q := evalExtensionPolynomial(p, x)
+	assert.True(expected.Equal(&q))
+}
+
+func BenchmarkProve(b *testing.B) {
+	var x0 fr.Element
+	x0.SetRandom()
+	air, trace := hashChain(1<<10, x0)
+	h := sha256.New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Prove(air, trace, h)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	var x0 fr.Element
+	x0.SetRandom()
+	air, trace := hashChain(1<<10, x0)
+	h := sha256.New()
+	proof, err := Prove(air, trace, h)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(air, &proof, h)
+	}
+}
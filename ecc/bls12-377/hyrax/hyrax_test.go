@@ -0,0 +1,213 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hyrax
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/mlpcs"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SRS re-used across tests of the Hyrax scheme
+const testSrsSize = 8
+
+var testSrs *SRS
+
+func init() {
+	testSrs, _ = NewSRS(testSrsSize, []byte("hyrax test"))
+}
+
+func randomVector(size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+// evaluate returns the evaluation at point of the multilinear polynomial p,
+// folding its first variable first.
+func evaluate(p, point []fr.Element) fr.Element {
+	p = append([]fr.Element(nil), p...)
+	for _, r := range point {
+		mid := len(p) / 2
+		for i := 0; i < mid; i++ {
+			var tmp fr.Element
+			tmp.Sub(&p[i+mid], &p[i]).Mul(&tmp, &r)
+			p[i].Add(&p[i], &tmp)
+		}
+		p = p[:mid]
+	}
+	return p[0]
+}
+
+func TestNewSRS(t *testing.T) {
+	assert := require.New(t)
+
+	srs, err := NewSRS(testSrsSize, []byte("hyrax test"))
+	assert.NoError(err)
+	assert.Equal(testSrs.G, srs.G)
+
+	_, err = NewSRS(0, nil)
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+	_, err = NewSRS(6, nil)
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+}
+
+func TestOpen(t *testing.T) {
+	// polynomials smaller than, as large as and larger than the SRS
+	for _, nbVars := range []int{0, 2, 3, 6} {
+		t.Run(fmt.Sprintf("nbVars=%d", nbVars), func(t *testing.T) {
+			assert := require.New(t)
+
+			p := randomVector(1 << nbVars)
+			point := randomVector(nbVars)
+			digest, err := Commit(p, testSrs)
+			assert.NoError(err)
+
+			proof, err := Open(p, point, testSrs)
+			assert.NoError(err)
+			assert.Equal(evaluate(p, point), proof.ClaimedValue)
+			assert.NoError(Verify(&digest, &proof, point, testSrs))
+
+			// wrong value
+			var one fr.Element
+			one.SetOne()
+			proof.ClaimedValue.Add(&proof.ClaimedValue, &one)
+			assert.ErrorIs(Verify(&digest, &proof, point, testSrs), ErrVerifyOpeningProof)
+			proof.ClaimedValue.Sub(&proof.ClaimedValue, &one)
+
+			if nbVars > 0 {
+				// wrong point
+				point[0].Add(&point[0], &one)
+				assert.ErrorIs(Verify(&digest, &proof, point, testSrs), ErrVerifyOpeningProof)
+				point[0].Sub(&point[0], &one)
+
+				// wrong row
+				proof.Row[0].Add(&proof.Row[0], &one)
+				assert.Error(Verify(&digest, &proof, point, testSrs))
+				proof.Row[0].Sub(&proof.Row[0], &one)
+			}
+
+			assert.NoError(Verify(&digest, &proof, point, testSrs))
+		})
+	}
+}
+
+func TestInvalidSize(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := Commit(nil, testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(make([]fr.Element, 12), testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Open(make([]fr.Element, 16), randomVector(3), testSrs)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+
+	p := randomVector(16)
+	digest, err := Commit(p, testSrs)
+	assert.NoError(err)
+	proof, err := Open(p, randomVector(4), testSrs)
+	assert.NoError(err)
+	assert.ErrorIs(Verify(&digest, &proof, randomVector(5), testSrs), ErrInvalidProof)
+}
+
+func TestBatchOpen(t *testing.T) {
+	assert := require.New(t)
+	const nbVars = 5
+
+	polynomials := make([][]fr.Element, 3)
+	digests := make([]Digest, len(polynomials))
+	var err error
+	for i := range polynomials {
+		polynomials[i] = randomVector(1 << nbVars)
+		digests[i], err = Commit(polynomials[i], testSrs)
+		assert.NoError(err)
+	}
+	point := randomVector(nbVars)
+
+	_, err = BatchOpenSinglePoint(nil, nil, point, sha256.New(), testSrs)
+	assert.ErrorIs(err, ErrZeroNbDigests)
+	_, err = BatchOpenSinglePoint(polynomials, digests[:2], point, sha256.New(), testSrs)
+	assert.ErrorIs(err, ErrInvalidNbDigests)
+
+	proof, err := BatchOpenSinglePoint(polynomials, digests, point, sha256.New(), testSrs, []byte("data"))
+	assert.NoError(err)
+	for i := range polynomials {
+		assert.Equal(evaluate(polynomials[i], point), proof.ClaimedValues[i])
+	}
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs, []byte("data")))
+
+	// wrong transcript
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs, []byte("atad")), ErrVerifyOpeningProof)
+
+	// wrong value
+	var one fr.Element
+	one.SetOne()
+	proof.ClaimedValues[1].Add(&proof.ClaimedValues[1], &one)
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs, []byte("data")), ErrVerifyOpeningProof)
+	proof.ClaimedValues[1].Sub(&proof.ClaimedValues[1], &one)
+
+	// wrong order of the digests
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs, []byte("data")), ErrVerifyOpeningProof)
+	digests[0], digests[1] = digests[1], digests[0]
+
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs, []byte("data")))
+}
+
+func TestScheme(t *testing.T) {
+	assert := require.New(t)
+
+	var scheme mlpcs.Scheme[fr.Element, Digest, OpeningProof, BatchOpeningProof] = NewScheme(testSrs, sha256.New())
+	polynomials := [][]fr.Element{randomVector(16), randomVector(16)}
+	point := randomVector(4)
+	digests := make([]Digest, len(polynomials))
+	for i := range polynomials {
+		var err error
+		digests[i], err = scheme.Commit(polynomials[i])
+		assert.NoError(err)
+	}
+
+	proof, err := scheme.Open(polynomials[0], point)
+	assert.NoError(err)
+	assert.NoError(scheme.Verify(&digests[0], &proof, point))
+
+	batchProof, err := scheme.BatchOpen(polynomials, digests, point)
+	assert.NoError(err)
+	assert.NoError(scheme.BatchVerify(digests, &batchProof, point))
+}
+
+func BenchmarkCommit(b *testing.B) {
+	srs, err := NewSRS(1<<10, []byte("hyrax bench"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomVector(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Commit(p, srs)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	srs, err := NewSRS(1<<10, []byte("hyrax bench"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomVector(1 << 20)
+	point := randomVector(20)
+	digest, _ := Commit(p, srs)
+	proof, _ := Open(p, point, srs)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(&digest, &proof, point, srs)
+	}
+}
@@ -9,11 +9,14 @@ import (
 	"errors"
 	"hash"
 	"math/big"
+	"math/bits"
+	"runtime"
 	"sync"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/fiat-shamir"
 
 	"github.com/consensys/gnark-crypto/internal/parallel"
@@ -26,6 +29,7 @@ var (
 	ErrVerifyOpeningProof            = errors.New("can't verify opening proof")
 	ErrVerifyBatchOpeningSinglePoint = errors.New("can't verify batch opening proof at single point")
 	ErrMinSRSSize                    = errors.New("minimum srs size is 2")
+	ErrInvalidSRS                    = errors.New("the SRS points are not consecutive powers of the same τ")
 )
 
 // Digest commitment of a polynomial.
@@ -64,7 +68,8 @@ func eval(p []fr.Element, point fr.Element) fr.Element {
 
 // NewSRS returns a new SRS using alpha as randomness source
 //
-// In production, a SRS generated through MPC should be used.
+// In production, a SRS generated through MPC should be used (see the mpcsetup
+// sub-package).
 //
 // Set Alpha = -1 to generate quickly a balanced, valid SRS (useful for benchmarking).
 //
@@ -132,6 +137,68 @@ func NewSRS(size uint64, bAlpha *big.Int) (*SRS, error) {
 	return &srs, nil
 }
 
+// CheckPowers checks that an SRS obtained from an untrusted source is well
+// formed, that is Pk.G1 = [G₁, [τ]G₁, [τ²]G₁, ...] and Vk.G2 = [G₂, [τ]G₂]
+// for the same τ, using a random linear combination of the powers:
+//
+//	e(∑ᵢrᵢ[τⁱ]G₁, [τ]G₂) = e(∑ᵢrᵢ[τⁱ⁺¹]G₁, G₂)
+//
+// It does not check that the points are in the prime order subgroup, which
+// is enforced when the SRS is deserialized with ReadFrom.
+func (srs *SRS) CheckPowers() error {
+	n := len(srs.Pk.G1)
+	if n < 2 {
+		return ErrMinSRSSize
+	}
+	if !srs.Pk.G1[0].Equal(&srs.Vk.G1) || srs.Pk.G1[1].IsInfinity() || srs.Vk.G2[1].IsInfinity() {
+		return ErrInvalidSRS
+	}
+
+	// e([τ]G₁, G₂) = e(G₁, [τ]G₂)
+	var g1Neg bls12377.G1Affine
+	g1Neg.Neg(&srs.Pk.G1[0])
+	ok, err := bls12377.PairingCheck(
+		[]bls12377.G1Affine{srs.Pk.G1[1], g1Neg},
+		[]bls12377.G2Affine{srs.Vk.G2[0], srs.Vk.G2[1]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidSRS
+	}
+	if n == 2 {
+		return nil
+	}
+
+	r := make([]fr.Element, n-1)
+	for i := range r {
+		if _, err := r[i].SetRandom(); err != nil {
+			return err
+		}
+	}
+	var left, right bls12377.G1Affine
+	config := ecc.MultiExpConfig{}
+	if _, err := left.MultiExp(srs.Pk.G1[:n-1], r, config); err != nil {
+		return err
+	}
+	if _, err := right.MultiExp(srs.Pk.G1[1:], r, config); err != nil {
+		return err
+	}
+	left.Neg(&left)
+	ok, err = bls12377.PairingCheck(
+		[]bls12377.G1Affine{left, right},
+		[]bls12377.G2Affine{srs.Vk.G2[1], srs.Vk.G2[0]},
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidSRS
+	}
+	return nil
+}
+
 // OpeningProof KZG proof for opening at a single point.
 //
 // implements io.ReaderFrom and io.WriterTo
@@ -203,6 +270,100 @@ func Open(p []fr.Element, point fr.Element, pk ProvingKey) (OpeningProof, error)
 	return res, nil
 }
 
+// OpenAllDomain computes the opening proofs of polynomial p at all the points
+// of the domain, in natural order: the i-th proof opens p at ωⁱ where ω is
+// the generator of the domain.
+//
+// It implements the Feist–Khovratovich algorithm (https://eprint.iacr.org/2023/033.pdf),
+// which costs O(n log n) group operations instead of the n MSMs of Open.
+// fft.Domain Cardinality must be larger than or equal to len(p).
+func OpenAllDomain(p []fr.Element, domain *fft.Domain, pk ProvingKey) ([]OpeningProof, error) {
+	n := int(domain.Cardinality)
+	if len(p) == 0 || len(p) > len(pk.G1) || len(p) > n {
+		return nil, ErrInvalidPolynomialSize
+	}
+
+	// claimed values
+	values := make([]fr.Element, n)
+	copy(values, p)
+	domain.FFT(values, fft.DIF)
+	fft.BitReverse(values)
+	res := make([]OpeningProof, n)
+	for i := range res {
+		res[i].ClaimedValue = values[i]
+	}
+	if len(p) == 1 {
+		// the quotients are zero
+		return res, nil
+	}
+
+	numCPU := uint64(runtime.NumCPU())
+	maxSplits := bits.TrailingZeros64(ecc.NextPowerOfTwo(numCPU)) << 1
+
+	// the proof at z is ∑ₜzᵗhₜ, so the proofs are the FFT of (hₜ)ₜ
+	h := make([]bls12377.G1Jac, n)
+	copy(h, fk20Quotients(p, pk.G1, maxSplits))
+	difFFTG1(h, computeTwiddles(domain.Generator, n), 0, maxSplits, nil)
+	bitReverse(h)
+
+	proofs := bls12377.BatchJacobianToAffineG1(h)
+	for i := range res {
+		res[i].H = proofs[i]
+	}
+
+	return res, nil
+}
+
+// fk20Quotients returns, for d = len(p) and t < d-1,
+//
+//	hₜ = ∑_{m<d-1-t} p_{t+m+1}[τᵐ]G₁
+//
+// such that the quotient of p by X-z commits to ∑ₜzᵗhₜ. This is a Toeplitz
+// matrix-vector product, computed as a cyclic convolution of size 2K, K ≥ d,
+// with FFTs over G₁.
+func fk20Quotients(p []fr.Element, g1 []bls12377.G1Affine, maxSplits int) []bls12377.G1Jac {
+	d := len(p)
+	k := int(ecc.NextPowerOfTwo(uint64(d)))
+	domain := fft.NewDomain(uint64(2 * k))
+
+	// u[m] = [τ^{k-1-m}]G₁ for 0 < m < k and w[x] = p_{x-k} for k < x < 2k,
+	// so that hₜ = ∑ₘu[m]w[t-m mod 2k]
+	u := make([]bls12377.G1Jac, 2*k)
+	for m := 1; m < k; m++ {
+		if e := k - 1 - m; e <= d-2 {
+			u[m].FromAffine(&g1[e])
+		}
+	}
+	w := make([]fr.Element, 2*k)
+	for x := k + 1; x < 2*k && x-k < d; x++ {
+		w[x] = p[x-k]
+	}
+
+	// convolution, both FFTs are in bit-reversed order
+	difFFTG1(u, computeTwiddles(domain.Generator, 2*k), 0, maxSplits, nil)
+	domain.FFT(w, fft.DIF)
+	parallel.Execute(2*k, func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			w[i].BigInt(&b)
+			u[i].ScalarMultiplication(&u[i], &b)
+		}
+	})
+	bitReverse(u)
+	difFFTG1(u, computeTwiddles(domain.GeneratorInv, 2*k), 0, maxSplits, nil)
+	bitReverse(u)
+
+	var cardinalityInv big.Int
+	domain.CardinalityInv.BigInt(&cardinalityInv)
+	parallel.Execute(d-1, func(start, end int) {
+		for t := start; t < end; t++ {
+			u[t].ScalarMultiplication(&u[t], &cardinalityInv)
+		}
+	})
+
+	return u[:d-1]
+}
+
 // Verify verifies a KZG opening proof at a single point
 func Verify(commitment *Digest, proof *OpeningProof, point fr.Element, vk VerifyingKey) error {
 
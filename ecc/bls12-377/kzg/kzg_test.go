@@ -154,6 +154,27 @@ func TestSerializationSRS(t *testing.T) {
 	t.Run("unsafe whole SRS round-trip", testutils.UnsafeBinaryMarshalerRoundTrip(srs))
 }
 
+func TestCheckPowers(t *testing.T) {
+	srs, err := NewSRS(64, new(big.Int).SetInt64(42))
+	require.NoError(t, err)
+	require.NoError(t, srs.CheckPowers())
+
+	// the quick SRS is well formed too
+	quickSrs, err := NewSRS(64, new(big.Int).SetInt64(-1))
+	require.NoError(t, err)
+	require.NoError(t, quickSrs.CheckPowers())
+
+	// a power of another τ
+	srs.Pk.G1[10].Double(&srs.Pk.G1[10])
+	require.ErrorIs(t, srs.CheckPowers(), ErrInvalidSRS)
+
+	// G₂ of another τ
+	srs, err = NewSRS(64, new(big.Int).SetInt64(42))
+	require.NoError(t, err)
+	srs.Vk.G2[1].Double(&srs.Vk.G2[1])
+	require.ErrorIs(t, srs.CheckPowers(), ErrInvalidSRS)
+}
+
 func TestCommit(t *testing.T) {
 
 	// create a polynomial
@@ -238,6 +259,48 @@ func TestVerifySinglePoint(t *testing.T) {
 	}
 }
 
+func TestOpenAllDomain(t *testing.T) {
+	assert := require.New(t)
+
+	// the last case is the boundary len(p) = domain.Cardinality
+	for _, c := range []struct{ size, cardinality int }{
+		{2, 4}, {60, 64}, {64, 128}, {64, 64},
+	} {
+		size := c.size
+		f := randomPolynomial(size)
+		digest, err := Commit(f, testSrs.Pk)
+		assert.NoError(err)
+
+		domain := fft.NewDomain(uint64(c.cardinality))
+		proofs, err := OpenAllDomain(f, domain, testSrs.Pk)
+		assert.NoError(err)
+		assert.Len(proofs, int(domain.Cardinality))
+
+		var point fr.Element
+		point.SetOne()
+		for i := range proofs {
+			expected, err := Open(f, point, testSrs.Pk)
+			assert.NoError(err)
+			assert.Equal(expected, proofs[i], "size %d, point %d", size, i)
+			point.Mul(&point, &domain.Generator)
+		}
+		assert.NoError(Verify(&digest, &proofs[1], domain.Generator, testSrs.Vk))
+	}
+
+	// constant polynomial, the quotients are zero
+	f := randomPolynomial(1)
+	proofs, err := OpenAllDomain(f, fft.NewDomain(4), testSrs.Pk)
+	assert.NoError(err)
+	for i := range proofs {
+		assert.True(proofs[i].H.IsInfinity())
+		assert.Equal(f[0], proofs[i].ClaimedValue)
+	}
+
+	// the domain must be large enough
+	_, err = OpenAllDomain(randomPolynomial(65), fft.NewDomain(64), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
 func TestVerifySinglePointQuickSRS(t *testing.T) {
 
 	size := 64
@@ -540,6 +603,20 @@ func BenchmarkKZGOpen(b *testing.B) {
 	}
 }
 
+func BenchmarkKZGOpenAllDomain(b *testing.B) {
+	srs, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
+	assert.NoError(b, err)
+
+	// random polynomial
+	p := randomPolynomial(benchSize / 2)
+	domain := fft.NewDomain(uint64(benchSize / 2))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = OpenAllDomain(p, domain, srs.Pk)
+	}
+}
+
 func BenchmarkKZGVerify(b *testing.B) {
 	srs, err := NewSRS(ecc.NextPowerOfTwo(benchSize), new(big.Int).SetInt64(42))
 	assert.NoError(b, err)
@@ -0,0 +1,31 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package mpcsetup implements a multi-party computation ceremony
+// ("powers of tau") producing a KZG structured reference string on the
+// bls12-377 curve, without any single party knowing the secret τ.
+//
+// The ceremony starts from [NewContribution], where τ = 1. Each participant
+// then calls [Contribution.Contribute] on the latest contribution: it
+// multiplies the accumulated τ by a fresh secret, and attaches a proof of
+// knowledge of that secret, bound to the hash of the previous contribution.
+// Anyone can check the whole transcript with [VerifyTranscript]. The
+// ceremony is usually closed with [Contribution.Seal], a contribution
+// derived from a public random beacon, before exporting the result with
+// [Contribution.SRS].
+//
+// The proof of knowledge of a contribution τ' is a tuple
+//
+//	([s]G₁, [sτ']G₁, [τ']R), where R = H([s]G₁, [sτ']G₁, challenge) ∈ G₂
+//
+// for a random s. It is checked with e([s]G₁, [τ']R) = e([sτ']G₁, R), and the
+// powers are checked with pairings on random linear combinations.
+//
+// See https://eprint.iacr.org/2017/1050 for the security model.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package mpcsetup
@@ -0,0 +1,54 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"io"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+)
+
+// WriteTo writes the binary encoding of the contribution to w, with
+// compressed points.
+func (c *Contribution) WriteTo(w io.Writer) (int64, error) {
+	enc := bls12377.NewEncoder(w)
+	toEncode := []interface{}{
+		c.Parameters.G1,
+		&c.Parameters.G2[0],
+		&c.Parameters.G2[1],
+		&c.Proof.SG,
+		&c.Proof.SXG,
+		&c.Proof.XR,
+	}
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return enc.BytesWritten(), err
+		}
+	}
+	n, err := w.Write(c.Challenge[:])
+	return enc.BytesWritten() + int64(n), err
+}
+
+// ReadFrom reads the binary encoding of a contribution from r. The points
+// are checked to be on the curve and in the prime order subgroup.
+func (c *Contribution) ReadFrom(r io.Reader) (int64, error) {
+	dec := bls12377.NewDecoder(r)
+	toDecode := []interface{}{
+		&c.Parameters.G1,
+		&c.Parameters.G2[0],
+		&c.Parameters.G2[1],
+		&c.Proof.SG,
+		&c.Proof.SXG,
+		&c.Proof.XR,
+	}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return dec.BytesRead(), err
+		}
+	}
+	n, err := io.ReadFull(r, c.Challenge[:])
+	return dec.BytesRead() + int64(n), err
+}
@@ -0,0 +1,301 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrMinSize            = errors.New("minimum ceremony size is 2")
+	ErrEmptyTranscript    = errors.New("empty transcript")
+	ErrInitialState       = errors.New("first contribution is not the initial state of the ceremony")
+	ErrSizeMismatch       = errors.New("contributions have different sizes")
+	ErrChallengeMismatch  = errors.New("contribution is not chained to the previous one")
+	ErrInvalidPoint       = errors.New("point is the identity or not in the subgroup")
+	ErrInvalidGenerators  = errors.New("the powers of τ must start with the generators")
+	ErrProofOfKnowledge   = errors.New("proof of knowledge of the contribution is invalid")
+	ErrInconsistentUpdate = errors.New("contribution is not a multiplicative update of the previous one")
+	ErrInconsistentPowers = errors.New("the G₁ points are not consecutive powers of τ")
+	ErrSealMismatch       = errors.New("contribution does not match the random beacon")
+)
+
+const (
+	sizeChallenge = sha256.Size
+	dstUpdate     = "BLS12-377_KZG_MPC_SETUP_UPDATE_PROOF"
+	dstBeacon     = "BLS12-377_KZG_MPC_SETUP_BEACON"
+)
+
+// Parameters are the powers of τ accumulated during the ceremony.
+type Parameters struct {
+	G1 []bls12377.G1Affine  // [G₁, [τ]G₁, [τ²]G₁, ...]
+	G2 [2]bls12377.G2Affine // [G₂, [τ]G₂]
+}
+
+// UpdateProof is a proof of knowledge of the secret τ' multiplied into the
+// parameters by a contribution, bound to the challenge of the contribution.
+type UpdateProof struct {
+	SG  bls12377.G1Affine // [s]G₁ for a random s
+	SXG bls12377.G1Affine // [sτ']G₁
+	XR  bls12377.G2Affine // [τ']R where R = H(SG, SXG, challenge)
+}
+
+// Contribution is the state of the ceremony after a contribution.
+//
+// implements io.ReaderFrom and io.WriterTo
+type Contribution struct {
+	Parameters Parameters
+	Proof      UpdateProof
+
+	// Challenge is the hash of the previous contribution, the initial
+	// contribution has a zero challenge.
+	Challenge [sizeChallenge]byte
+}
+
+// NewContribution returns the initial state of a ceremony for an SRS of the
+// given size, that is the powers of τ = 1.
+func NewContribution(size uint64) (*Contribution, error) {
+	if size < 2 {
+		return nil, ErrMinSize
+	}
+	_, _, g1, g2 := bls12377.Generators()
+	var c Contribution
+	c.Parameters.G1 = make([]bls12377.G1Affine, size)
+	for i := range c.Parameters.G1 {
+		c.Parameters.G1[i] = g1
+	}
+	c.Parameters.G2[0] = g2
+	c.Parameters.G2[1] = g2
+	return &c, nil
+}
+
+// Contribute samples a random secret τ' and returns the next state of the
+// ceremony, whose powers are those of τ⋅τ'. τ' is discarded on return.
+func (c *Contribution) Contribute() (*Contribution, error) {
+	var tau fr.Element
+	if _, err := tau.SetRandom(); err != nil {
+		return nil, err
+	}
+	for tau.IsZero() {
+		if _, err := tau.SetRandom(); err != nil {
+			return nil, err
+		}
+	}
+	return c.update(&tau)
+}
+
+// Seal returns the last contribution of the ceremony, whose secret τ' is
+// derived from a public random beacon (e.g. a future block hash) so that
+// anyone can check it with [VerifySeal].
+func (c *Contribution) Seal(beacon []byte) (*Contribution, error) {
+	tau, err := beaconToScalar(beacon)
+	if err != nil {
+		return nil, err
+	}
+	return c.update(&tau)
+}
+
+// Hash returns the hash of the contribution, which is the challenge of the
+// next contribution.
+func (c *Contribution) Hash() []byte {
+	h := sha256.New()
+	if _, err := c.WriteTo(h); err != nil {
+		panic(err) // writing to a hash does not fail
+	}
+	return h.Sum(nil)
+}
+
+// SRS returns the KZG SRS corresponding to the powers of τ of the
+// contribution. It is compatible with [kzg.SRS.WriteTo] and [kzg.SRS.WriteDump].
+func (c *Contribution) SRS() *kzg.SRS {
+	var srs kzg.SRS
+	srs.Pk.G1 = make([]bls12377.G1Affine, len(c.Parameters.G1))
+	copy(srs.Pk.G1, c.Parameters.G1)
+	srs.Vk.G1 = c.Parameters.G1[0]
+	srs.Vk.G2 = c.Parameters.G2
+	srs.Vk.Lines[0] = bls12377.PrecomputeLines(srs.Vk.G2[0])
+	srs.Vk.Lines[1] = bls12377.PrecomputeLines(srs.Vk.G2[1])
+	return &srs
+}
+
+// Verify checks that next is a valid contribution on top of prev.
+//
+// It assumes the points of next are in the prime order subgroup, which is
+// enforced by the decoder when the contribution is read with ReadFrom.
+func Verify(prev, next *Contribution) error {
+	n := len(prev.Parameters.G1)
+	if len(next.Parameters.G1) != n {
+		return ErrSizeMismatch
+	}
+	if h := prev.Hash(); string(h) != string(next.Challenge[:]) {
+		return ErrChallengeMismatch
+	}
+
+	_, _, g1, g2 := bls12377.Generators()
+	if !next.Parameters.G1[0].Equal(&g1) || !next.Parameters.G2[0].Equal(&g2) {
+		return ErrInvalidGenerators
+	}
+	for _, p := range []*bls12377.G1Affine{&next.Parameters.G1[1], &next.Proof.SG, &next.Proof.SXG} {
+		if p.IsInfinity() || !p.IsInSubGroup() {
+			return ErrInvalidPoint
+		}
+	}
+	for _, p := range []*bls12377.G2Affine{&next.Parameters.G2[1], &next.Proof.XR} {
+		if p.IsInfinity() || !p.IsInSubGroup() {
+			return ErrInvalidPoint
+		}
+	}
+
+	// proof of knowledge of τ': e([sτ']G₁, R) = e([s]G₁, [τ']R)
+	r, err := next.Proof.challengePoint(next.Challenge[:])
+	if err != nil {
+		return err
+	}
+	if !sameRatio(next.Proof.SXG, next.Proof.SG, next.Proof.XR, r) {
+		return ErrProofOfKnowledge
+	}
+
+	// the new powers are the previous ones times τ':
+	// e([ττ']G₁, R) = e([τ]G₁, [τ']R) and e([sτ']G₁, [τ]G₂) = e([s]G₁, [ττ']G₂)
+	if !sameRatio(next.Parameters.G1[1], prev.Parameters.G1[1], next.Proof.XR, r) {
+		return ErrInconsistentUpdate
+	}
+	if !sameRatio(next.Proof.SXG, next.Proof.SG, next.Parameters.G2[1], prev.Parameters.G2[1]) {
+		return ErrInconsistentUpdate
+	}
+
+	// the G₁ points are consecutive powers of τ
+	srs := kzg.SRS{
+		Pk: kzg.ProvingKey{G1: next.Parameters.G1},
+		Vk: kzg.VerifyingKey{G1: next.Parameters.G1[0], G2: next.Parameters.G2},
+	}
+	if err := srs.CheckPowers(); err != nil {
+		return ErrInconsistentPowers
+	}
+	return nil
+}
+
+// VerifyTranscript checks a whole ceremony, starting from its initial state.
+func VerifyTranscript(contributions []*Contribution) error {
+	if len(contributions) == 0 {
+		return ErrEmptyTranscript
+	}
+	initial, err := NewContribution(uint64(len(contributions[0].Parameters.G1)))
+	if err != nil {
+		return err
+	}
+	if string(initial.Hash()) != string(contributions[0].Hash()) {
+		return ErrInitialState
+	}
+	for i := 1; i < len(contributions); i++ {
+		if err := Verify(contributions[i-1], contributions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifySeal checks that sealed is the contribution derived from the random
+// beacon on top of prev.
+func VerifySeal(prev, sealed *Contribution, beacon []byte) error {
+	if err := Verify(prev, sealed); err != nil {
+		return err
+	}
+	tau, err := beaconToScalar(beacon)
+	if err != nil {
+		return err
+	}
+	var b big.Int
+	tau.BigInt(&b)
+	var expected bls12377.G1Affine
+	expected.ScalarMultiplication(&prev.Parameters.G1[1], &b)
+	if !expected.Equal(&sealed.Parameters.G1[1]) {
+		return ErrSealMismatch
+	}
+	return nil
+}
+
+// update returns the contribution multiplying the powers of c by those of tau.
+func (c *Contribution) update(tau *fr.Element) (*Contribution, error) {
+	n := len(c.Parameters.G1)
+	next := Contribution{}
+	copy(next.Challenge[:], c.Hash())
+
+	// [τ'ⁱ]
+	taus := make([]fr.Element, n)
+	taus[0].SetOne()
+	for i := 1; i < n; i++ {
+		taus[i].Mul(&taus[i-1], tau)
+	}
+
+	next.Parameters.G1 = make([]bls12377.G1Affine, n)
+	parallel.Execute(n, func(start, end int) {
+		var b big.Int
+		for i := start; i < end; i++ {
+			taus[i].BigInt(&b)
+			next.Parameters.G1[i].ScalarMultiplication(&c.Parameters.G1[i], &b)
+		}
+	})
+	var bTau big.Int
+	tau.BigInt(&bTau)
+	next.Parameters.G2[0] = c.Parameters.G2[0]
+	next.Parameters.G2[1].ScalarMultiplication(&c.Parameters.G2[1], &bTau)
+
+	// proof of knowledge of τ'
+	var s, sTau fr.Element
+	if _, err := s.SetRandom(); err != nil {
+		return nil, err
+	}
+	sTau.Mul(&s, tau)
+	var bs, bsTau big.Int
+	next.Proof.SG.ScalarMultiplicationBase(s.BigInt(&bs))
+	next.Proof.SXG.ScalarMultiplicationBase(sTau.BigInt(&bsTau))
+	r, err := next.Proof.challengePoint(next.Challenge[:])
+	if err != nil {
+		return nil, err
+	}
+	next.Proof.XR.ScalarMultiplication(&r, &bTau)
+
+	return &next, nil
+}
+
+// challengePoint returns R = H(SG, SXG, challenge) ∈ G₂.
+func (proof *UpdateProof) challengePoint(challenge []byte) (bls12377.G2Affine, error) {
+	sg := proof.SG.Bytes()
+	sxg := proof.SXG.Bytes()
+	msg := make([]byte, 0, len(sg)+len(sxg)+len(challenge))
+	msg = append(msg, sg[:]...)
+	msg = append(msg, sxg[:]...)
+	msg = append(msg, challenge...)
+	return bls12377.HashToG2(msg, []byte(dstUpdate))
+}
+
+// sameRatio returns e(a₁, b₀) = e(a₀, b₁), that is a₁/a₀ = b₁/b₀ in the
+// exponent.
+func sameRatio(a1, a0 bls12377.G1Affine, b1, b0 bls12377.G2Affine) bool {
+	a0.Neg(&a0)
+	ok, err := bls12377.PairingCheck([]bls12377.G1Affine{a1, a0}, []bls12377.G2Affine{b0, b1})
+	return err == nil && ok
+}
+
+// beaconToScalar derives a non-zero scalar from the random beacon.
+func beaconToScalar(beacon []byte) (fr.Element, error) {
+	tau, err := fr.Hash(beacon, []byte(dstBeacon), 1)
+	if err != nil {
+		return fr.Element{}, err
+	}
+	if tau[0].IsZero() {
+		tau[0].SetOne()
+	}
+	return tau[0], nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package mpcsetup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/kzg"
+)
+
+// simulate runs a ceremony with nbParticipants and a final beacon.
+func simulate(t *testing.T, size uint64, nbParticipants int) []*Contribution {
+	c, err := NewContribution(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transcript := []*Contribution{c}
+	for i := 0; i < nbParticipants; i++ {
+		c, err = c.Contribute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		transcript = append(transcript, c)
+	}
+	c, err = c.Seal([]byte("beacon"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(transcript, c)
+}
+
+func TestCeremony(t *testing.T) {
+	t.Parallel()
+
+	const size = 16
+	transcript := simulate(t, size, 3)
+	if err := VerifyTranscript(transcript); err != nil {
+		t.Fatal(err)
+	}
+	last := len(transcript) - 1
+	if err := VerifySeal(transcript[last-1], transcript[last], []byte("beacon")); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySeal(transcript[last-1], transcript[last], []byte("another beacon")); err != ErrSealMismatch {
+		t.Fatal("expected ErrSealMismatch")
+	}
+
+	// the resulting SRS can be used to commit and open
+	srs := transcript[last].SRS()
+	p := make([]fr.Element, size)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	digest, err := kzg.Commit(p, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var point fr.Element
+	point.SetRandom()
+	proof, err := kzg.Open(p, point, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kzg.Verify(&digest, &proof, point, srs.Vk); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCeremonyInvalid(t *testing.T) {
+	t.Parallel()
+
+	transcript := simulate(t, 8, 2)
+	prev, next := transcript[1], transcript[2]
+
+	t.Run("broken chain", func(t *testing.T) {
+		if err := Verify(transcript[0], next); err != ErrChallengeMismatch {
+			t.Fatal("expected ErrChallengeMismatch", err)
+		}
+	})
+
+	t.Run("tampered power", func(t *testing.T) {
+		tampered := *next
+		tampered.Parameters.G1 = append(tampered.Parameters.G1[:0:0], next.Parameters.G1...)
+		tampered.Parameters.G1[3] = tampered.Parameters.G1[2]
+		if err := Verify(prev, &tampered); err != ErrInconsistentPowers {
+			t.Fatal("expected ErrInconsistentPowers", err)
+		}
+	})
+
+	t.Run("replayed proof", func(t *testing.T) {
+		// a contribution reusing the proof of another one is rejected
+		other, err := prev.Contribute()
+		if err != nil {
+			t.Fatal(err)
+		}
+		other.Proof = next.Proof
+		if err := Verify(prev, other); err == nil {
+			t.Fatal("replayed proof should be rejected")
+		}
+	})
+
+	t.Run("wrong initial state", func(t *testing.T) {
+		if err := VerifyTranscript(transcript[1:]); err != ErrInitialState {
+			t.Fatal("expected ErrInitialState", err)
+		}
+	})
+}
+
+func TestContributionSerialization(t *testing.T) {
+	t.Parallel()
+
+	transcript := simulate(t, 8, 1)
+	c := transcript[1]
+
+	var buf bytes.Buffer
+	written, err := c.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reconstructed Contribution
+	read, err := reconstructed.ReadFrom(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read != written {
+		t.Fatal("bytes read and written mismatch")
+	}
+	if !bytes.Equal(c.Hash(), reconstructed.Hash()) {
+		t.Fatal("contribution should be the same after deserialization")
+	}
+	if err := Verify(transcript[0], &reconstructed); err != nil {
+		t.Fatal(err)
+	}
+
+	// the exported SRS roundtrips through the kzg serialization
+	var srsBuf bytes.Buffer
+	if _, err := c.SRS().WriteTo(&srsBuf); err != nil {
+		t.Fatal(err)
+	}
+	var srs kzg.SRS
+	if _, err := srs.ReadFrom(&srsBuf); err != nil {
+		t.Fatal(err)
+	}
+	if !srs.Vk.G2[1].Equal(&c.Parameters.G2[1]) {
+		t.Fatal("SRS should be the same after deserialization")
+	}
+}
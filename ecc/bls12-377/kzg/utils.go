@@ -72,6 +72,12 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 	// inverse the generator
 	generator.Inverse(&generator)
 
+	return computeTwiddles(generator, cardinality), nil
+}
+
+// computeTwiddles returns the powers of the root of unity generator, of order
+// cardinality, used by difFFTG1.
+func computeTwiddles(generator fr.Element, cardinality int) []*big.Int {
 	// nb fft stages
 	nbStages := uint64(bits.TrailingZeros64(uint64(cardinality)))
 
@@ -80,7 +86,7 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 	w := generator
 	r[0] = new(big.Int).SetUint64(1)
 	if len(r) == 1 {
-		return r, nil
+		return r
 	}
 	r[1] = new(big.Int)
 	w.BigInt(r[1])
@@ -90,7 +96,7 @@ func computeTwiddlesInv(cardinality int) ([]*big.Int, error) {
 		w.BigInt(r[j])
 	}
 
-	return r, nil
+	return r
 }
 
 func bitReverse[T any](a []T) {
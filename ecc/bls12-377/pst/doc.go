@@ -0,0 +1,24 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package pst provides the multilinear KZG commitment scheme of
+// Papamanthou, Shi and Tamassia (PST).
+//
+// A multilinear polynomial f in n variables is committed to in the Lagrange
+// basis of the hypercube, [f(τ)]G₁ = ∑_b f(b)[eq(τ, b)]G₁, for a secret
+// τ ∈ 𝔽ⁿ. An opening at z is proved with the commitments of the n quotients
+//
+//	f - f(z) = ∑ᵢ (Xᵢ - zᵢ)⋅qᵢ(Xᵢ₊₁, …, Xₙ)
+//
+// and verified with n+1 pairings. The polynomials are given by their
+// evaluations on the hypercube, in the order of polynomial.MultiLin, and
+// implement the mlpcs.Scheme interface with [Scheme].
+//
+// See https://eprint.iacr.org/2011/587.pdf.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package pst
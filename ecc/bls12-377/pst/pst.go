@@ -0,0 +1,416 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/mlpcs"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (larger than SRS or not a power of 2)")
+	ErrInvalidNbVariables    = errors.New("invalid number of variables (larger than SRS, == 0 or not matching the point)")
+	ErrInvalidProof          = errors.New("the number of quotients doesn't match the number of variables")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+)
+
+// Digest commitment of a multilinear polynomial.
+type Digest = bls12377.G1Affine
+
+// ProvingKey used to create or open commitments
+type ProvingKey struct {
+	// G1[k] is the Lagrange basis of the multilinear polynomials in the last k
+	// variables: G1[k][b] = [eq((τₙ₋ₖ₊₁, …, τₙ), b)]G₁ for b ∈ {0,1}ᵏ
+	G1 [][]bls12377.G1Affine
+}
+
+// VerifyingKey used to verify opening proofs
+type VerifyingKey struct {
+	G1  bls12377.G1Affine
+	G2  bls12377.G2Affine
+	Tau []bls12377.G2Affine // [[τ₁]G₂, …, [τₙ]G₂]
+}
+
+// SRS must be computed through MPC and comprises the ProvingKey and the VerifyingKey
+type SRS struct {
+	Pk ProvingKey
+	Vk VerifyingKey
+}
+
+// OpeningProof proves that a committed polynomial evaluates to ClaimedValue
+// at a point z.
+type OpeningProof struct {
+	// Quotients are the commitments of the quotients qᵢ(Xᵢ₊₁, …, Xₙ) such that
+	// f - f(z) = ∑ᵢ (Xᵢ - zᵢ)qᵢ
+	Quotients []bls12377.G1Affine
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof proves the evaluations of several committed polynomials
+// at the same point.
+type BatchOpeningProof struct {
+	// Quotients are the commitments of the quotients of ∑ᵢγⁱfᵢ
+	Quotients []bls12377.G1Affine
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+}
+
+// NewSRS returns a new SRS for the multilinear polynomials in up to
+// len(tau) variables, using tau as randomness source.
+//
+// In production, a SRS generated through MPC should be used.
+func NewSRS(tau []*big.Int) (*SRS, error) {
+	nbVars := len(tau)
+	if nbVars == 0 {
+		return nil, ErrInvalidNbVariables
+	}
+	taus := make([]fr.Element, nbVars)
+	for i := range tau {
+		taus[i].SetBigInt(tau[i])
+	}
+
+	var srs SRS
+	_, _, gen1Aff, gen2Aff := bls12377.Generators()
+	srs.Vk.G1 = gen1Aff
+	srs.Vk.G2 = gen2Aff
+	srs.Vk.Tau = make([]bls12377.G2Affine, nbVars)
+	for i := range tau {
+		srs.Vk.Tau[i].ScalarMultiplication(&gen2Aff, tau[i])
+	}
+
+	srs.Pk.G1 = make([][]bls12377.G1Affine, nbVars+1)
+	for k := range srs.Pk.G1 {
+		eq := make(polynomial.MultiLin, 1<<k)
+		eq[0].SetOne()
+		eq.Eq(taus[nbVars-k:])
+		srs.Pk.G1[k] = bls12377.BatchScalarMultiplicationG1(&gen1Aff, eq)
+	}
+
+	return &srs, nil
+}
+
+// Commit commits to the multilinear polynomial of evaluations p, of size a
+// power of 2 at most 2ⁿ where n is the number of variables of the SRS.
+func Commit(p []fr.Element, pk ProvingKey, nbTasks ...int) (Digest, error) {
+	nbVars, err := nbVariables(len(p), len(pk.G1))
+	if err != nil {
+		return Digest{}, err
+	}
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+
+	var res bls12377.G1Affine
+	if _, err := res.MultiExp(pk.G1[nbVars], p, config); err != nil {
+		return Digest{}, err
+	}
+
+	return res, nil
+}
+
+// Open computes an opening proof of the multilinear polynomial p at point.
+func Open(p []fr.Element, point []fr.Element, pk ProvingKey) (OpeningProof, error) {
+	nbVars, err := nbVariables(len(p), len(pk.G1))
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	if nbVars != len(point) {
+		return OpeningProof{}, ErrInvalidNbVariables
+	}
+
+	quotients, value := computeQuotients(p, point)
+	res := OpeningProof{
+		Quotients:    make([]bls12377.G1Affine, nbVars),
+		ClaimedValue: value,
+	}
+	for i := range quotients {
+		if res.Quotients[i], err = Commit(quotients[i], pk); err != nil {
+			return OpeningProof{}, err
+		}
+	}
+
+	return res, nil
+}
+
+// Verify verifies a PST opening proof at point.
+func Verify(commitment *Digest, proof *OpeningProof, point []fr.Element, vk VerifyingKey) error {
+	nbVars := len(point)
+	if nbVars > len(vk.Tau) {
+		return ErrInvalidNbVariables
+	}
+	if len(proof.Quotients) != nbVars {
+		return ErrInvalidProof
+	}
+
+	// e(C - [f(z)]G₁, G₂) = ∏ᵢ e(πᵢ, [τᵢ - zᵢ]G₂), that is
+	// e(C - [f(z)]G₁ + ∑ᵢ[zᵢ]πᵢ, G₂)⋅∏ᵢ e(-πᵢ, [τᵢ]G₂) == 1
+	// where the variables of the polynomial are the last variables of the SRS
+	points := make([]bls12377.G1Affine, 0, nbVars+2)
+	scalars := make([]fr.Element, 0, nbVars+2)
+	points = append(points, *commitment, vk.G1)
+	scalars = append(scalars, fr.One(), fr.Element{})
+	scalars[1].Neg(&proof.ClaimedValue)
+	points = append(points, proof.Quotients...)
+	scalars = append(scalars, point...)
+
+	var lhs bls12377.G1Affine
+	if _, err := lhs.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+
+	P := make([]bls12377.G1Affine, nbVars+1)
+	Q := make([]bls12377.G2Affine, nbVars+1)
+	P[0], Q[0] = lhs, vk.G2
+	tau := vk.Tau[len(vk.Tau)-nbVars:]
+	for i := 0; i < nbVars; i++ {
+		P[i+1].Neg(&proof.Quotients[i])
+		Q[i+1] = tau[i]
+	}
+	check, err := bls12377.PairingCheck(P, Q)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at point of a list of polynomials.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * point is the point at which the polynomials are opened.
+// * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
+// * polynomials is the list of polynomials to open, they are supposed to be of the same size.
+// * dataTranscript extra data that might be needed to derive the challenge used for folding
+func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point []fr.Element, hf hash.Hash, pk ProvingKey, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	if len(polynomials) == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	if len(digests) != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	for i := range polynomials {
+		if len(polynomials[i]) != 1<<len(point) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+
+	var res BatchOpeningProof
+	res.ClaimedValues = make([]fr.Element, len(polynomials))
+	for i := range polynomials {
+		res.ClaimedValues[i] = polynomial.MultiLin(polynomials[i]).Evaluate(point, nil)
+	}
+
+	// derive the challenge γ, binded to the point and the commitments
+	gamma, err := deriveGamma(point, digests, res.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// ∑ᵢγⁱfᵢ
+	folded := make([]fr.Element, len(polynomials[0]))
+	for i := len(polynomials) - 1; i >= 0; i-- {
+		for j := range folded {
+			folded[j].Mul(&folded[j], &gamma).Add(&folded[j], &polynomials[i][j])
+		}
+	}
+
+	proof, err := Open(folded, point, pk)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+	res.Quotients = proof.Quotients
+
+	return res, nil
+}
+
+// FoldProof folds the digests and the proofs in batchOpeningProof using Fiat Shamir
+// to obtain an opening proof at a single point.
+//
+// * digests list of digests on which batchOpeningProof is based
+// * batchOpeningProof opening proof of digests
+// * returns the folded version of batchOpeningProof, Digest, the folded version of digests
+func FoldProof(digests []Digest, batchOpeningProof *BatchOpeningProof, point []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (OpeningProof, Digest, error) {
+	nbDigests := len(digests)
+	if nbDigests == 0 {
+		return OpeningProof{}, Digest{}, ErrZeroNbDigests
+	}
+	if nbDigests != len(batchOpeningProof.ClaimedValues) {
+		return OpeningProof{}, Digest{}, ErrInvalidNbDigests
+	}
+
+	gamma, err := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+
+	// ∑ᵢγⁱdᵢ, ∑ᵢγⁱf(z)
+	gammai := make([]fr.Element, nbDigests)
+	gammai[0].SetOne()
+	for i := 1; i < nbDigests; i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+	var res OpeningProof
+	var tmp fr.Element
+	for i := range gammai {
+		tmp.Mul(&gammai[i], &batchOpeningProof.ClaimedValues[i])
+		res.ClaimedValue.Add(&res.ClaimedValue, &tmp)
+	}
+	var foldedDigest Digest
+	if _, err := foldedDigest.MultiExp(digests, gammai, ecc.MultiExpConfig{}); err != nil {
+		return OpeningProof{}, Digest{}, err
+	}
+	res.Quotients = batchOpeningProof.Quotients
+
+	return res, foldedDigest, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+//
+// * digests list of digests on which opening proof is done
+// * batchOpeningProof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenge used for the folding
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point []fr.Element, hf hash.Hash, vk VerifyingKey, dataTranscript ...[]byte) error {
+
+	// fold the proof
+	foldedProof, foldedDigest, err := FoldProof(digests, batchOpeningProof, point, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// verify the foldedProof against the foldedDigest
+	return Verify(&foldedDigest, &foldedProof, point, vk)
+}
+
+// Scheme implements mlpcs.Scheme with an SRS, and the hash function used by
+// Fiat-Shamir for the batch openings.
+type Scheme struct {
+	srs *SRS
+	hf  hash.Hash
+}
+
+var _ mlpcs.Scheme[fr.Element, Digest, OpeningProof, BatchOpeningProof] = (*Scheme)(nil)
+
+// NewScheme returns the PST commitment scheme with the given SRS, using hf for
+// Fiat-Shamir.
+func NewScheme(srs *SRS, hf hash.Hash) *Scheme {
+	return &Scheme{srs: srs, hf: hf}
+}
+
+// Commit commits to p, see [Commit].
+func (s *Scheme) Commit(p []fr.Element) (Digest, error) {
+	return Commit(p, s.srs.Pk)
+}
+
+// Open opens p at point, see [Open].
+func (s *Scheme) Open(p []fr.Element, point []fr.Element) (OpeningProof, error) {
+	return Open(p, point, s.srs.Pk)
+}
+
+// Verify verifies an opening proof, see [Verify].
+func (s *Scheme) Verify(digest *Digest, proof *OpeningProof, point []fr.Element) error {
+	return Verify(digest, proof, point, s.srs.Vk)
+}
+
+// BatchOpen opens polynomials at point, see [BatchOpenSinglePoint].
+func (s *Scheme) BatchOpen(polynomials [][]fr.Element, digests []Digest, point []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	return BatchOpenSinglePoint(polynomials, digests, point, s.hf, s.srs.Pk, dataTranscript...)
+}
+
+// BatchVerify verifies a batch opening proof, see [BatchVerifySinglePoint].
+func (s *Scheme) BatchVerify(digests []Digest, proof *BatchOpeningProof, point []fr.Element, dataTranscript ...[]byte) error {
+	return BatchVerifySinglePoint(digests, proof, point, s.hf, s.srs.Vk, dataTranscript...)
+}
+
+// nbVariables returns the number of variables of a multilinear polynomial of
+// size, for an SRS with a Lagrange basis up to nbBases-1 variables.
+func nbVariables(size, nbBases int) (int, error) {
+	if size == 0 || bits.OnesCount(uint(size)) != 1 {
+		return 0, ErrInvalidPolynomialSize
+	}
+	nbVars := bits.TrailingZeros(uint(size))
+	if nbVars >= nbBases {
+		return 0, ErrInvalidPolynomialSize
+	}
+	return nbVars, nil
+}
+
+// computeQuotients returns the quotients qᵢ(Xᵢ₊₁, …, Xₙ), in evaluation form,
+// such that f - f(z) = ∑ᵢ (Xᵢ - zᵢ)qᵢ, and f(z). Fixing the variables one at a
+// time, f(z₁, …, zᵢ₋₁, Xᵢ, …) = f(z₁, …, zᵢ, Xᵢ₊₁, …) + (Xᵢ - zᵢ)qᵢ where qᵢ
+// is the difference of the two halves of the bookkeeping table.
+func computeQuotients(p []fr.Element, point []fr.Element) ([][]fr.Element, fr.Element) {
+	table := make([]fr.Element, len(p))
+	copy(table, p)
+	quotients := make([][]fr.Element, len(point))
+	for i := range point {
+		mid := len(table) / 2
+		bottom, top := table[:mid], table[mid:]
+		q := make([]fr.Element, mid)
+		for j := range q {
+			q[j].Sub(&top[j], &bottom[j])
+			top[j].Mul(&q[j], &point[i])
+			bottom[j].Add(&bottom[j], &top[j])
+		}
+		quotients[i] = q
+		table = bottom
+	}
+	return quotients, table[0]
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold proofs.
+func deriveGamma(point []fr.Element, digests []Digest, claimedValues []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+
+	// derive the challenge gamma, binded to the point and the commitments
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	for i := range point {
+		if err := fs.Bind("gamma", point[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range digests {
+		if err := fs.Bind("gamma", digests[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range claimedValues {
+		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	for i := 0; i < len(dataTranscript); i++ {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	return gamma, nil
+}
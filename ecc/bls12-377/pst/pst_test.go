@@ -0,0 +1,184 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package pst
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/polynomial"
+	"github.com/stretchr/testify/require"
+)
+
+// Test SRS re-used across tests of the PST scheme
+const testNbVars = 6
+
+var testSrs *SRS
+var testTau []fr.Element
+
+func init() {
+	testTau = make([]fr.Element, testNbVars)
+	bTau := make([]*big.Int, testNbVars)
+	for i := range testTau {
+		testTau[i].SetUint64(uint64(42 + i))
+		bTau[i] = new(big.Int).SetUint64(uint64(42 + i))
+	}
+	testSrs, _ = NewSRS(bTau)
+}
+
+func randomMultiLin(nbVars int) polynomial.MultiLin {
+	p := make(polynomial.MultiLin, 1<<nbVars)
+	for i := range p {
+		p[i].SetRandom()
+	}
+	return p
+}
+
+func randomPoint(nbVars int) []fr.Element {
+	point := make([]fr.Element, nbVars)
+	for i := range point {
+		point[i].SetRandom()
+	}
+	return point
+}
+
+func TestCommit(t *testing.T) {
+	assert := require.New(t)
+
+	// the commitment of a polynomial in n variables is [f(τₙ₋ₖ₊₁, …, τₙ)]G₁
+	for nbVars := 0; nbVars <= testNbVars; nbVars++ {
+		p := randomMultiLin(nbVars)
+		digest, err := Commit(p, testSrs.Pk)
+		assert.NoError(err)
+
+		var bValue big.Int
+		value := p.Evaluate(testTau[testNbVars-nbVars:], nil)
+		value.BigInt(&bValue)
+		var expected bls12377.G1Affine
+		expected.ScalarMultiplication(&testSrs.Vk.G1, &bValue)
+		assert.True(expected.Equal(&digest), "nbVars=%d", nbVars)
+	}
+
+	_, err := Commit(make([]fr.Element, 3), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = Commit(make([]fr.Element, 1<<(testNbVars+1)), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestOpen(t *testing.T) {
+	assert := require.New(t)
+
+	for _, nbVars := range []int{0, 1, 4, testNbVars} {
+		p := randomMultiLin(nbVars)
+		point := randomPoint(nbVars)
+		digest, err := Commit(p, testSrs.Pk)
+		assert.NoError(err)
+
+		proof, err := Open(p, point, testSrs.Pk)
+		assert.NoError(err)
+		assert.Equal(p.Evaluate(point, nil), proof.ClaimedValue)
+		assert.NoError(Verify(&digest, &proof, point, testSrs.Vk), "nbVars=%d", nbVars)
+
+		// wrong claimed value
+		wrongProof := proof
+		wrongProof.ClaimedValue.Double(&proof.ClaimedValue)
+		if !proof.ClaimedValue.IsZero() {
+			assert.ErrorIs(Verify(&digest, &wrongProof, point, testSrs.Vk), ErrVerifyOpeningProof)
+		}
+
+		// wrong point
+		if nbVars > 0 {
+			wrongPoint := randomPoint(nbVars)
+			assert.ErrorIs(Verify(&digest, &proof, wrongPoint, testSrs.Vk), ErrVerifyOpeningProof)
+			assert.ErrorIs(Verify(&digest, &proof, point[1:], testSrs.Vk), ErrInvalidProof)
+		}
+	}
+}
+
+func TestBatchOpen(t *testing.T) {
+	assert := require.New(t)
+
+	const nbVars, nbPolynomials = 5, 3
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	for i := range polynomials {
+		polynomials[i] = randomMultiLin(nbVars)
+		var err error
+		digests[i], err = Commit(polynomials[i], testSrs.Pk)
+		assert.NoError(err)
+	}
+	point := randomPoint(nbVars)
+
+	proof, err := BatchOpenSinglePoint(polynomials, digests, point, sha256.New(), testSrs.Pk, []byte("data"))
+	assert.NoError(err)
+	for i := range polynomials {
+		assert.Equal(polynomial.MultiLin(polynomials[i]).Evaluate(point, nil), proof.ClaimedValues[i])
+	}
+	assert.NoError(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk, []byte("data")))
+
+	// wrong transcript
+	assert.Error(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk))
+
+	// wrong claimed value
+	proof.ClaimedValues[1].Double(&proof.ClaimedValues[1])
+	assert.ErrorIs(BatchVerifySinglePoint(digests, &proof, point, sha256.New(), testSrs.Vk, []byte("data")), ErrVerifyOpeningProof)
+
+	// wrong number of digests
+	_, err = BatchOpenSinglePoint(polynomials, digests[1:], point, sha256.New(), testSrs.Pk)
+	assert.ErrorIs(err, ErrInvalidNbDigests)
+}
+
+func TestScheme(t *testing.T) {
+	assert := require.New(t)
+
+	scheme := NewScheme(testSrs, sha256.New())
+	p := randomMultiLin(testNbVars)
+	point := randomPoint(testNbVars)
+
+	digest, err := scheme.Commit(p)
+	assert.NoError(err)
+	proof, err := scheme.Open(p, point)
+	assert.NoError(err)
+	assert.NoError(scheme.Verify(&digest, &proof, point))
+
+	q := randomMultiLin(testNbVars)
+	digestQ, err := scheme.Commit(q)
+	assert.NoError(err)
+	batchProof, err := scheme.BatchOpen([][]fr.Element{p, q}, []Digest{digest, digestQ}, point)
+	assert.NoError(err)
+	assert.NoError(scheme.BatchVerify([]Digest{digest, digestQ}, &batchProof, point))
+}
+
+func BenchmarkCommit(b *testing.B) {
+	p := randomMultiLin(testNbVars)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Commit(p, testSrs.Pk)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	p := randomMultiLin(testNbVars)
+	point := randomPoint(testNbVars)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = Open(p, point, testSrs.Pk)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	p := randomMultiLin(testNbVars)
+	point := randomPoint(testNbVars)
+	digest, _ := Commit(p, testSrs.Pk)
+	proof, _ := Open(p, point, testSrs.Pk)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Verify(&digest, &proof, point, testSrs.Vk)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"errors"
+	"io"
+	"math/big"
+)
+
+var (
+	ErrInvalidThreshold      = errors.New("threshold must be in [1, number of participants]")
+	ErrInvalidIdentifier     = errors.New("identifier must be in [1, number of participants]")
+	ErrInvalidNbMessages     = errors.New("there must be one message per participant")
+	ErrInvalidCommitment     = errors.New("invalid polynomial commitment")
+	ErrInvalidProofKnowledge = errors.New("invalid proof of knowledge of the secret")
+	ErrInvalidSecretShare    = errors.New("secret share does not match the polynomial commitment")
+	ErrMissingSecretShare    = errors.New("missing secret share")
+	ErrRoundNotDone          = errors.New("previous round of the key generation is not done")
+)
+
+// tag of the hash of the proofs of knowledge of the DKG
+const tagDKG = "FROST/DKG proof of knowledge"
+
+// Participant holds the state of a participant of the distributed key
+// generation of Pedersen, as modified by FROST. The participants are identified
+// by 1, 2, …, n.
+type Participant struct {
+	id             uint64
+	threshold      int
+	nbParticipants int
+	context        []byte
+
+	coefficients []*big.Int // secret polynomial fᵢ
+	round1       []Round1Message
+}
+
+// Round1Message is broadcast by each participant in the first round of the
+// key generation.
+type Round1Message struct {
+	Identifier uint64
+
+	// Commitment to the secret polynomial fᵢ = ∑ₖaᵢₖXᵏ, φᵢₖ = aᵢₖ⋅G
+	Commitment []point
+
+	// Schnorr proof of knowledge of aᵢ₀
+	ProofR point
+	ProofZ *big.Int
+}
+
+// SecretShare is sent privately from a participant to another in the second
+// round of the key generation.
+type SecretShare struct {
+	From, To uint64
+	Value    *big.Int // f_From(To)
+}
+
+// NewParticipant returns the participant of identifier id to a key generation
+// among nbParticipants, where threshold participants can sign, and its
+// message for the first round. context identifies the key generation and must
+// be the same for all the participants.
+func NewParticipant(id uint64, threshold, nbParticipants int, context []byte, rand io.Reader) (*Participant, Round1Message, error) {
+	var msg Round1Message
+	if threshold < 1 || threshold > nbParticipants {
+		return nil, msg, ErrInvalidThreshold
+	}
+	if id < 1 || id > uint64(nbParticipants) {
+		return nil, msg, ErrInvalidIdentifier
+	}
+
+	p := &Participant{
+		id:             id,
+		threshold:      threshold,
+		nbParticipants: nbParticipants,
+		context:        append([]byte(nil), context...),
+		coefficients:   make([]*big.Int, threshold),
+	}
+	msg.Identifier = id
+	msg.Commitment = make([]point, threshold)
+	var err error
+	for k := range p.coefficients {
+		if p.coefficients[k], err = randomScalar(rand); err != nil {
+			return nil, msg, err
+		}
+		msg.Commitment[k] = scalarMulBase(p.coefficients[k])
+	}
+
+	// μ = k + aᵢ₀⋅c with c = H(i ‖ context ‖ φᵢ₀ ‖ k⋅G)
+	k, err := randomScalar(rand)
+	if err != nil {
+		return nil, msg, err
+	}
+	msg.ProofR = scalarMulBase(k)
+	c := p.proofChallenge(id, &msg.Commitment[0], &msg.ProofR)
+	msg.ProofZ = c.Mul(c, p.coefficients[0])
+	msg.ProofZ.Add(msg.ProofZ, k).Mod(msg.ProofZ, order)
+
+	return p, msg, nil
+}
+
+// Round2 checks the messages of the first round of all the participants,
+// including p's, and returns the secret shares p sends to each of the other
+// participants. It returns a *CulpritError identifying the participants whose
+// message is invalid.
+func (p *Participant) Round2(round1 []Round1Message) ([]SecretShare, error) {
+	if len(round1) != p.nbParticipants {
+		return nil, ErrInvalidNbMessages
+	}
+	sorted := make([]Round1Message, p.nbParticipants)
+	for _, msg := range round1 {
+		if msg.Identifier < 1 || msg.Identifier > uint64(p.nbParticipants) || sorted[msg.Identifier-1].Commitment != nil {
+			return nil, ErrInvalidIdentifier
+		}
+		sorted[msg.Identifier-1] = msg
+	}
+
+	var culprits []uint64
+	var err error
+	for _, msg := range sorted {
+		if len(msg.Commitment) != p.threshold || msg.ProofZ == nil {
+			culprits = append(culprits, msg.Identifier)
+			err = ErrInvalidCommitment
+			continue
+		}
+		c := p.proofChallenge(msg.Identifier, &msg.Commitment[0], &msg.ProofR)
+		if !verifyShare(msg.ProofZ, &msg.ProofR, &msg.Commitment[0], c) {
+			culprits = append(culprits, msg.Identifier)
+			err = ErrInvalidProofKnowledge
+		}
+	}
+	if len(culprits) != 0 {
+		return nil, &CulpritError{Culprits: culprits, Err: err}
+	}
+	p.round1 = sorted
+
+	shares := make([]SecretShare, 0, p.nbParticipants-1)
+	for j := uint64(1); j <= uint64(p.nbParticipants); j++ {
+		if j != p.id {
+			shares = append(shares, SecretShare{From: p.id, To: j, Value: p.evaluate(j)})
+		}
+	}
+	return shares, nil
+}
+
+// Finalize checks the secret shares received from the other participants
+// against their polynomial commitments, and returns the key share of p and
+// the public key package of the group. It returns a *CulpritError identifying
+// the participants whose share is invalid.
+//
+// The secret share of participant i is sᵢ = ∑ⱼfⱼ(i), the group key is
+// Y = ∑ⱼφⱼ₀ and the verification share of participant i is Yᵢ = sᵢ⋅G.
+func (p *Participant) Finalize(shares []SecretShare) (*KeyShare, *PublicKeyPackage, error) {
+	if p.round1 == nil {
+		return nil, nil, ErrRoundNotDone
+	}
+
+	received := make([]*big.Int, p.nbParticipants)
+	received[p.id-1] = p.evaluate(p.id)
+	for _, share := range shares {
+		if share.To != p.id || share.From < 1 || share.From > uint64(p.nbParticipants) || share.From == p.id || share.Value == nil {
+			return nil, nil, ErrInvalidIdentifier
+		}
+		received[share.From-1] = new(big.Int).Mod(share.Value, order)
+	}
+
+	var culprits []uint64
+	var err error
+	for j := range received {
+		id := uint64(j + 1)
+		if received[j] == nil {
+			culprits = append(culprits, id)
+			err = ErrMissingSecretShare
+			continue
+		}
+		lhs := scalarMulBase(received[j])
+		rhs := evaluateCommitment(p.round1[j].Commitment, p.id)
+		if !lhs.Equal(&rhs) {
+			culprits = append(culprits, id)
+			err = ErrInvalidSecretShare
+		}
+	}
+	if len(culprits) != 0 {
+		return nil, nil, &CulpritError{Culprits: culprits, Err: err}
+	}
+
+	// sᵢ = ∑ⱼfⱼ(i)
+	secret := new(big.Int)
+	for _, s := range received {
+		secret.Add(secret, s)
+	}
+	secret.Mod(secret, order)
+
+	// the commitment to ∑ⱼfⱼ gives the group key and the verification shares
+	commitment := make([]point, p.threshold)
+	for k := range commitment {
+		commitment[k] = identity()
+	}
+	for _, msg := range p.round1 {
+		for k := range commitment {
+			commitment[k] = add(&commitment[k], &msg.Commitment[k])
+		}
+	}
+	pub := &PublicKeyPackage{
+		GroupKey:           commitment[0],
+		VerificationShares: make(map[uint64]point, p.nbParticipants),
+		Threshold:          p.threshold,
+	}
+	for id := uint64(1); id <= uint64(p.nbParticipants); id++ {
+		pub.VerificationShares[id] = evaluateCommitment(commitment, id)
+	}
+	if isIdentity(&pub.GroupKey) {
+		return nil, nil, ErrInvalidCommitment
+	}
+
+	keyShare := &KeyShare{
+		Identifier: p.id,
+		GroupKey:   pub.GroupKey,
+		secret:     secret,
+	}
+	return keyShare, pub, nil
+}
+
+// evaluate returns p's secret polynomial evaluated at x.
+func (p *Participant) evaluate(x uint64) *big.Int {
+	bx := new(big.Int).SetUint64(x)
+	res := new(big.Int)
+	for k := len(p.coefficients) - 1; k >= 0; k-- {
+		res.Mul(res, bx).Add(res, p.coefficients[k]).Mod(res, order)
+	}
+	return res
+}
+
+func (p *Participant) proofChallenge(id uint64, phi, R *point) *big.Int {
+	return hashToScalar(tagDKG, encodeUint64(id), encodeUint64(uint64(len(p.context))), p.context, encodePoint(phi), encodePoint(R))
+}
+
+// evaluateCommitment returns ∑ₖxᵏ⋅φₖ, the commitment to f(x).
+func evaluateCommitment(commitment []point, x uint64) point {
+	bx := new(big.Int).SetUint64(x)
+	res := identity()
+	for k := len(commitment) - 1; k >= 0; k-- {
+		res = scalarMul(&res, bx)
+		res = add(&res, &commitment[k])
+	}
+	return res
+}
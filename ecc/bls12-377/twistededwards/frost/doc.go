@@ -0,0 +1,25 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package frost provides FROST threshold signatures on bls12-377's twisted edwards curve.
+//
+// n participants run a distributed key generation, in which each of them
+// deals Shamir shares of a random secret and proves knowledge of it, so that
+// any t of them can sign under the group key, but no fewer.
+// A signature is produced in two rounds: the signers send commitments to
+// their nonces to a coordinator, then shares of the signature. The
+// coordinator aggregates the shares, and identifies the signers whose share
+// is invalid when the signature does not verify.
+// The signature is an EdDSA signature under the group key, as verified by the
+// eddsa package with the same hash function.
+//
+// Documentation:
+//   - FROST: https://eprint.iacr.org/2020/852
+//   - RFC 9591: https://www.rfc-editor.org/rfc/rfc9591
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package frost
@@ -0,0 +1,340 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+	"sort"
+)
+
+var (
+	ErrNotEnoughSigners      = errors.New("fewer signers than the threshold")
+	ErrUnknownSigner         = errors.New("signer is not one of the participants")
+	ErrDuplicateSigner       = errors.New("signer appears several times")
+	ErrMissingCommitment     = errors.New("signer commitments are not in the signing package")
+	ErrNonceReuse            = errors.New("signing nonces were already used")
+	ErrInvalidNbShares       = errors.New("there must be one signature share per signer")
+	ErrInvalidSignatureShare = errors.New("invalid signature share")
+	ErrIdentityCommitment    = errors.New("group commitment is the identity")
+)
+
+// tags of the hashes of FROST
+const (
+	tagNonce       = "FROST/nonce"
+	tagMessage     = "FROST/message"
+	tagCommitments = "FROST/commitments"
+	tagRho         = "FROST/rho"
+)
+
+// CulpritError is returned when some participants misbehaved, and identifies
+// them.
+type CulpritError struct {
+	Culprits []uint64
+	Err      error
+}
+
+func (e *CulpritError) Error() string {
+	return fmt.Sprintf("participants %v: %v", e.Culprits, e.Err)
+}
+
+func (e *CulpritError) Unwrap() error {
+	return e.Err
+}
+
+// KeyShare is the share of the group secret key of a participant.
+type KeyShare struct {
+	Identifier uint64
+	GroupKey   point
+
+	secret *big.Int // sᵢ
+}
+
+// PublicKeyPackage holds the public keys of the group, as computed by any of
+// the participants of the key generation.
+type PublicKeyPackage struct {
+	// GroupKey Y = s⋅G for the group secret s
+	GroupKey point
+
+	// VerificationShares[i] = Yᵢ = sᵢ⋅G
+	VerificationShares map[uint64]point
+
+	// Threshold is the number of participants needed to sign
+	Threshold int
+}
+
+// SigningNonces are the secret nonces of a signer, to be used for a single
+// signature.
+type SigningNonces struct {
+	d, e        *big.Int
+	Commitments SigningCommitments
+}
+
+// SigningCommitments are the commitments to the nonces of a signer, sent to
+// the coordinator of the signature in the first round.
+type SigningCommitments struct {
+	Identifier uint64
+	D, E       point // hiding and binding nonce commitments
+}
+
+// SignatureShare is the share of the signature of a signer, sent to the
+// coordinator of the signature in the second round.
+type SignatureShare struct {
+	Identifier uint64
+	Z          *big.Int
+}
+
+// signingState holds the values shared by all the signers for signing a
+// message.
+type signingState struct {
+	commitments    []SigningCommitments // sorted by identifier
+	bindingFactors []*big.Int           // ρᵢ of commitments[i]
+	r              point                // normalized group commitment R
+	negatedR       bool                 // whether r = -R
+	negatedY       bool                 // whether the signature verifies under -Y
+	c              *big.Int             // challenge
+}
+
+// PublicKey returns the key the threshold signatures verify under.
+func (pub *PublicKeyPackage) PublicKey() point {
+	Y, _ := normalize(&pub.GroupKey)
+	return Y
+}
+
+// Commit generates the signing nonces of the key share and their commitments,
+// for the first round of the signature. The nonces are derived from randomness
+// read from crypto/rand and the secret share, so that a weak source of
+// randomness does not leak the secret share. They must be used for a single
+// signature.
+func (ks *KeyShare) Commit() (*SigningNonces, SigningCommitments, error) {
+	var r [32]byte
+	if _, err := io.ReadFull(rand.Reader, r[:]); err != nil {
+		return nil, SigningCommitments{}, err
+	}
+	secret := encodeScalar(ks.secret)
+	nonces := &SigningNonces{
+		d: hashToScalar(tagNonce, r[:], secret, []byte{1}),
+		e: hashToScalar(tagNonce, r[:], secret, []byte{2}),
+	}
+	if nonces.d.Sign() == 0 || nonces.e.Sign() == 0 {
+		return nil, SigningCommitments{}, errZeroScalar
+	}
+	nonces.Commitments = SigningCommitments{
+		Identifier: ks.Identifier,
+		D:          scalarMulBase(nonces.d),
+		E:          scalarMulBase(nonces.e),
+	}
+	return nonces, nonces.Commitments, nil
+}
+
+// Sign returns the share of the signature of message of the key share, with
+// the nonces committed to in commitments, the commitments of all the signers.
+// The nonces are erased, so that they cannot be used again:
+//
+//	zᵢ = dᵢ + eᵢ⋅ρᵢ + λᵢ⋅sᵢ⋅c
+//
+// where the nonces and the secret share are negated if needed by the
+// normalization of R and Y. hFunc is used to compute the challenge as the
+// verifier of the aggregated signature does.
+func (ks *KeyShare) Sign(nonces *SigningNonces, commitments []SigningCommitments, message []byte, hFunc hash.Hash) (SignatureShare, error) {
+	res := SignatureShare{Identifier: ks.Identifier}
+	if nonces.d == nil {
+		return res, ErrNonceReuse
+	}
+	state, err := newSigningState(&ks.GroupKey, commitments, message, hFunc)
+	if err != nil {
+		return res, err
+	}
+	i := state.index(ks.Identifier)
+	if i < 0 || !state.commitments[i].D.Equal(&nonces.Commitments.D) || !state.commitments[i].E.Equal(&nonces.Commitments.E) {
+		return res, ErrMissingCommitment
+	}
+	d, e := nonces.d, nonces.e
+	nonces.d, nonces.e = nil, nil
+
+	// k = dᵢ + eᵢ⋅ρᵢ
+	k := new(big.Int).Mul(e, state.bindingFactors[i])
+	k.Add(k, d).Mod(k, order)
+	if state.negatedR {
+		k.Sub(order, k)
+	}
+	s := new(big.Int).Set(ks.secret)
+	if state.negatedY {
+		s.Sub(order, s)
+	}
+
+	res.Z = s.Mul(s, state.lagrange(i)).Mul(s, state.c)
+	res.Z.Add(res.Z, k).Mod(res.Z, order)
+	return res, nil
+}
+
+// Aggregate returns the signature of message from the signature shares of the
+// signers of commitments, z = ∑ᵢzᵢ. The signature verifies under
+// PublicKeyPackage.PublicKey.
+//
+// If the signature is invalid, the shares are checked against the
+// verification shares of the signers,
+//
+//	zᵢ⋅G = Dᵢ + ρᵢ⋅Eᵢ + λᵢ⋅c⋅Yᵢ
+//
+// and a *CulpritError identifies the signers of the invalid shares.
+func Aggregate(commitments []SigningCommitments, message []byte, shares []SignatureShare, pub *PublicKeyPackage, hFunc hash.Hash) ([]byte, error) {
+	if len(commitments) < pub.Threshold {
+		return nil, ErrNotEnoughSigners
+	}
+	if len(shares) != len(commitments) {
+		return nil, ErrInvalidNbShares
+	}
+	for i := range commitments {
+		if _, ok := pub.VerificationShares[commitments[i].Identifier]; !ok {
+			return nil, ErrUnknownSigner
+		}
+	}
+	state, err := newSigningState(&pub.GroupKey, commitments, message, hFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*SignatureShare, len(shares))
+	for j := range shares {
+		i := state.index(shares[j].Identifier)
+		if i < 0 {
+			return nil, ErrUnknownSigner
+		}
+		if sorted[i] != nil {
+			return nil, ErrDuplicateSigner
+		}
+		sorted[i] = &shares[j]
+	}
+
+	var culprits []uint64
+	z := new(big.Int)
+	for _, share := range sorted {
+		if share.Z == nil || share.Z.Cmp(order) >= 0 {
+			culprits = append(culprits, share.Identifier)
+			continue
+		}
+		z.Add(z, share.Z)
+	}
+	if len(culprits) != 0 {
+		return nil, &CulpritError{Culprits: culprits, Err: ErrInvalidSignatureShare}
+	}
+	z.Mod(z, order)
+
+	Y := pub.PublicKey()
+	if verifyShare(z, &state.r, &Y, state.c) {
+		return signatureBytes(&state.r, z), nil
+	}
+
+	// identify the invalid shares
+	for i, share := range sorted {
+		R := scalarMul(&state.commitments[i].E, state.bindingFactors[i])
+		R = add(&R, &state.commitments[i].D)
+		if state.negatedR {
+			R.Neg(&R)
+		}
+		Yi := pub.VerificationShares[share.Identifier]
+		if state.negatedY {
+			Yi.Neg(&Yi)
+		}
+		lc := new(big.Int).Mul(state.lagrange(i), state.c)
+		lc.Mod(lc, order)
+		if !verifyShare(share.Z, &R, &Yi, lc) {
+			culprits = append(culprits, share.Identifier)
+		}
+	}
+	return nil, &CulpritError{Culprits: culprits, Err: ErrInvalidSignatureShare}
+}
+
+// newSigningState computes the binding factors, the group commitment and the
+// challenge of the signature of message under the group key Y:
+//
+//	ρᵢ = hash_rho(Y ‖ hash_message(m) ‖ hash_commitments(B) ‖ i)
+//	R = ∑ᵢDᵢ + ρᵢ⋅Eᵢ
+//	c = challenge(R, Y, m)
+//
+// where B is the list of the commitments of the signers, sorted by identifier.
+func newSigningState(Y *point, commitments []SigningCommitments, message []byte, hFunc hash.Hash) (*signingState, error) {
+	state := &signingState{
+		commitments:    make([]SigningCommitments, len(commitments)),
+		bindingFactors: make([]*big.Int, len(commitments)),
+	}
+	copy(state.commitments, commitments)
+	sort.Slice(state.commitments, func(i, j int) bool {
+		return state.commitments[i].Identifier < state.commitments[j].Identifier
+	})
+	encoded := make([][]byte, 0, 3*len(commitments))
+	for i := range state.commitments {
+		if state.commitments[i].Identifier == 0 {
+			return nil, ErrUnknownSigner
+		}
+		if i > 0 && state.commitments[i].Identifier == state.commitments[i-1].Identifier {
+			return nil, ErrDuplicateSigner
+		}
+		encoded = append(encoded,
+			encodeUint64(state.commitments[i].Identifier),
+			encodePoint(&state.commitments[i].D),
+			encodePoint(&state.commitments[i].E))
+	}
+
+	y := encodePoint(Y)
+	m := encodeScalar(hashToScalar(tagMessage, message))
+	b := encodeScalar(hashToScalar(tagCommitments, encoded...))
+	R := identity()
+	for i := range state.commitments {
+		state.bindingFactors[i] = hashToScalar(tagRho, y, m, b, encodeUint64(state.commitments[i].Identifier))
+		T := scalarMul(&state.commitments[i].E, state.bindingFactors[i])
+		T = add(&T, &state.commitments[i].D)
+		R = add(&R, &T)
+	}
+	if isIdentity(&R) {
+		return nil, ErrIdentityCommitment
+	}
+	state.r, state.negatedR = normalize(&R)
+
+	var Yn point
+	Yn, state.negatedY = normalize(Y)
+	var err error
+	if state.c, err = challenge(&state.r, &Yn, message, hFunc); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// index returns the index of the commitments of the signer id, or -1.
+func (state *signingState) index(id uint64) int {
+	i := sort.Search(len(state.commitments), func(i int) bool {
+		return state.commitments[i].Identifier >= id
+	})
+	if i == len(state.commitments) || state.commitments[i].Identifier != id {
+		return -1
+	}
+	return i
+}
+
+// lagrange returns the Lagrange coefficient at 0 of the i-th signer among the
+// signers, λᵢ = ∏ⱼ xⱼ/(xⱼ-xᵢ) for j ≠ i.
+func (state *signingState) lagrange(i int) *big.Int {
+	num := big.NewInt(1)
+	den := big.NewInt(1)
+	xi := new(big.Int).SetUint64(state.commitments[i].Identifier)
+	xj := new(big.Int)
+	for j := range state.commitments {
+		if j == i {
+			continue
+		}
+		xj.SetUint64(state.commitments[j].Identifier)
+		num.Mul(num, xj).Mod(num, order)
+		xj.Sub(xj, xi)
+		den.Mul(den, xj).Mod(den, order)
+	}
+	den.ModInverse(den, order)
+	return num.Mul(num, den).Mod(num, order)
+}
@@ -0,0 +1,261 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+	"hash"
+	"math/big"
+	"testing"
+)
+
+// dkg runs the distributed key generation among nbParticipants, and returns
+// the key shares of the participants and the public key package.
+func dkg(t *testing.T, threshold, nbParticipants int) ([]*KeyShare, *PublicKeyPackage) {
+	assert := require.New(t)
+
+	participants := make([]*Participant, nbParticipants)
+	round1 := make([]Round1Message, nbParticipants)
+	var err error
+	for i := range participants {
+		participants[i], round1[i], err = NewParticipant(uint64(i+1), threshold, nbParticipants, []byte("test"), rand.Reader)
+		assert.NoError(err)
+	}
+
+	received := make([][]SecretShare, nbParticipants)
+	for i := range participants {
+		shares, err := participants[i].Round2(round1)
+		assert.NoError(err)
+		for _, share := range shares {
+			received[share.To-1] = append(received[share.To-1], share)
+		}
+	}
+
+	keyShares := make([]*KeyShare, nbParticipants)
+	var pub *PublicKeyPackage
+	for i := range participants {
+		var p *PublicKeyPackage
+		keyShares[i], p, err = participants[i].Finalize(received[i])
+		assert.NoError(err)
+		if pub != nil {
+			assert.True(pub.GroupKey.Equal(&p.GroupKey))
+		}
+		pub = p
+	}
+	return keyShares, pub
+}
+
+// sign runs the two rounds of a signature of message by signers, and returns
+// the commitments and the signature shares.
+func sign(t *testing.T, signers []*KeyShare, message []byte, hFunc hash.Hash) ([]SigningCommitments, []SignatureShare) {
+	assert := require.New(t)
+
+	nonces := make([]*SigningNonces, len(signers))
+	commitments := make([]SigningCommitments, len(signers))
+	var err error
+	for i := range signers {
+		nonces[i], commitments[i], err = signers[i].Commit()
+		assert.NoError(err)
+	}
+	shares := make([]SignatureShare, len(signers))
+	for i := range signers {
+		shares[i], err = signers[i].Sign(nonces[i], commitments, message, hFunc)
+		assert.NoError(err)
+	}
+	return commitments, shares
+}
+
+func verify(t *testing.T, pub *PublicKeyPackage, sig, message []byte, hFunc hash.Hash) bool {
+	pk := eddsa.PublicKey{A: pub.PublicKey()}
+	ok, err := pk.Verify(sig, message, hFunc)
+	require.NoError(t, err)
+	return ok
+}
+
+func TestFROST(t *testing.T) {
+	assert := require.New(t)
+
+	type config struct{ threshold, nbParticipants int }
+	for _, c := range []config{
+		{threshold: 1, nbParticipants: 1},
+		{threshold: 2, nbParticipants: 3},
+		{threshold: 3, nbParticipants: 5},
+	} {
+		keyShares, pub := dkg(t, c.threshold, c.nbParticipants)
+		message := []byte("testing FROST")
+		hFunc := sha256.New()
+
+		// any set of at least threshold participants can sign
+		for _, signers := range [][]*KeyShare{keyShares[:c.threshold], keyShares[c.nbParticipants-c.threshold:], keyShares} {
+			commitments, shares := sign(t, signers, message, hFunc)
+			sig, err := Aggregate(commitments, message, shares, pub, hFunc)
+			assert.NoError(err)
+			assert.True(verify(t, pub, sig, message, hFunc), c)
+			assert.False(verify(t, pub, sig, []byte("testing FROST!"), hFunc), c)
+		}
+	}
+}
+
+func TestFROSTSecretShares(t *testing.T) {
+	assert := require.New(t)
+
+	keyShares, pub := dkg(t, 3, 5)
+
+	// the verification shares match the secret shares
+	for _, ks := range keyShares {
+		Yi := scalarMulBase(ks.secret)
+		expected := pub.VerificationShares[ks.Identifier]
+		assert.True(Yi.Equal(&expected))
+	}
+
+	// any threshold shares interpolate the group secret
+	state := new(signingState)
+	for _, id := range []uint64{1, 3, 4} {
+		state.commitments = append(state.commitments, SigningCommitments{Identifier: id})
+	}
+	s := new(big.Int)
+	for i, c := range state.commitments {
+		term := new(big.Int).Mul(state.lagrange(i), keyShares[c.Identifier-1].secret)
+		s.Add(s, term)
+	}
+	s.Mod(s, order)
+	Y := scalarMulBase(s)
+	assert.True(Y.Equal(&pub.GroupKey))
+}
+
+func TestFROSTIdentifiableAbort(t *testing.T) {
+	assert := require.New(t)
+
+	keyShares, pub := dkg(t, 3, 5)
+	signers := []*KeyShare{keyShares[0], keyShares[2], keyShares[3], keyShares[4]}
+	message := []byte("testing FROST")
+	commitments, shares := sign(t, signers, message, sha256.New())
+
+	// wrong shares of signers 3 and 5 are identified
+	shares[1].Z.Add(shares[1].Z, big.NewInt(1)).Mod(shares[1].Z, order)
+	shares[3].Z.Add(shares[3].Z, big.NewInt(1)).Mod(shares[3].Z, order)
+	_, err := Aggregate(commitments, message, shares, pub, sha256.New())
+	var culpritErr *CulpritError
+	assert.ErrorAs(err, &culpritErr)
+	assert.ErrorIs(err, ErrInvalidSignatureShare)
+	assert.Equal([]uint64{3, 5}, culpritErr.Culprits)
+
+	// a share computed for another message is identified
+	_, shares = sign(t, signers, message, sha256.New())
+	_, err = Aggregate(commitments, message, shares, pub, sha256.New())
+	assert.ErrorAs(err, &culpritErr)
+	assert.Equal([]uint64{1, 3, 4, 5}, culpritErr.Culprits)
+
+	// not enough signers
+	_, err = Aggregate(commitments[:2], message, shares[:2], pub, sha256.New())
+	assert.ErrorIs(err, ErrNotEnoughSigners)
+}
+
+func TestFROSTNonceReuse(t *testing.T) {
+	assert := require.New(t)
+
+	keyShares, _ := dkg(t, 2, 2)
+	nonces := make([]*SigningNonces, 2)
+	commitments := make([]SigningCommitments, 2)
+	var err error
+	for i := range keyShares {
+		nonces[i], commitments[i], err = keyShares[i].Commit()
+		assert.NoError(err)
+	}
+	_, err = keyShares[0].Sign(nonces[1], commitments, []byte("testing FROST"), sha256.New())
+	assert.ErrorIs(err, ErrMissingCommitment)
+	_, err = keyShares[0].Sign(nonces[0], commitments, []byte("testing FROST"), sha256.New())
+	assert.NoError(err)
+	_, err = keyShares[0].Sign(nonces[0], commitments, []byte("testing FROST"), sha256.New())
+	assert.ErrorIs(err, ErrNonceReuse)
+}
+
+func TestDKGIdentifiableAbort(t *testing.T) {
+	assert := require.New(t)
+
+	const threshold, nbParticipants = 2, 4
+	participants := make([]*Participant, nbParticipants)
+	round1 := make([]Round1Message, nbParticipants)
+	var err error
+	for i := range participants {
+		participants[i], round1[i], err = NewParticipant(uint64(i+1), threshold, nbParticipants, []byte("test"), rand.Reader)
+		assert.NoError(err)
+	}
+
+	// an invalid proof of knowledge is identified
+	var culpritErr *CulpritError
+	proofZ := round1[1].ProofZ
+	round1[1].ProofZ = new(big.Int).Add(proofZ, big.NewInt(1))
+	_, err = participants[0].Round2(round1)
+	assert.ErrorAs(err, &culpritErr)
+	assert.ErrorIs(err, ErrInvalidProofKnowledge)
+	assert.Equal([]uint64{2}, culpritErr.Culprits)
+	round1[1].ProofZ = proofZ
+
+	// a proof of knowledge is bound to the context
+	_, otherRound1, err := NewParticipant(3, threshold, nbParticipants, []byte("other"), rand.Reader)
+	assert.NoError(err)
+	_, err = participants[0].Round2([]Round1Message{round1[0], round1[1], otherRound1, round1[3]})
+	assert.ErrorIs(err, ErrInvalidProofKnowledge)
+
+	// an invalid secret share is identified
+	received := make([][]SecretShare, nbParticipants)
+	for i := range participants {
+		shares, err := participants[i].Round2(round1)
+		assert.NoError(err)
+		for _, share := range shares {
+			received[share.To-1] = append(received[share.To-1], share)
+		}
+	}
+	for i := range received[0] {
+		if received[0][i].From == 4 {
+			received[0][i].Value = new(big.Int).Add(received[0][i].Value, big.NewInt(1))
+		}
+	}
+	_, _, err = participants[0].Finalize(received[0])
+	assert.ErrorAs(err, &culpritErr)
+	assert.ErrorIs(err, ErrInvalidSecretShare)
+	assert.Equal([]uint64{4}, culpritErr.Culprits)
+
+	// a missing secret share is identified
+	_, _, err = participants[1].Finalize(received[1][1:])
+	assert.ErrorAs(err, &culpritErr)
+	assert.ErrorIs(err, ErrMissingSecretShare)
+	assert.Equal([]uint64{received[1][0].From}, culpritErr.Culprits)
+
+	_, _, err = NewParticipant(1, 3, 2, nil, rand.Reader)
+	assert.ErrorIs(err, ErrInvalidThreshold)
+	_, _, err = NewParticipant(0, 1, 2, nil, rand.Reader)
+	assert.ErrorIs(err, ErrInvalidIdentifier)
+}
+
+func TestMarshal(t *testing.T) {
+	assert := require.New(t)
+
+	keyShares, pub := dkg(t, 2, 3)
+	message := []byte("testing FROST")
+	commitments, shares := sign(t, keyShares[:2], message, sha256.New())
+
+	for i := range commitments {
+		var c SigningCommitments
+		n, err := c.SetBytes(commitments[i].Bytes())
+		assert.NoError(err)
+		assert.Equal(sizeCommitments, n)
+		commitments[i] = c
+
+		var s SignatureShare
+		n, err = s.SetBytes(shares[i].Bytes())
+		assert.NoError(err)
+		assert.Equal(sizeSignatureShare, n)
+		shares[i] = s
+	}
+	sig, err := Aggregate(commitments, message, shares, pub, sha256.New())
+	assert.NoError(err)
+	assert.True(verify(t, pub, sig, message, sha256.New()))
+}
@@ -0,0 +1,183 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards"
+)
+
+// point is an element of the prime order group the signatures are computed in.
+type point = twistededwards.PointAffine
+
+const (
+	sizeScalar    = fr.Bytes
+	sizePoint     = fr.Bytes
+	sizeSignature = sizePoint + sizeScalar
+)
+
+var (
+	errInvalidPoint = errors.New("invalid point encoding")
+	errZeroScalar   = errors.New("scalar is zero")
+	errHashNeeded   = errors.New("hFunc cannot be nil. We need a hash for Fiat-Shamir")
+)
+
+// order of the prime subgroup of the curve
+var order = func() *big.Int {
+	curve := twistededwards.GetEdwardsCurve()
+	return &curve.Order
+}()
+
+func identity() point {
+	var p point
+	p.Y.SetOne()
+	return p
+}
+
+func scalarMulBase(s *big.Int) point {
+	var p point
+	curve := twistededwards.GetEdwardsCurve()
+	p.ScalarMultiplication(&curve.Base, s)
+	return p
+}
+
+func scalarMul(p *point, s *big.Int) point {
+	var res point
+	res.ScalarMultiplication(p, s)
+	return res
+}
+
+func add(p, q *point) point {
+	var res point
+	res.Add(p, q)
+	return res
+}
+
+func isIdentity(p *point) bool {
+	return p.IsZero()
+}
+
+// normalize returns the point standing for p in the signatures, and whether it
+// is -p.
+// EdDSA uses the points themselves.
+func normalize(p *point) (point, bool) {
+	return *p, false
+}
+
+func encodePoint(p *point) []byte {
+	b := p.Bytes()
+	return b[:]
+}
+
+// decodePoint reads a point from buf, checking that it is a non-identity
+// element of the prime order group.
+func decodePoint(buf []byte) (point, error) {
+	var p point
+	if _, err := p.SetBytes(buf); err != nil {
+		return p, err
+	}
+	if !p.IsOnCurve() || p.IsZero() {
+		return p, errInvalidPoint
+	}
+	var q point
+	if q.ScalarMultiplication(&p, order); !q.IsZero() {
+		return p, errInvalidPoint
+	}
+	return p, nil
+}
+
+func encodeScalar(s *big.Int) []byte {
+	var b [sizeScalar]byte
+	s.FillBytes(b[:])
+	return b[:]
+}
+
+// decodeScalar reads a scalar from buf, checking that it is reduced.
+func decodeScalar(buf []byte) (*big.Int, error) {
+	if len(buf) < sizeScalar {
+		return nil, io.ErrShortBuffer
+	}
+	s := new(big.Int).SetBytes(buf[:sizeScalar])
+	if s.Cmp(order) >= 0 {
+		return nil, errors.New("scalar is not reduced")
+	}
+	return s, nil
+}
+
+func encodeUint64(v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return b[:]
+}
+
+// randomScalar returns a uniformly random scalar in [1, order-1].
+func randomScalar(rand io.Reader) (*big.Int, error) {
+	var buf [sizeScalar + 16]byte
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(buf[:])
+	n := new(big.Int).Sub(order, big.NewInt(1))
+	return k.Mod(k, n).Add(k, big.NewInt(1)), nil
+}
+
+// hashToScalar returns SHA512(len(tag) ‖ tag ‖ x₁ ‖ … ‖ xₖ) mod order.
+func hashToScalar(tag string, data ...[]byte) *big.Int {
+	h := sha512.New()
+	h.Write(encodeUint64(uint64(len(tag))))
+	h.Write([]byte(tag))
+	for _, d := range data {
+		h.Write(d)
+	}
+	e := new(big.Int).SetBytes(h.Sum(nil))
+	return e.Mod(e, order)
+}
+
+// challenge returns the challenge of the signature of message under the
+// normalized public key Y, with normalized nonce commitment R, as computed by
+// the verifier.
+// It is H(R.X ‖ R.Y ‖ Y.X ‖ Y.Y ‖ m) mod order, with H = hFunc.
+func challenge(R, Y *point, message []byte, hFunc hash.Hash) (*big.Int, error) {
+	if hFunc == nil {
+		return nil, errHashNeeded
+	}
+	hFunc.Reset()
+	rx := R.X.Bytes()
+	ry := R.Y.Bytes()
+	yx := Y.X.Bytes()
+	yy := Y.Y.Bytes()
+	for _, b := range [][]byte{rx[:], ry[:], yx[:], yy[:], message} {
+		if _, err := hFunc.Write(b); err != nil {
+			return nil, err
+		}
+	}
+	e := new(big.Int).SetBytes(hFunc.Sum(nil))
+	return e.Mod(e, order), nil
+}
+
+// signatureBytes returns the encoding of the signature (R, s) for the normalized
+// nonce commitment R.
+// It is the compressed R ‖ s, as in the eddsa package.
+func signatureBytes(R *point, s *big.Int) []byte {
+	res := make([]byte, 0, sizeSignature)
+	res = append(res, encodePoint(R)...)
+	return append(res, encodeScalar(s)...)
+}
+
+// verifyShare checks s⋅G = R + e⋅Y for a share of signature s.
+func verifyShare(s *big.Int, R, Y *point, e *big.Int) bool {
+	lhs := scalarMulBase(s)
+	rhs := scalarMul(Y, e)
+	rhs = add(&rhs, R)
+	return lhs.Equal(&rhs)
+}
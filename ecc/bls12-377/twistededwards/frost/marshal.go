@@ -0,0 +1,68 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package frost
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+const (
+	sizeCommitments    = 8 + 2*sizePoint
+	sizeSignatureShare = 8 + sizeScalar
+)
+
+// Bytes returns the binary representation of the commitments, as
+// identifier ‖ D ‖ E.
+func (c *SigningCommitments) Bytes() []byte {
+	res := make([]byte, 0, sizeCommitments)
+	res = append(res, encodeUint64(c.Identifier)...)
+	res = append(res, encodePoint(&c.D)...)
+	return append(res, encodePoint(&c.E)...)
+}
+
+// SetBytes sets c from its binary representation in buf, checking that its
+// points are valid group elements.
+// It returns the number of bytes read from the buffer.
+func (c *SigningCommitments) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeCommitments {
+		return 0, io.ErrShortBuffer
+	}
+	D, err := decodePoint(buf[8 : 8+sizePoint])
+	if err != nil {
+		return 0, err
+	}
+	E, err := decodePoint(buf[8+sizePoint : sizeCommitments])
+	if err != nil {
+		return 0, err
+	}
+	c.Identifier = binary.BigEndian.Uint64(buf[:8])
+	c.D, c.E = D, E
+	return sizeCommitments, nil
+}
+
+// Bytes returns the binary representation of the signature share, as
+// identifier ‖ z.
+func (share *SignatureShare) Bytes() []byte {
+	res := make([]byte, 0, sizeSignatureShare)
+	res = append(res, encodeUint64(share.Identifier)...)
+	return append(res, encodeScalar(share.Z)...)
+}
+
+// SetBytes sets share from its binary representation in buf.
+// It returns the number of bytes read from the buffer.
+func (share *SignatureShare) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizeSignatureShare {
+		return 0, io.ErrShortBuffer
+	}
+	z, err := decodeScalar(buf[8:sizeSignatureShare])
+	if err != nil {
+		return 0, err
+	}
+	share.Identifier = binary.BigEndian.Uint64(buf[:8])
+	share.Z = z
+	return sizeSignatureShare, nil
+}
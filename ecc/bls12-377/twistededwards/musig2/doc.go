@@ -0,0 +1,24 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package musig2 provides MuSig2 multi-signatures on bls12-377's twisted edwards curve.
+//
+// A set of signers aggregates its public keys into a single key, and signs a
+// message together in two rounds: the signers first exchange public nonces,
+// then partial signatures, which sum up to a single signature.
+// The signature is an EdDSA signature under the aggregated key, as verified by
+// the eddsa package with the same hash function.
+//
+// The public nonces can be exchanged before the message is known, but each
+// secret nonce must be used for a single signature.
+//
+// Documentation:
+//   - MuSig2: https://eprint.iacr.org/2020/1261
+//   - BIP-327: https://github.com/bitcoin/bips/blob/master/bip-0327.mediawiki
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package musig2
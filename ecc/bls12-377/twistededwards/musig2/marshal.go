@@ -0,0 +1,72 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package musig2
+
+import (
+	"io"
+)
+
+// Bytes returns the binary representation of the public key.
+func (pk *PublicKey) Bytes() []byte {
+	return encodePoint(&pk.A)
+}
+
+// SetBytes sets pk from its binary representation in buf, checking that it is
+// a valid group element.
+// It returns the number of bytes read from the buffer.
+func (pk *PublicKey) SetBytes(buf []byte) (int, error) {
+	if len(buf) < sizePoint {
+		return 0, io.ErrShortBuffer
+	}
+	A, err := decodePoint(buf[:sizePoint])
+	if err != nil {
+		return 0, err
+	}
+	pk.A = A
+	return sizePoint, nil
+}
+
+// Bytes returns the binary representation of the public nonce, as R₁ ‖ R₂.
+func (nonce *PublicNonce) Bytes() []byte {
+	res := make([]byte, 0, 2*sizePoint)
+	res = append(res, encodePoint(&nonce.R1)...)
+	return append(res, encodePoint(&nonce.R2)...)
+}
+
+// SetBytes sets nonce from its binary representation in buf, checking that
+// its points are valid group elements.
+// It returns the number of bytes read from the buffer.
+func (nonce *PublicNonce) SetBytes(buf []byte) (int, error) {
+	if len(buf) < 2*sizePoint {
+		return 0, io.ErrShortBuffer
+	}
+	R1, err := decodePoint(buf[:sizePoint])
+	if err != nil {
+		return 0, err
+	}
+	R2, err := decodePoint(buf[sizePoint : 2*sizePoint])
+	if err != nil {
+		return 0, err
+	}
+	nonce.R1, nonce.R2 = R1, R2
+	return 2 * sizePoint, nil
+}
+
+// Bytes returns the binary representation of the partial signature.
+func (sig *PartialSignature) Bytes() []byte {
+	return encodeScalar(sig.S)
+}
+
+// SetBytes sets sig from its binary representation in buf.
+// It returns the number of bytes read from the buffer.
+func (sig *PartialSignature) SetBytes(buf []byte) (int, error) {
+	s, err := decodeScalar(buf)
+	if err != nil {
+		return 0, err
+	}
+	sig.S = s
+	return sizeScalar, nil
+}
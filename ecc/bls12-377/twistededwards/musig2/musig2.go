@@ -0,0 +1,330 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package musig2
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+)
+
+var (
+	ErrNoPublicKeys            = errors.New("no public keys to aggregate")
+	ErrIdentityKey             = errors.New("aggregated public key is the identity")
+	ErrUnknownPublicKey        = errors.New("public key is not one of the aggregated keys")
+	ErrNoNonces                = errors.New("no public nonces to aggregate")
+	ErrNonceReuse              = errors.New("secret nonce was already used")
+	ErrNonceMismatch           = errors.New("secret nonce was generated for another key")
+	ErrInvalidNbSignatures     = errors.New("number of partial signatures, public nonces and public keys differ")
+	ErrInvalidPartialSignature = errors.New("invalid partial signature")
+)
+
+// tags of the hashes of MuSig2
+const (
+	tagKeyAggList = "MuSig2/KeyAgg list"
+	tagKeyAggCoef = "MuSig2/KeyAgg coefficient"
+	tagNonce      = "MuSig2/nonce"
+	tagNonceCoef  = "MuSig2/noncecoef"
+)
+
+// PublicKey is the public key of a signer, a group element.
+type PublicKey struct {
+	A point
+}
+
+// PrivateKey is the private key of a signer.
+type PrivateKey struct {
+	PublicKey PublicKey
+	scalar    [sizeScalar]byte // secret key, in big Endian
+}
+
+// AggregatedKey is the aggregation of the public keys of the signers, with
+// their coefficients.
+type AggregatedKey struct {
+	// Q = ∑ᵢaᵢ⋅Pᵢ
+	Q point
+
+	publicKeys   []PublicKey
+	coefficients []*big.Int
+}
+
+// SecretNonce is the secret nonce of a signer, to be used for a single
+// signature.
+type SecretNonce struct {
+	k1, k2    *big.Int
+	publicKey PublicKey
+}
+
+// PublicNonce is the public nonce of a signer, or the aggregation of the public
+// nonces of all the signers.
+type PublicNonce struct {
+	R1, R2 point
+}
+
+// PartialSignature is the share of the signature of a signer.
+type PartialSignature struct {
+	S *big.Int
+}
+
+// Session holds the values shared by all the signers for signing a message,
+// once the public nonces are aggregated.
+type Session struct {
+	key      *AggregatedKey
+	b        *big.Int // nonce coefficient
+	e        *big.Int // challenge
+	r        point    // normalized nonce commitment R
+	negatedQ bool     // whether the signature verifies under -Q
+	negatedR bool     // whether r = -R
+}
+
+// GenerateKey generates a public and private key pair.
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	d, err := randomScalar(rand)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := new(PrivateKey)
+	d.FillBytes(privateKey.scalar[:])
+	privateKey.PublicKey.A = scalarMulBase(d)
+	return privateKey, nil
+}
+
+// AggregateKeys aggregates the public keys of the signers, in the order they
+// are given, following the KeyAgg algorithm of MuSig2:
+//
+//	L = hash_list(P₁ ‖ … ‖ Pᵤ)
+//	aᵢ = hash_coef(L ‖ Pᵢ), or 1 if Pᵢ is the second distinct key
+//	Q = ∑ᵢaᵢ⋅Pᵢ
+func AggregateKeys(publicKeys []PublicKey) (*AggregatedKey, error) {
+	if len(publicKeys) == 0 {
+		return nil, ErrNoPublicKeys
+	}
+
+	encoded := make([][]byte, len(publicKeys))
+	for i := range publicKeys {
+		encoded[i] = encodePoint(&publicKeys[i].A)
+	}
+	lBin := encodeScalar(hashToScalar(tagKeyAggList, encoded...))
+
+	// the coefficient of the second distinct key is 1
+	second := -1
+	for i := 1; i < len(publicKeys); i++ {
+		if !publicKeys[i].A.Equal(&publicKeys[0].A) {
+			second = i
+			break
+		}
+	}
+
+	res := &AggregatedKey{
+		publicKeys:   make([]PublicKey, len(publicKeys)),
+		coefficients: make([]*big.Int, len(publicKeys)),
+		Q:            identity(),
+	}
+	copy(res.publicKeys, publicKeys)
+	for i := range publicKeys {
+		if second != -1 && publicKeys[i].A.Equal(&publicKeys[second].A) {
+			res.coefficients[i] = big.NewInt(1)
+		} else {
+			res.coefficients[i] = hashToScalar(tagKeyAggCoef, lBin, encoded[i])
+		}
+		aP := scalarMul(&publicKeys[i].A, res.coefficients[i])
+		res.Q = add(&res.Q, &aP)
+	}
+	if isIdentity(&res.Q) {
+		return nil, ErrIdentityKey
+	}
+	return res, nil
+}
+
+// PublicKey returns the key the aggregated signatures verify under.
+func (key *AggregatedKey) PublicKey() point {
+	Q, _ := normalize(&key.Q)
+	return Q
+}
+
+// coefficient returns the coefficient of the public key pk.
+func (key *AggregatedKey) coefficient(pk *PublicKey) (*big.Int, error) {
+	for i := range key.publicKeys {
+		if key.publicKeys[i].A.Equal(&pk.A) {
+			return key.coefficients[i], nil
+		}
+	}
+	return nil, ErrUnknownPublicKey
+}
+
+// GenerateNonce generates the secret and public nonces of privKey for signing
+// message under the aggregated key. The nonces are derived from randomness
+// read from rand, the secret key, the aggregated key and the message, so that
+// a weak source of randomness does not leak the secret key:
+//
+//	kⱼ = hash_nonce(rand ‖ sk ‖ Q ‖ m ‖ j) for j = 1, 2
+//
+// The secret nonce must be used for a single signature.
+func GenerateNonce(rand io.Reader, privKey *PrivateKey, key *AggregatedKey, message []byte) (*SecretNonce, PublicNonce, error) {
+	var pubNonce PublicNonce
+	var r [32]byte
+	if _, err := io.ReadFull(rand, r[:]); err != nil {
+		return nil, pubNonce, err
+	}
+	q := encodePoint(&key.Q)
+	secNonce := &SecretNonce{
+		k1:        hashToScalar(tagNonce, r[:], privKey.scalar[:], q, message, []byte{1}),
+		k2:        hashToScalar(tagNonce, r[:], privKey.scalar[:], q, message, []byte{2}),
+		publicKey: privKey.PublicKey,
+	}
+	if secNonce.k1.Sign() == 0 || secNonce.k2.Sign() == 0 {
+		return nil, pubNonce, errZeroScalar
+	}
+	pubNonce.R1 = scalarMulBase(secNonce.k1)
+	pubNonce.R2 = scalarMulBase(secNonce.k2)
+	return secNonce, pubNonce, nil
+}
+
+// AggregateNonces returns the sum of the public nonces of the signers.
+func AggregateNonces(nonces []PublicNonce) (PublicNonce, error) {
+	var res PublicNonce
+	if len(nonces) == 0 {
+		return res, ErrNoNonces
+	}
+	res = nonces[0]
+	for i := 1; i < len(nonces); i++ {
+		res.R1 = add(&res.R1, &nonces[i].R1)
+		res.R2 = add(&res.R2, &nonces[i].R2)
+	}
+	return res, nil
+}
+
+// NewSession returns the signing session of message under the aggregated key,
+// for the aggregated public nonce:
+//
+//	b = hash_noncecoef(R₁ ‖ R₂ ‖ Q ‖ m)
+//	R = R₁ + b⋅R₂, or the generator if it is the identity
+//	e = challenge(R, Q, m)
+//
+// hFunc is used to compute the challenge as the verifier of the aggregated
+// signature does.
+func NewSession(key *AggregatedKey, aggNonce PublicNonce, message []byte, hFunc hash.Hash) (*Session, error) {
+	s := &Session{key: key}
+	s.b = hashToScalar(tagNonceCoef, encodePoint(&aggNonce.R1), encodePoint(&aggNonce.R2), encodePoint(&key.Q), message)
+
+	R := scalarMul(&aggNonce.R2, s.b)
+	R = add(&R, &aggNonce.R1)
+	if isIdentity(&R) {
+		R = scalarMulBase(big.NewInt(1))
+	}
+	s.r, s.negatedR = normalize(&R)
+
+	var Q point
+	Q, s.negatedQ = normalize(&key.Q)
+	var err error
+	if s.e, err = challenge(&s.r, &Q, message, hFunc); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Sign returns the partial signature of privKey with secNonce. The secret nonce
+// is erased, so that it cannot be used again:
+//
+//	sᵢ = k₁ + b⋅k₂ + e⋅aᵢ⋅dᵢ
+//
+// where the nonces and the secret key are negated if needed by the
+// normalization of R and Q.
+func (s *Session) Sign(secNonce *SecretNonce, privKey *PrivateKey) (PartialSignature, error) {
+	var res PartialSignature
+	if secNonce.k1 == nil {
+		return res, ErrNonceReuse
+	}
+	if !secNonce.publicKey.A.Equal(&privKey.PublicKey.A) {
+		return res, ErrNonceMismatch
+	}
+	a, err := s.key.coefficient(&privKey.PublicKey)
+	if err != nil {
+		return res, err
+	}
+	k1, k2 := secNonce.k1, secNonce.k2
+	secNonce.k1, secNonce.k2 = nil, nil
+
+	// k = k₁ + b⋅k₂
+	k := new(big.Int).Mul(s.b, k2)
+	k.Add(k, k1).Mod(k, order)
+	if s.negatedR {
+		k.Sub(order, k)
+	}
+	d := new(big.Int).SetBytes(privKey.scalar[:])
+	if s.negatedQ {
+		d.Sub(order, d)
+	}
+
+	res.S = d.Mul(d, a).Mul(d, s.e)
+	res.S.Add(res.S, k).Mod(res.S, order)
+	return res, nil
+}
+
+// VerifyPartial checks the partial signature of the signer of public key pk
+// and public nonce pubNonce:
+//
+//	sᵢ⋅G = R₁ᵢ + b⋅R₂ᵢ + e⋅aᵢ⋅Pᵢ
+//
+// where the nonce commitment and the public key are negated if needed by the
+// normalization of R and Q.
+func (s *Session) VerifyPartial(sig PartialSignature, pubNonce PublicNonce, pk PublicKey) error {
+	a, err := s.key.coefficient(&pk)
+	if err != nil {
+		return err
+	}
+	if sig.S == nil || sig.S.Cmp(order) >= 0 {
+		return ErrInvalidPartialSignature
+	}
+	R := scalarMul(&pubNonce.R2, s.b)
+	R = add(&R, &pubNonce.R1)
+	if s.negatedR {
+		R.Neg(&R)
+	}
+	P := pk.A
+	if s.negatedQ {
+		P.Neg(&P)
+	}
+	ea := new(big.Int).Mul(s.e, a)
+	ea.Mod(ea, order)
+	if !verifyShare(sig.S, &R, &P, ea) {
+		return ErrInvalidPartialSignature
+	}
+	return nil
+}
+
+// Aggregate returns the signature of the session from the partial signatures
+// of all the signers, s = ∑ᵢsᵢ. The signature verifies under
+// AggregatedKey.PublicKey.
+func (s *Session) Aggregate(sigs []PartialSignature) ([]byte, error) {
+	sum := new(big.Int)
+	for i := range sigs {
+		if sigs[i].S == nil || sigs[i].S.Cmp(order) >= 0 {
+			return nil, fmt.Errorf("signer %d: %w", i, ErrInvalidPartialSignature)
+		}
+		sum.Add(sum, sigs[i].S)
+	}
+	sum.Mod(sum, order)
+	return signatureBytes(&s.r, sum), nil
+}
+
+// VerifyAndAggregate checks the partial signatures, where sigs[i] is the
+// partial signature of the signer of public nonce nonces[i] and public key
+// publicKeys[i], before aggregating them. The error identifies the first
+// invalid partial signature.
+func (s *Session) VerifyAndAggregate(sigs []PartialSignature, nonces []PublicNonce, publicKeys []PublicKey) ([]byte, error) {
+	if len(nonces) != len(sigs) || len(publicKeys) != len(sigs) {
+		return nil, ErrInvalidNbSignatures
+	}
+	for i := range sigs {
+		if err := s.VerifyPartial(sigs[i], nonces[i], publicKeys[i]); err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+	}
+	return s.Aggregate(sigs)
+}
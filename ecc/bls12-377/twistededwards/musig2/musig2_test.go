@@ -0,0 +1,201 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package musig2
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"github.com/consensys/gnark-crypto/ecc/bls12-377/twistededwards/eddsa"
+	"github.com/stretchr/testify/require"
+	"hash"
+	"math/big"
+	"testing"
+)
+
+// sign runs a MuSig2 session of the signers on message, and returns the
+// session with the public nonces and partial signatures.
+func sign(t *testing.T, privKeys []*PrivateKey, key *AggregatedKey, message []byte, hFunc hash.Hash) (*Session, []PublicNonce, []PartialSignature) {
+	assert := require.New(t)
+
+	secNonces := make([]*SecretNonce, len(privKeys))
+	pubNonces := make([]PublicNonce, len(privKeys))
+	var err error
+	for i := range privKeys {
+		secNonces[i], pubNonces[i], err = GenerateNonce(rand.Reader, privKeys[i], key, message)
+		assert.NoError(err)
+	}
+	aggNonce, err := AggregateNonces(pubNonces)
+	assert.NoError(err)
+
+	session, err := NewSession(key, aggNonce, message, hFunc)
+	assert.NoError(err)
+	sigs := make([]PartialSignature, len(privKeys))
+	for i := range privKeys {
+		sigs[i], err = session.Sign(secNonces[i], privKeys[i])
+		assert.NoError(err)
+	}
+	return session, pubNonces, sigs
+}
+
+func setup(t *testing.T, nbSigners int) ([]*PrivateKey, []PublicKey, *AggregatedKey) {
+	assert := require.New(t)
+
+	privKeys := make([]*PrivateKey, nbSigners)
+	publicKeys := make([]PublicKey, nbSigners)
+	var err error
+	for i := range privKeys {
+		privKeys[i], err = GenerateKey(rand.Reader)
+		assert.NoError(err)
+		publicKeys[i] = privKeys[i].PublicKey
+	}
+	key, err := AggregateKeys(publicKeys)
+	assert.NoError(err)
+	return privKeys, publicKeys, key
+}
+
+func verify(t *testing.T, key *AggregatedKey, sig, message []byte, hFunc hash.Hash) bool {
+	pk := eddsa.PublicKey{A: key.PublicKey()}
+	ok, err := pk.Verify(sig, message, hFunc)
+	require.NoError(t, err)
+	return ok
+}
+
+func TestMuSig2(t *testing.T) {
+	assert := require.New(t)
+
+	for _, nbSigners := range []int{1, 2, 5} {
+		privKeys, publicKeys, key := setup(t, nbSigners)
+		message := []byte("testing MuSig2")
+		hFunc := sha256.New()
+
+		session, pubNonces, sigs := sign(t, privKeys, key, message, hFunc)
+		for i := range sigs {
+			assert.NoError(session.VerifyPartial(sigs[i], pubNonces[i], publicKeys[i]), nbSigners)
+		}
+		sig, err := session.VerifyAndAggregate(sigs, pubNonces, publicKeys)
+		assert.NoError(err)
+
+		assert.True(verify(t, key, sig, message, hFunc), nbSigners)
+		assert.False(verify(t, key, sig, []byte("testing MuSig3"), hFunc), nbSigners)
+	}
+}
+
+func TestMuSig2DuplicateKeys(t *testing.T) {
+	assert := require.New(t)
+
+	privKeys, publicKeys, _ := setup(t, 3)
+	privKeys = append(privKeys, privKeys[1])
+	publicKeys = append(publicKeys, publicKeys[1])
+	key, err := AggregateKeys(publicKeys)
+	assert.NoError(err)
+
+	message := []byte("testing MuSig2")
+	session, pubNonces, sigs := sign(t, privKeys, key, message, sha256.New())
+	sig, err := session.VerifyAndAggregate(sigs, pubNonces, publicKeys)
+	assert.NoError(err)
+	assert.True(verify(t, key, sig, message, sha256.New()))
+}
+
+func TestMuSig2InvalidPartialSignature(t *testing.T) {
+	assert := require.New(t)
+
+	privKeys, publicKeys, key := setup(t, 4)
+	message := []byte("testing MuSig2")
+	session, pubNonces, sigs := sign(t, privKeys, key, message, sha256.New())
+
+	// a wrong partial signature is identified
+	sigs[2].S.Add(sigs[2].S, big.NewInt(1)).Mod(sigs[2].S, order)
+	_, err := session.VerifyAndAggregate(sigs, pubNonces, publicKeys)
+	assert.ErrorIs(err, ErrInvalidPartialSignature)
+	assert.ErrorContains(err, "signer 2")
+
+	// and makes the signature invalid
+	sig, err := session.Aggregate(sigs)
+	assert.NoError(err)
+	assert.False(verify(t, key, sig, message, sha256.New()))
+
+	// a partial signature is checked against its own nonce
+	assert.ErrorIs(session.VerifyPartial(sigs[1], pubNonces[0], publicKeys[1]), ErrInvalidPartialSignature)
+
+	// a signer outside of the aggregated keys
+	other, err := GenerateKey(rand.Reader)
+	assert.NoError(err)
+	assert.ErrorIs(session.VerifyPartial(sigs[1], pubNonces[1], other.PublicKey), ErrUnknownPublicKey)
+}
+
+func TestMuSig2NonceReuse(t *testing.T) {
+	assert := require.New(t)
+
+	privKeys, _, key := setup(t, 2)
+	message := []byte("testing MuSig2")
+	secNonces := make([]*SecretNonce, 2)
+	pubNonces := make([]PublicNonce, 2)
+	var err error
+	for i := range privKeys {
+		secNonces[i], pubNonces[i], err = GenerateNonce(rand.Reader, privKeys[i], key, message)
+		assert.NoError(err)
+	}
+	aggNonce, err := AggregateNonces(pubNonces)
+	assert.NoError(err)
+	session, err := NewSession(key, aggNonce, message, sha256.New())
+	assert.NoError(err)
+
+	_, err = session.Sign(secNonces[0], privKeys[1])
+	assert.ErrorIs(err, ErrNonceMismatch)
+	_, err = session.Sign(secNonces[0], privKeys[0])
+	assert.NoError(err)
+	_, err = session.Sign(secNonces[0], privKeys[0])
+	assert.ErrorIs(err, ErrNonceReuse)
+}
+
+func TestGenerateNonce(t *testing.T) {
+	assert := require.New(t)
+
+	privKeys, _, key := setup(t, 2)
+	message := []byte("testing MuSig2")
+
+	// the nonces are derived from the randomness, the secret key and the
+	// message
+	seed := bytes.Repeat([]byte{42}, 32)
+	_, nonce, err := GenerateNonce(bytes.NewReader(seed), privKeys[0], key, message)
+	assert.NoError(err)
+	_, same, err := GenerateNonce(bytes.NewReader(seed), privKeys[0], key, message)
+	assert.NoError(err)
+	assert.Equal(nonce, same)
+	_, other, err := GenerateNonce(bytes.NewReader(seed), privKeys[1], key, message)
+	assert.NoError(err)
+	assert.NotEqual(nonce, other)
+	_, other, err = GenerateNonce(bytes.NewReader(seed), privKeys[0], key, []byte("other message"))
+	assert.NoError(err)
+	assert.NotEqual(nonce, other)
+
+	_, _, err = GenerateNonce(bytes.NewReader(seed[:31]), privKeys[0], key, message)
+	assert.Error(err)
+}
+
+func TestMarshal(t *testing.T) {
+	assert := require.New(t)
+
+	privKeys, publicKeys, key := setup(t, 2)
+	session, pubNonces, sigs := sign(t, privKeys, key, []byte("testing MuSig2"), sha256.New())
+
+	var pk PublicKey
+	n, err := pk.SetBytes(publicKeys[0].Bytes())
+	assert.NoError(err)
+	assert.Equal(sizePoint, n)
+	assert.True(pk.A.Equal(&publicKeys[0].A))
+
+	var nonce PublicNonce
+	_, err = nonce.SetBytes(pubNonces[1].Bytes())
+	assert.NoError(err)
+	assert.Equal(pubNonces[1].Bytes(), nonce.Bytes())
+
+	var sig PartialSignature
+	_, err = sig.SetBytes(sigs[0].Bytes())
+	assert.NoError(err)
+	assert.NoError(session.VerifyPartial(sig, pubNonces[0], publicKeys[0]))
+}
@@ -0,0 +1,373 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package accumulator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+var (
+	ErrExists    = errors.New("the element is already in the accumulator")
+	ErrNotExists = errors.New("the element is not in the accumulator")
+	ErrEmpty     = errors.New("no element given")
+	ErrFull      = errors.New("the number of elements exceeds the size of the SRS")
+)
+
+// hashDST is the domain separation tag of the hash of the elements to scalars
+var hashDST = []byte("BILINEAR_ACCUMULATOR_BLS12-381_V1_")
+
+// HashToElement returns the scalar to which an element is hashed.
+func HashToElement(element []byte) fr.Element {
+	res, err := fr.Hash(element, hashDST, 1)
+	if err != nil {
+		// fr.Hash only fails with a domain separation tag of more than 255 bytes
+		panic(err)
+	}
+	return res[0]
+}
+
+// Accumulator is the state of the manager of a bilinear-map accumulator: the
+// set of the scalars of its elements, the polynomial ∏ (X + xᵢ) and its
+// commitment.
+type Accumulator struct {
+	pk       kzg.ProvingKey
+	trapdoor *fr.Element
+
+	elements map[fr.Element]struct{}
+	poly     []fr.Element // coefficients of ∏ (X + xᵢ), in increasing degree
+	value    bls12381.G1Affine
+}
+
+// Option configures an Accumulator.
+type Option func(*Accumulator)
+
+// WithTrapdoor sets the secret α of the SRS, with which the updates and the
+// witnesses are computed with a scalar multiplication instead of a
+// multi-exponentiation.
+func WithTrapdoor(alpha *big.Int) Option {
+	return func(a *Accumulator) {
+		a.trapdoor = new(fr.Element).SetBigInt(alpha)
+	}
+}
+
+// New returns an empty accumulator, whose value is G₁, using the KZG proving
+// key. It holds at most len(pk.G1)-1 elements.
+func New(pk kzg.ProvingKey, opts ...Option) *Accumulator {
+	a := &Accumulator{
+		pk:       pk,
+		elements: make(map[fr.Element]struct{}),
+		poly:     []fr.Element{fr.One()},
+		value:    pk.G1[0],
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Value returns the value of the accumulator.
+func (a *Accumulator) Value() bls12381.G1Affine {
+	return a.value
+}
+
+// Len returns the number of elements in the accumulator.
+func (a *Accumulator) Len() int {
+	return len(a.elements)
+}
+
+// Contains returns true if the element is in the accumulator.
+func (a *Accumulator) Contains(element []byte) bool {
+	_, ok := a.elements[HashToElement(element)]
+	return ok
+}
+
+// Update is a batch of additions or deletions, published by the manager of
+// the accumulator so that the holders of witnesses can update them.
+type Update struct {
+	// Added and Deleted are the scalars of the added or deleted elements,
+	// one of them being empty.
+	Added, Deleted []fr.Element
+
+	// Values are the successive values of the accumulator: Values[0] before
+	// the update, and Values[i+1] after the i-th element.
+	Values []bls12381.G1Affine
+}
+
+// Add adds the elements to the accumulator, and returns the update.
+func (a *Accumulator) Add(elements ...[]byte) (*Update, error) {
+	xs, err := a.hashElements(elements, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(a.poly)+len(xs) > len(a.pk.G1) {
+		return nil, ErrFull
+	}
+	update := &Update{
+		Added:  xs,
+		Values: make([]bls12381.G1Affine, 1, len(xs)+1),
+	}
+	update.Values[0] = a.value
+	for _, x := range xs {
+		a.elements[x] = struct{}{}
+		a.poly = mulByXPlus(a.poly, x)
+
+		// A' = [α + x]A
+		if a.trapdoor != nil {
+			var s fr.Element
+			s.Add(a.trapdoor, &x)
+			scalarMul(&a.value, &a.value, &s)
+		} else if a.value, err = kzg.Commit(a.poly, a.pk); err != nil {
+			return nil, err
+		}
+		update.Values = append(update.Values, a.value)
+	}
+	return update, nil
+}
+
+// Delete deletes the elements from the accumulator, and returns the update.
+func (a *Accumulator) Delete(elements ...[]byte) (*Update, error) {
+	xs, err := a.hashElements(elements, true)
+	if err != nil {
+		return nil, err
+	}
+	update := &Update{
+		Deleted: xs,
+		Values:  make([]bls12381.G1Affine, 1, len(xs)+1),
+	}
+	update.Values[0] = a.value
+	for _, x := range xs {
+		delete(a.elements, x)
+		a.poly, _ = divideByXPlus(a.poly, x)
+
+		// A' = [1/(α + x)]A
+		if a.trapdoor != nil {
+			var s fr.Element
+			s.Add(a.trapdoor, &x).Inverse(&s)
+			scalarMul(&a.value, &a.value, &s)
+		} else if a.value, err = kzg.Commit(a.poly, a.pk); err != nil {
+			return nil, err
+		}
+		update.Values = append(update.Values, a.value)
+	}
+	return update, nil
+}
+
+// MembershipWitness returns the membership witness of the element, the value
+// of the accumulator without it.
+func (a *Accumulator) MembershipWitness(element []byte) (bls12381.G1Affine, error) {
+	x := HashToElement(element)
+	if _, ok := a.elements[x]; !ok {
+		return bls12381.G1Affine{}, ErrNotExists
+	}
+	if a.trapdoor != nil {
+		var s fr.Element
+		s.Add(a.trapdoor, &x).Inverse(&s)
+		var res bls12381.G1Affine
+		scalarMul(&res, &a.value, &s)
+		return res, nil
+	}
+	q, _ := divideByXPlus(a.poly, x)
+	return kzg.Commit(q, a.pk)
+}
+
+// VerifyMembership returns true if the witness proves that the element is in
+// the accumulator of the given value: e(w, [α]G₂)⋅e([x]w - A, G₂) = 1.
+func VerifyMembership(vk kzg.VerifyingKey, value bls12381.G1Affine, element []byte, witness bls12381.G1Affine) bool {
+	x := HashToElement(element)
+
+	// [x]w - A
+	var lhs bls12381.G1Affine
+	scalarMul(&lhs, &witness, &x)
+	lhs.Sub(&lhs, &value)
+
+	ok, err := bls12381.PairingCheckFixedQ([]bls12381.G1Affine{lhs, witness}, vk.Lines[:])
+	return err == nil && ok
+}
+
+// NonMembershipWitness proves that an element y is not in an accumulator A:
+// W = [q(α)]G₁ and R = f(-y) ≠ 0 where f = q⋅(X + y) + R.
+type NonMembershipWitness struct {
+	W bls12381.G1Affine
+	R fr.Element
+}
+
+// NonMembershipWitness returns the non-membership witness of the element.
+func (a *Accumulator) NonMembershipWitness(element []byte) (NonMembershipWitness, error) {
+	y := HashToElement(element)
+	if _, ok := a.elements[y]; ok {
+		return NonMembershipWitness{}, ErrExists
+	}
+	q, r := divideByXPlus(a.poly, y)
+	res := NonMembershipWitness{R: r}
+	if a.trapdoor != nil {
+		// q(α) = (f(α) - r)/(α + y)
+		var s, fs fr.Element
+		for i := len(a.poly) - 1; i >= 0; i-- {
+			fs.Mul(&fs, a.trapdoor).Add(&fs, &a.poly[i])
+		}
+		fs.Sub(&fs, &r)
+		s.Add(a.trapdoor, &y).Inverse(&s)
+		fs.Mul(&fs, &s)
+		scalarMul(&res.W, &a.pk.G1[0], &fs)
+		return res, nil
+	}
+	var err error
+	res.W, err = kzg.Commit(q, a.pk)
+	return res, err
+}
+
+// VerifyNonMembership returns true if the witness proves that the element is
+// not in the accumulator of the given value: R ≠ 0 and
+// e(W, [α]G₂)⋅e([y]W + [R]G₁ - A, G₂) = 1.
+func VerifyNonMembership(vk kzg.VerifyingKey, value bls12381.G1Affine, element []byte, witness NonMembershipWitness) bool {
+	if witness.R.IsZero() {
+		return false
+	}
+	y := HashToElement(element)
+
+	// [y]W + [R]G₁ - A
+	var lhs, rG1 bls12381.G1Affine
+	scalarMul(&lhs, &witness.W, &y)
+	scalarMul(&rG1, &vk.G1, &witness.R)
+	lhs.Add(&lhs, &rG1).Sub(&lhs, &value)
+
+	ok, err := bls12381.PairingCheckFixedQ([]bls12381.G1Affine{lhs, witness.W}, vk.Lines[:])
+	return err == nil && ok
+}
+
+// UpdateMembershipWitness returns the membership witness of the element after
+// the update, without knowledge of the set: for each element y of the update,
+// with A the value before its addition, or A' the value after its deletion,
+//   - after the addition of y, w' = A + [y - x]w
+//   - after the deletion of y, w' = [1/(y - x)](w - A').
+func UpdateMembershipWitness(element []byte, witness bls12381.G1Affine, update *Update) (bls12381.G1Affine, error) {
+	x := HashToElement(element)
+	if err := update.check(&x); err != nil {
+		return bls12381.G1Affine{}, err
+	}
+	w := witness
+	var d fr.Element
+	for i := range update.Added {
+		d.Sub(&update.Added[i], &x)
+		scalarMul(&w, &w, &d)
+		w.Add(&w, &update.Values[i])
+	}
+	for i := range update.Deleted {
+		d.Sub(&update.Deleted[i], &x).Inverse(&d)
+		w.Sub(&w, &update.Values[i+1])
+		scalarMul(&w, &w, &d)
+	}
+	return w, nil
+}
+
+// UpdateNonMembershipWitness returns the non-membership witness of the element
+// after the update, without knowledge of the set: for each element y of the
+// update, of the same form as the membership witnesses with z the scalar of
+// the element,
+//   - after the addition of y, W' = A + [y - z]W and R' = (y - z)R
+//   - after the deletion of y, W' = [1/(y - z)](W - A') and R' = R/(y - z).
+func UpdateNonMembershipWitness(element []byte, witness NonMembershipWitness, update *Update) (NonMembershipWitness, error) {
+	z := HashToElement(element)
+	if err := update.check(&z); err != nil {
+		return NonMembershipWitness{}, err
+	}
+	res := witness
+	var d fr.Element
+	for i := range update.Added {
+		d.Sub(&update.Added[i], &z)
+		scalarMul(&res.W, &res.W, &d)
+		res.W.Add(&res.W, &update.Values[i])
+		res.R.Mul(&res.R, &d)
+	}
+	for i := range update.Deleted {
+		d.Sub(&update.Deleted[i], &z).Inverse(&d)
+		res.W.Sub(&res.W, &update.Values[i+1])
+		scalarMul(&res.W, &res.W, &d)
+		res.R.Mul(&res.R, &d)
+	}
+	return res, nil
+}
+
+// check returns an error if the update is malformed, or if it contains x, in
+// which case the witnesses of x can't be updated.
+func (u *Update) check(x *fr.Element) error {
+	if len(u.Values) != len(u.Added)+len(u.Deleted)+1 || (len(u.Added) > 0 && len(u.Deleted) > 0) {
+		return errors.New("invalid update")
+	}
+	for i := range u.Added {
+		if u.Added[i].Equal(x) {
+			return ErrExists
+		}
+	}
+	for i := range u.Deleted {
+		if u.Deleted[i].Equal(x) {
+			return ErrNotExists
+		}
+	}
+	return nil
+}
+
+// hashElements returns the distinct scalars of the elements, which must all be
+// in the accumulator if members is set, and none otherwise.
+func (a *Accumulator) hashElements(elements [][]byte, members bool) ([]fr.Element, error) {
+	if len(elements) == 0 {
+		return nil, ErrEmpty
+	}
+	xs := make([]fr.Element, 0, len(elements))
+	seen := make(map[fr.Element]struct{}, len(elements))
+	for _, element := range elements {
+		x := HashToElement(element)
+		if _, ok := seen[x]; ok {
+			continue
+		}
+		seen[x] = struct{}{}
+		if _, ok := a.elements[x]; ok != members {
+			if members {
+				return nil, ErrNotExists
+			}
+			return nil, ErrExists
+		}
+		xs = append(xs, x)
+	}
+	return xs, nil
+}
+
+// mulByXPlus returns f⋅(X + x).
+func mulByXPlus(f []fr.Element, x fr.Element) []fr.Element {
+	res := make([]fr.Element, len(f)+1)
+	var t fr.Element
+	for i := range f {
+		t.Mul(&f[i], &x)
+		res[i].Add(&res[i], &t)
+		res[i+1].Set(&f[i])
+	}
+	return res
+}
+
+// divideByXPlus returns the quotient and the remainder of f by X + x.
+func divideByXPlus(f []fr.Element, x fr.Element) (q []fr.Element, r fr.Element) {
+	q = make([]fr.Element, len(f)-1)
+	r = f[len(f)-1]
+	var t fr.Element
+	for i := len(f) - 2; i >= 0; i-- {
+		q[i] = r
+		t.Mul(&r, &x)
+		r.Sub(&f[i], &t)
+	}
+	return q, r
+}
+
+// scalarMul sets res to [s]p.
+func scalarMul(res, p *bls12381.G1Affine, s *fr.Element) {
+	var b big.Int
+	s.BigInt(&b)
+	res.ScalarMultiplication(p, &b)
+}
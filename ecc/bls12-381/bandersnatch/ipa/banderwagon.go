@@ -0,0 +1,101 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ErrInvalidEncoding = errors.New("the encoding is not an element of banderwagon")
+
+// The commitments are elements of banderwagon, the quotient of the subgroup
+// 2E of the points of Bandersnatch which are doubles, of order 2r, by the
+// 2-torsion point (0, -1). The points (x, y) and (-x, -y) are the same
+// element, which is encoded with x⋅sign(y), where the sign of y is positive
+// when y is lexicographically largest.
+
+// DigestBytes returns the encoding of the commitment: x⋅sign(y), in big
+// endian.
+func DigestBytes(p *Digest) [fp.Bytes]byte {
+	x := p.X
+	if !p.Y.LexicographicallyLargest() {
+		x.Neg(&x)
+	}
+	return x.Bytes()
+}
+
+// DigestFromBytes returns the commitment of the given encoding, after
+// checking that it is an element of banderwagon.
+func DigestFromBytes(b []byte) (Digest, error) {
+	if len(b) != fp.Bytes {
+		return Digest{}, ErrInvalidEncoding
+	}
+	var p Digest
+	if err := p.X.SetBytesCanonical(b); err != nil {
+		return Digest{}, ErrInvalidEncoding
+	}
+
+	// the point is a double if 1 - ax² is a square
+	curve := bandersnatch.GetEdwardsCurve()
+	var x2, t fp.Element
+	x2.Square(&p.X)
+	t.Mul(&curve.A, &x2)
+	t.Sub(new(fp.Element).SetOne(), &t)
+	if t.Legendre() != 1 {
+		return Digest{}, ErrInvalidEncoding
+	}
+
+	// ax² + y² = 1 + dx²y² ⇔ y² = (1 - ax²)/(1 - dx²)
+	var den fp.Element
+	den.Mul(&curve.D, &x2)
+	den.Sub(new(fp.Element).SetOne(), &den)
+	if den.IsZero() {
+		return Digest{}, ErrInvalidEncoding
+	}
+	t.Div(&t, &den)
+	if p.Y.Sqrt(&t) == nil {
+		return Digest{}, ErrInvalidEncoding
+	}
+	if !p.Y.LexicographicallyLargest() {
+		p.Y.Neg(&p.Y)
+	}
+	return p, nil
+}
+
+// DigestEqual returns true if p and q are the same element of banderwagon:
+// x_p⋅y_q = x_q⋅y_p.
+func DigestEqual(p, q *Digest) bool {
+	var l, r fp.Element
+	l.Mul(&p.X, &q.Y)
+	r.Mul(&q.X, &p.Y)
+	return l.Equal(&r)
+}
+
+// generators returns the first n elements of banderwagon whose encodings are
+// SHA-256(seed ‖ i) reduced modulo p, for i = 0, 1, … encoded on 8 bytes in big
+// endian.
+func generators(seed []byte, n int) []bandersnatch.PointAffine {
+	res := make([]bandersnatch.PointAffine, 0, n)
+	h := sha256.New()
+	var counter [8]byte
+	for i := uint64(0); len(res) < n; i++ {
+		h.Reset()
+		h.Write(seed)
+		binary.BigEndian.PutUint64(counter[:], i)
+		h.Write(counter[:])
+
+		var x fp.Element
+		x.SetBytes(h.Sum(nil))
+		b := x.Bytes()
+		if p, err := DigestFromBytes(b[:]); err == nil {
+			res = append(res, p)
+		}
+	}
+	return res
+}
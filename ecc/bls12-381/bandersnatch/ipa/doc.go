@@ -0,0 +1,30 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package ipa provides a transparent polynomial commitment scheme on the
+// Bandersnatch curve, based on the inner product argument (IPA) of
+// Bulletproofs, in the Lagrange basis used by Verkle trees.
+//
+// Polynomials are given by their evaluations on the domain {0, 1, …, n-1}
+// (n = 256 for Verkle trees), and a commitment is C = ∑ᵢf(i)Gᵢ where the Gᵢ
+// are obtained by hashing a seed. An opening proof at z proves that
+// ⟨f, (ℓᵢ(z))ᵢ⟩ = f(z), where ℓᵢ are the Lagrange polynomials of the domain,
+// computed with the barycentric formula. Many openings, of different
+// polynomials at different points of the domain, are aggregated into a single
+// proof with the multiproof scheme of Verkle trees.
+//
+// The transcript, the generators derived from VerkleSeed and the encoding of
+// the commitments follow the Verkle tree specification, and the proofs are
+// byte-compatible with those of github.com/crate-crypto/go-ipa.
+//
+// Commitments are elements of banderwagon, the quotient of the points of
+// Bandersnatch which are doubles by the 2-torsion point (0, -1), and the
+// scalars are elements of the bandersnatch/fr field.
+//
+// See https://dankradfeist.de/ethereum/2021/06/18/pcs-multiproofs.html and
+// https://eprint.iacr.org/2017/1066.pdf (Bulletproofs).
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package ipa
@@ -0,0 +1,535 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ipa
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// DomainSize is the size of the domain of the polynomials of Verkle trees.
+const DomainSize = 256
+
+// VerkleSeed is the seed of the generators of Verkle trees.
+const VerkleSeed = "eth_verkle_oct_2021"
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("polynomial size must be the size of the domain")
+	ErrInvalidSRSSize        = errors.New("srs size must be a power of 2")
+	ErrInvalidIndex          = errors.New("point is not in the domain")
+	ErrInvalidProofSize      = errors.New("number of rounds of the proof does not match the SRS")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+)
+
+// Digest commitment of a polynomial.
+type Digest = bandersnatch.PointAffine
+
+// SRS is the transparent setup of the scheme. The points are obtained by
+// hashing a seed, so that their discrete logarithm relations are unknown.
+type SRS struct {
+	G []bandersnatch.PointAffine // Lagrange basis of the commitments
+	Q bandersnatch.PointAffine   // basis of the inner products
+}
+
+// Config holds the SRS and the precomputations on the domain {0, …, n-1}.
+type Config struct {
+	SRS
+
+	// weights[i] = A'(i) = ∏_{j≠i}(i-j) where A = ∏ⱼ(X-j)
+	weights, weightsInv []fr.Element
+
+	// inverses[k+n-1] = 1/k for 0 < |k| < n
+	inverses []fr.Element
+}
+
+// OpeningProof proves that a committed polynomial evaluates to ClaimedValue
+// at a point.
+type OpeningProof struct {
+	// L, R are the cross terms of each folding round
+	L, R []bandersnatch.PointAffine
+
+	// A is the polynomial folded down to a single coefficient
+	A fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof proves the evaluations of several committed polynomials
+// at points of the domain.
+type BatchOpeningProof struct {
+	// D commits to g = ∑ᵢrⁱ(fᵢ-yᵢ)/(X-zᵢ)
+	D Digest
+
+	// Proof opens ∑ᵢrⁱfᵢ/(t-zᵢ) - g at t
+	Proof OpeningProof
+
+	// ClaimedValues purported values yᵢ = fᵢ(zᵢ)
+	ClaimedValues []fr.Element
+}
+
+// NewSRS returns an SRS of the given size, which must be a power of 2. The
+// points G are the first elements of banderwagon whose encodings are hashes of
+// seed and a counter, and Q is the base point of the curve, as in Verkle trees
+// with the seed VerkleSeed and the size DomainSize.
+func NewSRS(size uint64, seed []byte) (*SRS, error) {
+	if size == 0 || bits.OnesCount64(size) != 1 {
+		return nil, ErrInvalidSRSSize
+	}
+	return &SRS{
+		G: generators(seed, int(size)),
+		Q: bandersnatch.GetEdwardsCurve().Base,
+	}, nil
+}
+
+// NewConfig returns a configuration for polynomials in evaluation form on
+// the domain {0, …, n-1}, where n is the size of the SRS.
+func NewConfig(srs *SRS) (*Config, error) {
+	n := len(srs.G)
+	if n == 0 || bits.OnesCount(uint(n)) != 1 {
+		return nil, ErrInvalidSRSSize
+	}
+	c := &Config{SRS: *srs}
+
+	// A'(i) = (-1)ⁿ⁻¹⁻ⁱ⋅i!⋅(n-1-i)!
+	factorials := make([]fr.Element, n)
+	factorials[0].SetOne()
+	for i := 1; i < n; i++ {
+		var t fr.Element
+		t.SetUint64(uint64(i))
+		factorials[i].Mul(&factorials[i-1], &t)
+	}
+	c.weights = make([]fr.Element, n)
+	for i := range c.weights {
+		c.weights[i].Mul(&factorials[i], &factorials[n-1-i])
+		if (n-1-i)%2 == 1 {
+			c.weights[i].Neg(&c.weights[i])
+		}
+	}
+	c.weightsInv = fr.BatchInvert(c.weights)
+
+	c.inverses = make([]fr.Element, 2*n-1)
+	for k := 1; k < n; k++ {
+		c.inverses[n-1+k].SetUint64(uint64(k))
+		c.inverses[n-1-k].Neg(&c.inverses[n-1+k])
+	}
+	c.inverses = fr.BatchInvert(c.inverses)
+
+	return c, nil
+}
+
+// Commit commits to a polynomial given by its evaluations on the domain.
+func (c *Config) Commit(evaluations []fr.Element) (Digest, error) {
+	if len(evaluations) != len(c.G) {
+		return Digest{}, ErrInvalidPolynomialSize
+	}
+	return multiExp(c.G, evaluations), nil
+}
+
+// Evaluate returns f(point) where f is given by its evaluations on the
+// domain.
+func (c *Config) Evaluate(evaluations []fr.Element, point fr.Element) fr.Element {
+	return innerProduct(evaluations, c.lagrangeCoefficients(point))
+}
+
+// Open computes an opening proof of the polynomial given by its evaluations
+// on the domain, at given point, which may or may not be in the domain.
+func (c *Config) Open(evaluations []fr.Element, point fr.Element, fs *Transcript) (OpeningProof, error) {
+	commitment, err := c.Commit(evaluations)
+	if err != nil {
+		return OpeningProof{}, err
+	}
+	return c.open(evaluations, point, &commitment, fs), nil
+}
+
+// Verify verifies an opening proof of the polynomial committed to at point.
+func (c *Config) Verify(commitment *Digest, proof *OpeningProof, point fr.Element, fs *Transcript) error {
+	return c.verify(commitment, proof, point, fs)
+}
+
+// BatchOpenMultiPoints opens the polynomials, given by their evaluations on
+// the domain, at points of the domain, where the i-th polynomial is opened at
+// indices[i], with a single opening proof.
+//
+// With r derived from the digests, points and values, the prover commits to
+// g = ∑ᵢrⁱ(fᵢ-yᵢ)/(X-zᵢ), then with t derived from this commitment, it opens
+// h - g at t where h = ∑ᵢrⁱfᵢ/(t-zᵢ), whose commitment E and value at t are
+// computed by the verifier from the digests and the claimed values.
+func (c *Config) BatchOpenMultiPoints(polynomials [][]fr.Element, digests []Digest, indices []uint64, fs *Transcript) (BatchOpeningProof, error) {
+	var res BatchOpeningProof
+	n := len(c.G)
+
+	if len(polynomials) == 0 {
+		return res, ErrZeroNbDigests
+	}
+	if len(digests) != len(polynomials) || len(indices) != len(polynomials) {
+		return res, ErrInvalidNbDigests
+	}
+	for i := range polynomials {
+		if len(polynomials[i]) != n {
+			return res, ErrInvalidPolynomialSize
+		}
+		if indices[i] >= uint64(n) {
+			return res, ErrInvalidIndex
+		}
+	}
+
+	points := make([]fr.Element, len(indices))
+	res.ClaimedValues = make([]fr.Element, len(indices))
+	for i := range indices {
+		points[i].SetUint64(indices[i])
+		res.ClaimedValues[i] = polynomials[i][indices[i]]
+	}
+
+	r := deriveR(fs, digests, points, res.ClaimedValues)
+
+	// g = ∑ᵢrⁱ(fᵢ-yᵢ)/(X-zᵢ), aggregating first the polynomials opened at
+	// the same point
+	aggregated := make(map[uint64][]fr.Element)
+	var ri, t fr.Element
+	ri.SetOne()
+	for i := range polynomials {
+		a, ok := aggregated[indices[i]]
+		if !ok {
+			a = make([]fr.Element, n)
+			aggregated[indices[i]] = a
+		}
+		for j := range a {
+			t.Mul(&polynomials[i][j], &ri)
+			a[j].Add(&a[j], &t)
+		}
+		ri.Mul(&ri, &r)
+	}
+	g := make([]fr.Element, n)
+	for index, a := range aggregated {
+		q := c.divideOnDomain(a, int(index))
+		for j := range g {
+			g[j].Add(&g[j], &q[j])
+		}
+	}
+	res.D = multiExp(c.G, g)
+
+	fs.appendPoint("D", &res.D)
+	tChallenge := fs.ChallengeScalar("t")
+
+	// h - g where h = ∑ᵢrⁱfᵢ/(t-zᵢ), and its commitment
+	coefficients, err := batchCoefficients(r, tChallenge, points)
+	if err != nil {
+		return res, err
+	}
+	for j := range g {
+		g[j].Neg(&g[j])
+	}
+	for i := range polynomials {
+		for j := range polynomials[i] {
+			t.Mul(&polynomials[i][j], &coefficients[i])
+			g[j].Add(&g[j], &t)
+		}
+	}
+	commitment := multiExp(digests, coefficients)
+	fs.appendPoint("E", &commitment)
+	var minusD bandersnatch.PointAffine
+	minusD.Neg(&res.D)
+	commitment.Add(&commitment, &minusD)
+
+	res.Proof = c.open(g, tChallenge, &commitment, fs)
+	return res, nil
+}
+
+// BatchVerifyMultiPoints verifies a proof of the openings of the committed
+// polynomials, where the i-th digest is opened at indices[i].
+func (c *Config) BatchVerifyMultiPoints(digests []Digest, proof *BatchOpeningProof, indices []uint64, fs *Transcript) error {
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+	if len(indices) != len(digests) || len(proof.ClaimedValues) != len(digests) {
+		return ErrInvalidNbDigests
+	}
+	points := make([]fr.Element, len(indices))
+	for i := range indices {
+		if indices[i] >= uint64(len(c.G)) {
+			return ErrInvalidIndex
+		}
+		points[i].SetUint64(indices[i])
+	}
+
+	r := deriveR(fs, digests, points, proof.ClaimedValues)
+	fs.appendPoint("D", &proof.D)
+	t := fs.ChallengeScalar("t")
+
+	// commitment E - D = ∑ᵢrⁱCᵢ/(t-zᵢ) - D and value ∑ᵢrⁱyᵢ/(t-zᵢ) of h - g
+	coefficients, err := batchCoefficients(r, t, points)
+	if err != nil {
+		return err
+	}
+	commitment := multiExp(digests, coefficients)
+	fs.appendPoint("E", &commitment)
+	var minusD bandersnatch.PointAffine
+	minusD.Neg(&proof.D)
+	commitment.Add(&commitment, &minusD)
+
+	value := innerProduct(coefficients, proof.ClaimedValues)
+	if !value.Equal(&proof.Proof.ClaimedValue) {
+		return ErrVerifyOpeningProof
+	}
+
+	return c.verify(&commitment, &proof.Proof, t, fs)
+}
+
+// MapToScalarField returns the x/y coordinate ratio of a commitment, reduced
+// in the scalar field, used to commit to commitments in Verkle trees.
+func MapToScalarField(p *Digest) fr.Element {
+	var ratio fp.Element
+	ratio.Inverse(&p.Y).Mul(&ratio, &p.X)
+	b := ratio.Bytes()
+	var res fr.Element
+	res.SetBytes(b[:])
+	return res
+}
+
+// open proves that ⟨f, b⟩ = f(point) for b = (ℓᵢ(point))ᵢ and the
+// commitment C = ⟨f, G⟩. With U = [w]Q, each round splits f, b and G in
+// halves and sends L = ⟨a_hi, G_lo⟩ + ⟨a_hi, b_lo⟩U and
+// R = ⟨a_lo, G_hi⟩ + ⟨a_lo, b_hi⟩U, then folds
+//
+//	a ← a_lo + x⋅a_hi,  b ← b_lo + x⁻¹⋅b_hi,  G ← G_lo + x⁻¹⋅G_hi
+//
+// so that C + ⟨a, b⟩U becomes C + ⟨a, b⟩U + xL + x⁻¹R.
+func (c *Config) open(evaluations []fr.Element, point fr.Element, commitment *Digest, fs *Transcript) OpeningProof {
+	n := len(c.G)
+	b := c.lagrangeCoefficients(point)
+	res := OpeningProof{
+		ClaimedValue: innerProduct(evaluations, b),
+	}
+
+	u := deriveU(fs, commitment, point, res.ClaimedValue, &c.Q)
+
+	a := make([]fr.Element, n)
+	copy(a, evaluations)
+	g := make([]bandersnatch.PointAffine, n)
+	copy(g, c.G)
+
+	nbRounds := bits.TrailingZeros(uint(n))
+	res.L = make([]bandersnatch.PointAffine, nbRounds)
+	res.R = make([]bandersnatch.PointAffine, nbRounds)
+	for j := 0; j < nbRounds; j++ {
+		m := len(a) / 2
+		aLo, aHi := a[:m], a[m:]
+		bLo, bHi := b[:m], b[m:]
+		gLo, gHi := g[:m], g[m:]
+
+		// L = ⟨a_hi, G_lo⟩ + ⟨a_hi, b_lo⟩U and R = ⟨a_lo, G_hi⟩ + ⟨a_lo, b_hi⟩U
+		points := make([]bandersnatch.PointAffine, m+1)
+		scalars := make([]fr.Element, m+1)
+		copy(points, gLo)
+		points[m] = u
+		copy(scalars, aHi)
+		scalars[m] = innerProduct(aHi, bLo)
+		res.L[j] = multiExp(points, scalars)
+		copy(points, gHi)
+		copy(scalars, aLo)
+		scalars[m] = innerProduct(aLo, bHi)
+		res.R[j] = multiExp(points, scalars)
+
+		fs.appendPoint("L", &res.L[j])
+		fs.appendPoint("R", &res.R[j])
+		x := fs.ChallengeScalar("x")
+		var xInv fr.Element
+		xInv.Inverse(&x)
+
+		var t fr.Element
+		for i := 0; i < m; i++ {
+			t.Mul(&aHi[i], &x)
+			aLo[i].Add(&aLo[i], &t)
+			t.Mul(&bHi[i], &xInv)
+			bLo[i].Add(&bLo[i], &t)
+		}
+		foldPoints(gLo, gHi, &xInv)
+
+		a, b, g = aLo, bLo, gLo
+	}
+	res.A = a[0]
+
+	return res
+}
+
+// verify checks an opening proof with a single MSM, unrolling the folding
+// rounds: with sᵢ = ∏ⱼxⱼ^{-bⱼ(i)} where bⱼ(i) is the j-th most significant bit
+// of i, the folded G and b are ⟨s, G⟩ and ⟨s, b⟩, and
+//
+//	C + vU + ∑ⱼ(xⱼLⱼ + xⱼ⁻¹Rⱼ) = A⋅⟨s, G⟩ + A⋅⟨s, b⟩U
+func (c *Config) verify(commitment *Digest, proof *OpeningProof, point fr.Element, fs *Transcript) error {
+	n := len(c.G)
+	nbRounds := bits.TrailingZeros(uint(n))
+	if len(proof.L) != nbRounds || len(proof.R) != nbRounds {
+		return ErrInvalidProofSize
+	}
+
+	u := deriveU(fs, commitment, point, proof.ClaimedValue, &c.Q)
+
+	x := make([]fr.Element, nbRounds)
+	for j := 0; j < nbRounds; j++ {
+		fs.appendPoint("L", &proof.L[j])
+		fs.appendPoint("R", &proof.R[j])
+		x[j] = fs.ChallengeScalar("x")
+	}
+	xInv := fr.BatchInvert(x)
+
+	s := make([]fr.Element, 1, n)
+	s[0].SetOne()
+	for j := 0; j < nbRounds; j++ {
+		s = s[:2*len(s)]
+		for i := len(s)/2 - 1; i >= 0; i-- {
+			s[2*i+1].Mul(&s[i], &xInv[j])
+			s[2*i] = s[i]
+		}
+	}
+	foldedB := innerProduct(s, c.lagrangeCoefficients(point))
+
+	// ⟨-A⋅s, G⟩ + ∑ⱼ(xⱼLⱼ + xⱼ⁻¹Rⱼ) + (v - A⋅⟨s, b⟩)U + C = 0
+	points := make([]bandersnatch.PointAffine, 0, n+2*nbRounds+2)
+	scalars := make([]fr.Element, 0, n+2*nbRounds+2)
+	points = append(points, c.G...)
+	var minusA fr.Element
+	minusA.Neg(&proof.A)
+	for i := range s {
+		s[i].Mul(&s[i], &minusA)
+	}
+	scalars = append(scalars, s...)
+	points = append(points, proof.L...)
+	scalars = append(scalars, x...)
+	points = append(points, proof.R...)
+	scalars = append(scalars, xInv...)
+	var v fr.Element
+	v.Mul(&minusA, &foldedB).Add(&v, &proof.ClaimedValue)
+	points = append(points, u, *commitment)
+	scalars = append(scalars, v, fr.One())
+
+	// the identity of banderwagon is (0, ±1)
+	check := multiExp(points, scalars)
+	if !check.X.IsZero() {
+		return ErrVerifyOpeningProof
+	}
+
+	return nil
+}
+
+// lagrangeCoefficients returns (ℓᵢ(z))ᵢ, with the barycentric formula
+// ℓᵢ(z) = A(z)/(A'(i)(z-i)) when z is not in the domain.
+func (c *Config) lagrangeCoefficients(z fr.Element) []fr.Element {
+	n := len(c.G)
+	res := make([]fr.Element, n)
+	if z.IsUint64() && z.Uint64() < uint64(n) {
+		res[z.Uint64()].SetOne()
+		return res
+	}
+
+	var az fr.Element
+	az.SetOne()
+	for i := range res {
+		res[i].SetUint64(uint64(i))
+		res[i].Sub(&z, &res[i])
+		az.Mul(&az, &res[i])
+	}
+	res = fr.BatchInvert(res)
+	for i := range res {
+		res[i].Mul(&res[i], &c.weightsInv[i]).Mul(&res[i], &az)
+	}
+	return res
+}
+
+// divideOnDomain returns q = (f - f(m))/(X - m) in evaluation form, for m in
+// the domain. At m, q(m) = f'(m) = -∑_{j≠m} q(j)⋅A'(m)/A'(j).
+func (c *Config) divideOnDomain(f []fr.Element, m int) []fr.Element {
+	n := len(f)
+	q := make([]fr.Element, n)
+	var t fr.Element
+	for j := range f {
+		if j == m {
+			continue
+		}
+		q[j].Sub(&f[j], &f[m]).Mul(&q[j], &c.inverses[n-1+j-m])
+		t.Mul(&q[j], &c.weightsInv[j])
+		q[m].Sub(&q[m], &t)
+	}
+	q[m].Mul(&q[m], &c.weights[m])
+	return q
+}
+
+// deriveU returns U = [w]Q where w is bound to the statement.
+func deriveU(fs *Transcript, commitment *Digest, point, value fr.Element, q *bandersnatch.PointAffine) bandersnatch.PointAffine {
+	fs.domainSep("ipa")
+	fs.appendPoint("C", commitment)
+	fs.appendScalar("input point", &point)
+	fs.appendScalar("output point", &value)
+	w := fs.ChallengeScalar("w")
+
+	var bw big.Int
+	w.BigInt(&bw)
+	var u bandersnatch.PointAffine
+	u.ScalarMultiplication(q, &bw)
+	return u
+}
+
+// deriveR returns the challenge r of the multiproof, bound to the digests,
+// points and values of the openings.
+func deriveR(fs *Transcript, digests []Digest, points, values []fr.Element) fr.Element {
+	fs.domainSep("multiproof")
+	for i := range digests {
+		fs.appendPoint("C", &digests[i])
+		fs.appendScalar("z", &points[i])
+		fs.appendScalar("y", &values[i])
+	}
+	return fs.ChallengeScalar("r")
+}
+
+// batchCoefficients returns rⁱ/(t-zᵢ).
+func batchCoefficients(r, t fr.Element, points []fr.Element) ([]fr.Element, error) {
+	res := make([]fr.Element, len(points))
+	for i := range points {
+		res[i].Sub(&t, &points[i])
+		if res[i].IsZero() {
+			return nil, ErrVerifyOpeningProof
+		}
+	}
+	res = fr.BatchInvert(res)
+	var ri fr.Element
+	ri.SetOne()
+	for i := range res {
+		res[i].Mul(&res[i], &ri)
+		ri.Mul(&ri, &r)
+	}
+	return res, nil
+}
+
+// foldPoints sets lo[i] to lo[i] + [x]hi[i], up to the 2-torsion point
+// (0, -1) of the GLV scalar multiplication, which banderwagon ignores.
+func foldPoints(lo, hi []bandersnatch.PointAffine, x *fr.Element) {
+	var bx big.Int
+	x.BigInt(&bx)
+	parallel.Execute(len(lo), func(start, end int) {
+		var t bandersnatch.PointAffine
+		for i := start; i < end; i++ {
+			t.ScalarMultiplication(&hi[i], &bx)
+			lo[i].Add(&lo[i], &t)
+		}
+	})
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, t fr.Element
+	for i := range a {
+		t.Mul(&a[i], &b[i])
+		res.Add(&res, &t)
+	}
+	return res
+}
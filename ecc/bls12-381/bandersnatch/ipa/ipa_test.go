@@ -0,0 +1,445 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	fp "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// Test configuration re-used across tests of the IPA scheme
+var testConfig *Config
+
+func init() {
+	const srsSize = 64
+	srs, err := NewSRS(srsSize, []byte("test"))
+	if err != nil {
+		panic(err)
+	}
+	testConfig, err = NewConfig(srs)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestNewSRS(t *testing.T) {
+	assert := require.New(t)
+
+	_, err := NewSRS(6, []byte("test"))
+	assert.ErrorIs(err, ErrInvalidSRSSize)
+
+	srs, err := NewSRS(8, []byte("test"))
+	assert.NoError(err)
+	assert.Equal(testConfig.G[:8], srs.G)
+
+	curve := bandersnatch.GetEdwardsCurve()
+	for _, p := range append(srs.G, srs.Q) {
+		assert.True(p.IsOnCurve())
+		assert.False(p.X.IsZero())
+		var q bandersnatch.PointAffine
+		q.ScalarMultiplication(&p, &curve.Order)
+		assert.True(q.X.IsZero(), "point not in banderwagon")
+
+		b := DigestBytes(&p)
+		d, err := DigestFromBytes(b[:])
+		assert.NoError(err)
+		assert.True(DigestEqual(&p, &d))
+	}
+}
+
+func TestDigestBytes(t *testing.T) {
+	assert := require.New(t)
+
+	// encodings of the doublings of the generator, from go-ipa
+	expected := []string{
+		"4a2c7486fd924882bf02c6908de395122843e3e05264d7991e18e7985dad51e9",
+		"43aa74ef706605705989e8fd38df46873b7eae5921fbed115ac9d937399ce4d5",
+		"5e5f550494159f38aa54d2ed7f11a7e93e4968617990445cc93ac8e59808c126",
+		"0e7e3748db7c5c999a7bcd93d71d671f1f40090423792266f94cb27ca43fce5c",
+		"14ddaa48820cb6523b9ae5fe9fe257cbbd1f3d598a28e670a40da5d1159d864a",
+		"6989d1c82b2d05c74b62fb0fbdf8843adae62ff720d370e209a7b84e14548a7d",
+		"26b8df6fa414bf348a3dc780ea53b70303ce49f3369212dec6fbe4b349b832bf",
+		"37e46072db18f038f2cc7d3d5b5d1374c0eb86ca46f869d6a95fc2fb092c0d35",
+		"2c1ce64f26e1c772282a6633fac7ca73067ae820637ce348bb2c8477d228dc7d",
+		"297ab0f5a8336a7a4e2657ad7a33a66e360fb6e50812d4be3326fab73d6cee07",
+		"5b285811efa7a965bd6ef5632151ebf399115fcc8f5b9b8083415ce533cc39ce",
+		"1f939fa2fd457b3effb82b25d3fe8ab965f54015f108f8c09d67e696294ab626",
+		"3088dcb4d3f4bacd706487648b239e0be3072ed2059d981fe04ce6525af6f1b8",
+		"35fbc386a16d0227ff8673bc3760ad6b11009f749bb82d4facaea67f58fc60ed",
+		"00f29b4f3255e318438f0a31e058e4c081085426adb0479f14c64985d0b956e0",
+		"3fa4384b2fa0ecc3c0582223602921daaa893a97b64bdf94dcaa504e8b7b9e5f",
+	}
+	p := bandersnatch.GetEdwardsCurve().Base
+	for i := range expected {
+		b := DigestBytes(&p)
+		assert.Equal(expected[i], hex.EncodeToString(b[:]), "2^%d", i)
+
+		d, err := DigestFromBytes(b[:])
+		assert.NoError(err)
+		assert.True(DigestEqual(&p, &d))
+		var minusP Digest
+		minusP.X.Neg(&p.X)
+		minusP.Y.Neg(&p.Y)
+		assert.True(DigestEqual(&minusP, &d))
+		assert.Equal(b, DigestBytes(&minusP))
+
+		p.Double(&p)
+	}
+
+	// not a double, not canonical, wrong size
+	var x fp.Element
+	for x.SetOne(); ; x.Double(&x) {
+		b := x.Bytes()
+		if _, err := DigestFromBytes(b[:]); err != nil {
+			assert.ErrorIs(err, ErrInvalidEncoding)
+			break
+		}
+	}
+	modulus := fp.Modulus().Bytes()
+	_, err := DigestFromBytes(modulus)
+	assert.ErrorIs(err, ErrInvalidEncoding)
+	_, err = DigestFromBytes(make([]byte, 31))
+	assert.ErrorIs(err, ErrInvalidEncoding)
+}
+
+func TestVerkleSRS(t *testing.T) {
+	assert := require.New(t)
+
+	srs, err := NewSRS(DomainSize, []byte(VerkleSeed))
+	assert.NoError(err)
+
+	// CRS of go-ipa
+	h := sha256.New()
+	for i := range srs.G {
+		b := DigestBytes(&srs.G[i])
+		h.Write(b[:])
+	}
+	first := DigestBytes(&srs.G[0])
+	last := DigestBytes(&srs.G[DomainSize-1])
+	assert.Equal("01587ad1336675eb912550ec2a28eb8923b824b490dd2ba82e48f14590a298a0", hex.EncodeToString(first[:]))
+	assert.Equal("3de2be346b539395b0c0de56a5ccca54a317f1b5c80107b0802af9a62276a4d8", hex.EncodeToString(last[:]))
+	assert.Equal("1fcaea10bf24f750200e06fa473c76ff0468007291fa548e2d99f09ba9256fdb", hex.EncodeToString(h.Sum(nil)))
+}
+
+func TestVerkleOpeningProof(t *testing.T) {
+	assert := require.New(t)
+
+	config := verkleConfig(t)
+	f := verklePolynomial(false)
+	digest, err := config.Commit(f)
+	assert.NoError(err)
+	b := DigestBytes(&digest)
+	assert.Equal("1b9dff8f5ebbac250d291dfe90e36283a227c64b113c37f1bfb9e7a743cdb128", hex.EncodeToString(b[:]))
+
+	var point fr.Element
+	point.SetUint64(2101)
+	fs := NewTranscript("test")
+	proof, err := config.Open(f, point, fs)
+	assert.NoError(err)
+	var value [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&value, proof.ClaimedValue)
+	assert.Equal("4a353e70b03c89f161de002e8713beec0d740a5e20722fd5bd68b30540a33208", hex.EncodeToString(value[:]))
+	state := fs.ChallengeScalar("state")
+	fr.LittleEndian.PutElement(&value, state)
+	assert.Equal("0a81881cbfd7d7197a54ebd67ed6a68b5867f3c783706675b34ece43e85e7306", hex.EncodeToString(value[:]))
+
+	// serialized proof of go-ipa
+	assert.Equal("273395a8febdaed38e94c3d874e99c911a47dd84616d54c55021d5c4131b507e46a4ec2c7e82b77ec2f533994c91ca7edaef212c666a1169b29c323eabb0cf690e0146638d0e2d543f81da4bd597bf3013e1663f340a8f87b845495598d0a3951590b6417f868edaeb3424ff174901d1185a53a3ee127fb7be0af42dda44bf992885bde279ef821a298087717ef3f2b78b2ede7f5d2ea1b60a4195de86a530eb247fd7e456012ae9a070c61635e55d1b7a340dfab8dae991d6273d099d9552815434cc1ba7bcdae341cf7928c6f25102370bdf4b26aad3af654d9dff4b3735661db3177342de5aad774a59d3e1b12754aee641d5f9cd1ecd2751471b308d2d8410add1c9fcc5a2b7371259f0538270832a98d18151f653efbc60895fab8be9650510449081626b5cd24671d1a3253487d44f589c2ff0da3557e307e520cf4e0054bbf8bdffaa24b7e4cce5092ccae5a08281ee24758374f4e65f126cacce64051905b5e2038060ad399c69ca6cb1d596d7c9cb5e161c7dcddc1a7ad62660dd4a5f69b31229b80e6b3df520714e4ea2b5896ebd48d14c7455e91c1ecf4acc5ffb36937c49413b7d1005dd6efbd526f5af5d61131ca3fcdae1218ce81c75e62b39100ec7f474b48a2bee6cef453fa1bc3db95c7c6575bc2d5927cbf7413181ac905766a4038a7b422a8ef2bf7b5059b5c546c19a33c1049482b9a9093f864913ca82290decf6e9a65bf3f66bc3ba4a8ed17b56d890a83bcbe74435a42499dec115", hex.EncodeToString(proofBytes(&proof)))
+
+	assert.NoError(config.Verify(&digest, &proof, point, NewTranscript("test")))
+}
+
+func TestVerkleMultiProof(t *testing.T) {
+	assert := require.New(t)
+
+	config := verkleConfig(t)
+	polynomials := [][]fr.Element{verklePolynomial(false), verklePolynomial(true)}
+	digests := make([]Digest, len(polynomials))
+	for i := range polynomials {
+		var err error
+		digests[i], err = config.Commit(polynomials[i])
+		assert.NoError(err)
+	}
+	indices := []uint64{0, 0}
+
+	fs := NewTranscript("test")
+	proof, err := config.BatchOpenMultiPoints(polynomials, digests, indices, fs)
+	assert.NoError(err)
+	assert.Equal([]fr.Element{fr.NewElement(1), fr.NewElement(32)}, proof.ClaimedValues)
+	state := fs.ChallengeScalar("state")
+	var b [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&b, state)
+	assert.Equal("eee8a80357ff74b766eba39db90797d022e8d6dee426ded71234241be504d519", hex.EncodeToString(b[:]))
+
+	// serialized proof of go-ipa
+	d := DigestBytes(&proof.D)
+	assert.Equal("4f53588244efaf07a370ee3f9c467f933eed360d4fbf7a19dfc8bc49b67df4711bf1d0a720717cd6a8c75f1a668cb7cbdd63b48c676b89a7aee4298e71bd7f4013d7657146aa9736817da47051ed6a45fc7b5a61d00eb23e5df82a7f285cc10e67d444e91618465ca68d8ae4f2c916d1942201b7e2aae491ef0f809867d00e83468fb7f9af9b42ede76c1e90d89dd789ff22eb09e8b1d062d8a58b6f88b3cbe80136fc68331178cd45a1df9496ded092d976911b5244b85bc3de41e844ec194256b39aeee4ea55538a36139211e9910ad6b7a74e75d45b869d0a67aa4bf600930a5f760dfb8e4df9938d1f47b743d71c78ba8585e3b80aba26d24b1f50b36fa1458e79d54c05f58049245392bc3e2b5c5f9a1b99d43ed112ca82b201fb143d401741713188e47f1d6682b0bf496a5d4182836121efff0fd3b030fc6bfb5e21d6314a200963fe75cb856d444a813426b2084dfdc49dca2e649cb9da8bcb47859a4c629e97898e3547c591e39764110a224150d579c33fb74fa5eb96427036899c04154feab5344873d36a53a5baefd78c132be419f3f3a8dd8f60f72eb78dd5f43c53226f5ceb68947da3e19a750d760fb31fa8d4c7f53bfef11c4b89158aa56b1f4395430e16a3128f88e234ce1df7ef865f2d2c4975e8c82225f578310c31fd41d265fd530cbfa2b8895b228a510b806c31dff3b1fa5c08bffad443d567ed0e628febdd22775776e0cc9cebcaea9c6df9279a5d91dd0ee5e7a0434e989a160005321c97026cb559f71db23360105460d959bcdf74bee22c4ad8805a1d497507", hex.EncodeToString(append(d[:], proofBytes(&proof.Proof)...)))
+
+	assert.NoError(config.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")))
+}
+
+func TestMultiExp(t *testing.T) {
+	assert := require.New(t)
+
+	for _, size := range []int{1, 7, 64} {
+		scalars := randomPolynomial(size)
+		var expected, t bandersnatch.PointAffine
+		expected.X.SetZero()
+		expected.Y.SetOne()
+		for i := range scalars {
+			var s big.Int
+			scalars[i].BigInt(&s)
+			t.ScalarMultiplication(&testConfig.G[i], &s)
+			expected.Add(&expected, &t)
+		}
+		// the points are of order 2r, and the scalar multiplication with
+		// the GLV method is only correct up to the 2-torsion point (0, -1)
+		res := multiExp(testConfig.G[:size], scalars)
+		assert.True(DigestEqual(&expected, &res))
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	assert := require.New(t)
+
+	// evaluations on the domain of a random polynomial of degree < n
+	coefficients := randomPolynomial(len(testConfig.G))
+	horner := func(x fr.Element) fr.Element {
+		var res fr.Element
+		for i := len(coefficients) - 1; i >= 0; i-- {
+			res.Mul(&res, &x).Add(&res, &coefficients[i])
+		}
+		return res
+	}
+	evaluations := make([]fr.Element, len(testConfig.G))
+	for i := range evaluations {
+		var x fr.Element
+		x.SetUint64(uint64(i))
+		evaluations[i] = horner(x)
+	}
+
+	var point fr.Element
+	point.SetRandom()
+	assert.Equal(horner(point), testConfig.Evaluate(evaluations, point))
+	point.SetUint64(5)
+	assert.Equal(evaluations[5], testConfig.Evaluate(evaluations, point))
+
+	// quotient on the domain, q(X)(X-m) = f(X) - f(m)
+	for _, m := range []int{0, 17, 63} {
+		q := testConfig.divideOnDomain(evaluations, m)
+		var xm fr.Element
+		xm.SetUint64(uint64(m))
+		point.SetRandom()
+		lhs := testConfig.Evaluate(q, point)
+		var t fr.Element
+		t.Sub(&point, &xm)
+		lhs.Mul(&lhs, &t)
+		rhs := horner(point)
+		rhs.Sub(&rhs, &evaluations[m])
+		assert.Equal(rhs, lhs, "m=%d", m)
+	}
+}
+
+func TestVerifySinglePoint(t *testing.T) {
+	assert := require.New(t)
+
+	f := randomPolynomial(len(testConfig.G))
+	digest, err := testConfig.Commit(f)
+	assert.NoError(err)
+
+	var inDomain, outOfDomain fr.Element
+	inDomain.SetUint64(42)
+	outOfDomain.SetRandom()
+	for _, point := range []fr.Element{inDomain, outOfDomain} {
+		proof, err := testConfig.Open(f, point, NewTranscript("test"))
+		assert.NoError(err)
+		assert.Equal(testConfig.Evaluate(f, point), proof.ClaimedValue)
+		assert.Len(proof.L, 6)
+
+		assert.NoError(testConfig.Verify(&digest, &proof, point, NewTranscript("test")))
+
+		// wrong point
+		var wrongPoint fr.Element
+		wrongPoint.Double(&point)
+		assert.ErrorIs(testConfig.Verify(&digest, &proof, wrongPoint, NewTranscript("test")), ErrVerifyOpeningProof)
+
+		// wrong value
+		wrong := proof
+		wrong.ClaimedValue.Double(&proof.ClaimedValue)
+		assert.ErrorIs(testConfig.Verify(&digest, &wrong, point, NewTranscript("test")), ErrVerifyOpeningProof)
+
+		// wrong folded coefficient
+		wrong = proof
+		wrong.A.Double(&proof.A)
+		assert.ErrorIs(testConfig.Verify(&digest, &wrong, point, NewTranscript("test")), ErrVerifyOpeningProof)
+
+		// wrong round
+		wrong = proof
+		wrong.L = append(wrong.L[:0:0], proof.L...)
+		wrong.L[2], wrong.L[3] = wrong.L[3], wrong.L[2]
+		assert.ErrorIs(testConfig.Verify(&digest, &wrong, point, NewTranscript("test")), ErrVerifyOpeningProof)
+		wrong.L = wrong.L[1:]
+		assert.ErrorIs(testConfig.Verify(&digest, &wrong, point, NewTranscript("test")), ErrInvalidProofSize)
+	}
+
+	_, err = testConfig.Open(randomPolynomial(60), fr.One(), NewTranscript("test"))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestBatchVerifyMultiPoints(t *testing.T) {
+	assert := require.New(t)
+
+	const nbPolynomials = 5
+	polynomials := make([][]fr.Element, nbPolynomials)
+	digests := make([]Digest, nbPolynomials)
+	indices := []uint64{0, 13, 63, 13, 7}
+	for i := range polynomials {
+		polynomials[i] = randomPolynomial(len(testConfig.G))
+		var err error
+		digests[i], err = testConfig.Commit(polynomials[i])
+		assert.NoError(err)
+	}
+
+	proof, err := testConfig.BatchOpenMultiPoints(polynomials, digests, indices, NewTranscript("test"))
+	assert.NoError(err)
+	for i := range polynomials {
+		assert.Equal(polynomials[i][indices[i]], proof.ClaimedValues[i])
+	}
+	assert.NoError(testConfig.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")))
+
+	// wrong claimed value
+	proof.ClaimedValues[2].Double(&proof.ClaimedValues[2])
+	assert.ErrorIs(testConfig.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")), ErrVerifyOpeningProof)
+	proof.ClaimedValues[2] = polynomials[2][indices[2]]
+
+	// wrong digest
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.ErrorIs(testConfig.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")), ErrVerifyOpeningProof)
+	digests[0], digests[1] = digests[1], digests[0]
+
+	// wrong point
+	indices[4]++
+	assert.ErrorIs(testConfig.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")), ErrVerifyOpeningProof)
+	indices[4] = 64
+	assert.ErrorIs(testConfig.BatchVerifyMultiPoints(digests, &proof, indices, NewTranscript("test")), ErrInvalidIndex)
+	indices[4] = 7
+
+	assert.ErrorIs(testConfig.BatchVerifyMultiPoints(digests[1:], &proof, indices, NewTranscript("test")), ErrInvalidNbDigests)
+}
+
+func TestMapToScalarField(t *testing.T) {
+	assert := require.New(t)
+
+	// x/y computed in the base field, then reduced modulo the scalar field
+	p := testConfig.G[0]
+	var ratio, yInv big.Int
+	p.X.BigInt(&ratio)
+	p.Y.BigInt(&yInv)
+	yInv.ModInverse(&yInv, fp.Modulus())
+	ratio.Mul(&ratio, &yInv).Mod(&ratio, fp.Modulus())
+	var expected fr.Element
+	expected.SetBigInt(&ratio)
+
+	assert.Equal(expected, MapToScalarField(&p))
+}
+
+func BenchmarkCommit(b *testing.B) {
+	srs, err := NewSRS(DomainSize, []byte("bench"))
+	require.NoError(b, err)
+	config, err := NewConfig(srs)
+	require.NoError(b, err)
+	p := randomPolynomial(DomainSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = config.Commit(p)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	srs, err := NewSRS(DomainSize, []byte("bench"))
+	require.NoError(b, err)
+	config, err := NewConfig(srs)
+	require.NoError(b, err)
+	p := randomPolynomial(DomainSize)
+	var point fr.Element
+	point.SetRandom()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = config.Open(p, point, NewTranscript("test"))
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	srs, err := NewSRS(DomainSize, []byte("bench"))
+	require.NoError(b, err)
+	config, err := NewConfig(srs)
+	require.NoError(b, err)
+	p := randomPolynomial(DomainSize)
+	var point fr.Element
+	point.SetRandom()
+	digest, err := config.Commit(p)
+	require.NoError(b, err)
+	proof, err := config.Open(p, point, NewTranscript("test"))
+	require.NoError(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = config.Verify(&digest, &proof, point, NewTranscript("test"))
+	}
+}
+
+// verkleConfig returns the configuration of Verkle trees.
+func verkleConfig(t *testing.T) *Config {
+	srs, err := NewSRS(DomainSize, []byte(VerkleSeed))
+	require.NoError(t, err)
+	config, err := NewConfig(srs)
+	require.NoError(t, err)
+	return config
+}
+
+// verklePolynomial returns the test polynomial of go-ipa, whose evaluations
+// are 1, …, 32 repeated 8 times, or 32, …, 1 if reversed.
+func verklePolynomial(reversed bool) []fr.Element {
+	f := make([]fr.Element, DomainSize)
+	for i := range f {
+		if reversed {
+			f[i].SetUint64(uint64(32 - i%32))
+		} else {
+			f[i].SetUint64(uint64(i%32 + 1))
+		}
+	}
+	return f
+}
+
+// proofBytes returns the serialization of go-ipa: L, R and A in little
+// endian.
+func proofBytes(proof *OpeningProof) []byte {
+	var res []byte
+	for _, points := range [][]bandersnatch.PointAffine{proof.L, proof.R} {
+		for i := range points {
+			b := DigestBytes(&points[i])
+			res = append(res, b[:]...)
+		}
+	}
+	var a [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&a, proof.A)
+	return append(res, a[:]...)
+}
+
+func randomPolynomial(size int) []fr.Element {
+	f := make([]fr.Element, size)
+	for i := 0; i < size; i++ {
+		f[i].SetRandom()
+	}
+	return f
+}
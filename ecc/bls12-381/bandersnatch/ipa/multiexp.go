@@ -0,0 +1,93 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ipa
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// multiExp returns ∑ᵢscalars[i]⋅points[i] with the bucket method.
+func multiExp(points []bandersnatch.PointAffine, scalars []fr.Element) bandersnatch.PointAffine {
+	n := len(points)
+	if len(scalars) < n {
+		n = len(scalars)
+	}
+
+	// window size
+	c := 4
+	if l := bits.Len(uint(n)); l > 6 {
+		c = l - 2
+	}
+	if c > 16 {
+		c = 16
+	}
+	nbWindows := (fr.Bits + c - 1) / c
+
+	digits := make([][4]uint64, n)
+	parallel.Execute(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			digits[i] = scalars[i].Bits()
+		}
+	})
+
+	windows := make([]bandersnatch.PointExtended, nbWindows)
+	parallel.Execute(nbWindows, func(start, end int) {
+		buckets := make([]bandersnatch.PointExtended, 1<<c)
+		for w := start; w < end; w++ {
+			for i := range buckets {
+				setInfinity(&buckets[i])
+			}
+			for i := 0; i < n; i++ {
+				d := digit(&digits[i], w*c, c)
+				if d != 0 {
+					buckets[d].MixedAdd(&buckets[d], &points[i])
+				}
+			}
+			// ∑ⱼj⋅bucketⱼ with running sums
+			var sum bandersnatch.PointExtended
+			setInfinity(&sum)
+			setInfinity(&windows[w])
+			for j := len(buckets) - 1; j > 0; j-- {
+				sum.Add(&sum, &buckets[j])
+				windows[w].Add(&windows[w], &sum)
+			}
+		}
+	})
+
+	var res bandersnatch.PointExtended
+	setInfinity(&res)
+	for w := nbWindows - 1; w >= 0; w-- {
+		for i := 0; i < c; i++ {
+			res.Double(&res)
+		}
+		res.Add(&res, &windows[w])
+	}
+
+	var p bandersnatch.PointAffine
+	p.FromExtended(&res)
+	return p
+}
+
+// digit returns the c bits of s starting at bit offset.
+func digit(s *[4]uint64, offset, c int) uint64 {
+	word, shift := offset/64, offset%64
+	if word >= len(s) {
+		return 0
+	}
+	d := s[word] >> shift
+	if shift+c > 64 && word+1 < len(s) {
+		d |= s[word+1] << (64 - shift)
+	}
+	return d & (1<<c - 1)
+}
+
+func setInfinity(p *bandersnatch.PointExtended) {
+	var infinity bandersnatch.PointAffine
+	infinity.Y.SetOne()
+	p.FromAffine(&infinity)
+}
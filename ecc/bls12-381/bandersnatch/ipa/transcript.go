@@ -0,0 +1,67 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package ipa
+
+import (
+	"crypto/sha256"
+	"hash"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/bandersnatch/fr"
+)
+
+// Transcript is the Fiat-Shamir transcript of the Verkle trees specification.
+//
+// The messages are appended to a buffer, each preceded by its label. A
+// challenge is the SHA-256 hash of the state and of the buffer, read as a
+// little-endian integer reduced modulo the order of the group. The state is
+// then reset, and the challenge is appended to the buffer, so that it
+// summarizes the previous messages.
+type Transcript struct {
+	state hash.Hash
+	buf   []byte
+}
+
+// NewTranscript returns a transcript whose state is initialized with label.
+func NewTranscript(label string) *Transcript {
+	t := &Transcript{state: sha256.New()}
+	t.state.Write([]byte(label))
+	return t
+}
+
+// ChallengeScalar returns a challenge bound to the messages appended since
+// the previous challenge.
+func (t *Transcript) ChallengeScalar(label string) fr.Element {
+	t.domainSep(label)
+	t.state.Write(t.buf)
+	t.buf = t.buf[:0]
+	h := t.state.Sum(nil)
+	t.state.Reset()
+
+	slices.Reverse(h)
+	var res fr.Element
+	res.SetBytes(h)
+	t.appendScalar(label, &res)
+	return res
+}
+
+// domainSep appends label.
+func (t *Transcript) domainSep(label string) {
+	t.buf = append(t.buf, label...)
+}
+
+// appendScalar appends label and the little-endian encoding of s.
+func (t *Transcript) appendScalar(label string, s *fr.Element) {
+	var b [fr.Bytes]byte
+	fr.LittleEndian.PutElement(&b, *s)
+	t.buf = append(t.buf, label...)
+	t.buf = append(t.buf, b[:]...)
+}
+
+// appendPoint appends label and the encoding of p.
+func (t *Transcript) appendPoint(label string, p *Digest) {
+	b := DigestBytes(p)
+	t.buf = append(t.buf, label...)
+	t.buf = append(t.buf, b[:]...)
+}
@@ -0,0 +1,52 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+// Ciphersuite identifies one of the BLS signature schemes of the IETF draft,
+// which differ in the way they protect against rogue-key attacks.
+type Ciphersuite uint8
+
+const (
+	// Basic is the basic scheme: aggregate verification requires all the
+	// messages to be distinct.
+	Basic Ciphersuite = iota
+	// MessageAugmentation is the message augmentation scheme: the public key
+	// is prepended to the message before signing.
+	MessageAugmentation
+	// ProofOfPossession is the proof-of-possession scheme: signers must
+	// prove the possession of their secret key before their public key is
+	// aggregated. It is the only scheme supporting fast aggregate
+	// verification.
+	ProofOfPossession
+)
+
+// String returns the scheme tag of the ciphersuite, as used in the
+// domain separation tags: NUL, AUG or POP.
+func (cs Ciphersuite) String() string {
+	switch cs {
+	case Basic:
+		return "NUL"
+	case MessageAugmentation:
+		return "AUG"
+	case ProofOfPossession:
+		return "POP"
+	default:
+		return "unknown ciphersuite"
+	}
+}
+
+// SignatureDST returns the domain separation tag of the ciphersuite for
+// signatures hashed to curve with the hash-to-curve suite suiteID, e.g.
+// BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_.
+func (cs Ciphersuite) SignatureDST(suiteID string) []byte {
+	return []byte("BLS_SIG_" + suiteID + cs.String() + "_")
+}
+
+// PossessionDST returns the domain separation tag used to hash public keys
+// for proofs of possession with the hash-to-curve suite suiteID.
+func PossessionDST(suiteID string) []byte {
+	return []byte("BLS_POP_" + suiteID + ProofOfPossession.String() + "_")
+}
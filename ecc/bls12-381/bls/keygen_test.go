@@ -0,0 +1,100 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package bls
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestKeyGen(t *testing.T) {
+	t.Parallel()
+
+	ikm := bytes.Repeat([]byte{0x2a}, 32)
+
+	sk1, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.IsZero() {
+		t.Fatal("secret key should not be zero")
+	}
+
+	// deterministic
+	sk2, err := KeyGen(ikm, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sk1.Equal(&sk2) {
+		t.Fatal("KeyGen should be deterministic")
+	}
+
+	// the key information is bound to the secret key
+	sk3, err := KeyGen(ikm, []byte("key info"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sk1.Equal(&sk3) {
+		t.Fatal("KeyGen should depend on the key information")
+	}
+
+	if _, err := KeyGen(ikm[:31], nil); err != ErrShortIKM {
+		t.Fatal("KeyGen should reject short input keying material")
+	}
+}
+
+func TestKeyGenVectors(t *testing.T) {
+	t.Parallel()
+
+	// KeyGen of the IETF draft 04 with an empty key_info is derive_master_SK of
+	// EIP-2333, whose test cases are the known answers (IKM → SK).
+	vectors := []struct {
+		ikm, sk string
+	}{
+		{
+			ikm: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			sk:  "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		},
+		{
+			ikm: "3141592653589793238462643383279502884197169399375105820974944592",
+			sk:  "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		},
+		{
+			ikm: "0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+			sk:  "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		},
+		{
+			ikm: "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			sk:  "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		},
+	}
+	for _, v := range vectors {
+		ikm, err := hex.DecodeString(v.ikm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sk, err := KeyGen(ikm, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sk.String() != v.sk {
+			t.Fatalf("KeyGen(%s) = %s, expected %s", v.ikm, sk.String(), v.sk)
+		}
+	}
+}
+
+func TestCiphersuiteDST(t *testing.T) {
+	t.Parallel()
+
+	const suiteID = "BLS12381G2_XMD:SHA-256_SSWU_RO_"
+	if got := string(ProofOfPossession.SignatureDST(suiteID)); got != "BLS_SIG_"+suiteID+"POP_" {
+		t.Fatal("unexpected signature DST", got)
+	}
+	if got := string(PossessionDST(suiteID)); got != "BLS_POP_"+suiteID+"POP_" {
+		t.Fatal("unexpected proof of possession DST", got)
+	}
+}
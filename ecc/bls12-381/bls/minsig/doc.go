@@ -0,0 +1,21 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package minsig implements the minimal-signature-size variant of the BLS
+// signature scheme on the bls12-381 curve: public keys are points of G2 and
+// signatures are points of G1.
+//
+// The three ciphersuites of the IETF draft are supported (see
+// [bls.Ciphersuite]). [PrivateKey.Sign] and [PublicKey.Verify], which
+// implement the [signature.Signer] and [signature.PublicKey] interfaces, use
+// the proof-of-possession ciphersuite.
+//
+// Documentation:
+//   - IETF draft: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package minsig
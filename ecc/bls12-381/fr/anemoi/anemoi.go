@@ -0,0 +1,219 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ErrInvalidSizebuffer = errors.New("the size of the input should match the size of the hash buffer")
+
+// reference implementation: https://github.com/anemoi-hash/anemoi-hash/blob/main/anemoi.sage
+// original paper: https://eprint.iacr.org/2022/840.pdf
+
+const (
+	// NbColumns is the number ℓ of columns of the state
+	NbColumns = 1
+
+	// Width is the number of elements 2ℓ of the state (x, y), where x and y
+	// have ℓ elements
+	Width = 2 * NbColumns
+
+	// NbRounds is the number of rounds of the permutation
+	NbRounds = 21
+
+	// Alpha is the degree α of the open Flystel, the smallest α such that
+	// gcd(α, p-1) = 1
+	Alpha = 5
+
+	// generator g of 𝔽ₚ*
+	generator = 7
+
+	// the first and next 100 decimal digits of π, the seeds of the round
+	// constants, reduced mod p
+	pi0 = "1415926535897932384626433832795028841971693993751058209749445923078164062862089986280348253421170679"
+	pi1 = "8214808651328230664709384460955058223172535940812848111745028410270193852110555964462294895493038196"
+)
+
+// parameters of the permutation, computed on first use
+var (
+	initOnce sync.Once
+
+	// alphaInv = α⁻¹ mod p-1
+	alphaInv big.Int
+
+	// g and δ = g⁻¹, the constants of the quadratic functions of the Flystel
+	g, delta fr.Element
+
+	// mds is the matrix of the linear layer of the columns
+	mds [NbColumns][NbColumns]fr.Element
+
+	// c[r] and d[r] are the round constants of x and y of the r-th round
+	c, d [NbRounds][NbColumns]fr.Element
+)
+
+func initParameters() {
+	alphaInv.SetString("20974350070050476191779096203274386335076221000211055129041463479975432473805", 10)
+	g.SetUint64(generator)
+	delta.Inverse(&g)
+	for i, row := range [NbColumns][NbColumns]string{
+		{"1"},
+	} {
+		for j := range row {
+			if _, err := mds[i][j].SetString(row[j]); err != nil {
+				panic(err)
+			}
+		}
+	}
+	initRC()
+}
+
+// initRC computes the round constants as in the reference implementation:
+//
+//	c[r][i] = g⋅π₀²ʳ + (π₀ʳ + π₁ⁱ)ᵅ
+//	d[r][i] = g⋅π₁²ⁱ + (π₀ʳ + π₁ⁱ)ᵅ + δ
+func initRC() {
+	var p0, p1, p0r, p1i, t, s fr.Element
+	if _, err := p0.SetString(pi0); err != nil {
+		panic(err)
+	}
+	if _, err := p1.SetString(pi1); err != nil {
+		panic(err)
+	}
+	p0r.SetOne()
+	for r := 0; r < NbRounds; r++ {
+		p1i.SetOne()
+		for i := 0; i < NbColumns; i++ {
+			s.Add(&p0r, &p1i)
+			powAlpha(&s)
+
+			t.Square(&p0r).Mul(&t, &g)
+			c[r][i].Add(&t, &s)
+
+			t.Square(&p1i).Mul(&t, &g)
+			d[r][i].Add(&t, &s).Add(&d[r][i], &delta)
+
+			p1i.Mul(&p1i, &p1)
+		}
+		p0r.Mul(&p0r, &p0)
+	}
+}
+
+// Hash provides the Anemoi permutation of width Width on buffers.
+type Hash struct{}
+
+// NewHash returns the Anemoi permutation.
+func NewHash() Hash {
+	initOnce.Do(initParameters)
+	return Hash{}
+}
+
+// powAlpha sets x to xᵅ
+func powAlpha(x *fr.Element) {
+	var tmp fr.Element
+	tmp.Set(x)
+	for i := bits.Len(Alpha) - 2; i >= 0; i-- {
+		x.Square(x)
+		if (Alpha>>i)&1 == 1 {
+			x.Mul(x, &tmp)
+		}
+	}
+}
+
+// sBox applies the open Flystel on (x, y):
+//
+//	x ← x - g⋅y²
+//	y ← y - x^(1/α)
+//	x ← x + g⋅y² + δ
+func sBox(x, y *fr.Element) {
+	var t fr.Element
+	t.Square(y).Mul(&t, &g)
+	x.Sub(x, &t)
+	t.Exp(*x, &alphaInv)
+	y.Sub(y, &t)
+	t.Square(y).Mul(&t, &g)
+	x.Add(x, &t).Add(x, &delta)
+}
+
+// matMulInPlace multiplies the column s by the MDS matrix, after rotating it
+// by shift elements to the left
+func matMulInPlace(s []fr.Element, shift int) {
+	var res [NbColumns]fr.Element
+	var tmp fr.Element
+	for i := range res {
+		for j := range res {
+			tmp.Mul(&mds[i][j], &s[(j+shift)%NbColumns])
+			res[i].Add(&res[i], &tmp)
+		}
+	}
+	copy(s, res[:])
+}
+
+// linearLayer applies x ← M⋅x, y ← M⋅ρ(y), where ρ rotates y by one element to
+// the left, and the Pseudo-Hadamard transform y ← y + x, x ← x + y.
+func linearLayer(x, y []fr.Element) {
+	matMulInPlace(x, 0)
+	matMulInPlace(y, 1)
+	for i := range x {
+		y[i].Add(&y[i], &x[i])
+		x[i].Add(&x[i], &y[i])
+	}
+}
+
+// Permutation applies the permutation on input = (x, y), and stores the result
+// in input.
+//
+// Each round adds the round constants, applies the linear layer and the open
+// Flystel on each (xᵢ, yᵢ), and the linear layer is applied once more at the
+// end.
+func (h *Hash) Permutation(input []fr.Element) error {
+	if len(input) != Width {
+		return ErrInvalidSizebuffer
+	}
+	x, y := input[:NbColumns], input[NbColumns:]
+	for r := 0; r < NbRounds; r++ {
+		for i := range x {
+			x[i].Add(&x[i], &c[r][i])
+			y[i].Add(&y[i], &d[r][i])
+		}
+		linearLayer(x, y)
+		for i := range x {
+			sBox(&x[i], &y[i])
+		}
+	}
+	linearLayer(x, y)
+	return nil
+}
+
+// Compress is the Jive compression mode of the permutation, which compresses
+// two digests of NbColumns elements into one:
+//
+//	Compress(x, y) = x + y + u + v
+//
+// where (u, v) is the image of (x, y) by the permutation.
+func (h *Hash) Compress(left, right []fr.Element) ([]fr.Element, error) {
+	if len(left) != NbColumns || len(right) != NbColumns {
+		return nil, ErrInvalidSizebuffer
+	}
+	var state [Width]fr.Element
+	copy(state[:], left)
+	copy(state[NbColumns:], right)
+	if err := h.Permutation(state[:]); err != nil {
+		return nil, err
+	}
+	res := make([]fr.Element, NbColumns)
+	for i := range res {
+		res[i].Add(&left[i], &right[i]).
+			Add(&res[i], &state[i]).
+			Add(&res[i], &state[NbColumns+i])
+	}
+	return res, nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package anemoi
+
+import (
+	"bytes"
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func randomState(t int) []fr.Element {
+	res := make([]fr.Element, t)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+// sBoxInv is the inverse of the open Flystel
+func sBoxInv(x, y *fr.Element) {
+	var t fr.Element
+	t.Square(y).Mul(&t, &g)
+	x.Sub(x, &t).Sub(x, &delta)
+	t.Exp(*x, &alphaInv)
+	y.Add(y, &t)
+	t.Square(y).Mul(&t, &g)
+	x.Add(x, &t)
+}
+
+func TestSBox(t *testing.T) {
+	assert := require.New(t)
+	NewHash()
+	in := randomState(2)
+	x, y := in[0], in[1]
+	sBox(&x, &y)
+	assert.False(x.Equal(&in[0]) && y.Equal(&in[1]))
+	sBoxInv(&x, &y)
+	assert.True(x.Equal(&in[0]) && y.Equal(&in[1]))
+}
+
+// TestLinearLayer checks the linear layer against the explicit matrices.
+func TestLinearLayer(t *testing.T) {
+	assert := require.New(t)
+	NewHash()
+	state := randomState(Width)
+	x, y := state[:NbColumns], state[NbColumns:]
+
+	var u, v [NbColumns]fr.Element
+	var tmp fr.Element
+	for i := 0; i < NbColumns; i++ {
+		for j := 0; j < NbColumns; j++ {
+			tmp.Mul(&mds[i][j], &x[j])
+			u[i].Add(&u[i], &tmp)
+			tmp.Mul(&mds[i][j], &y[(j+1)%NbColumns])
+			v[i].Add(&v[i], &tmp)
+		}
+		v[i].Add(&v[i], &u[i])
+		u[i].Add(&u[i], &v[i])
+	}
+	linearLayer(x, y)
+	assert.Equal(u[:], x)
+	assert.Equal(v[:], y)
+}
+
+func TestPermutation(t *testing.T) {
+	assert := require.New(t)
+	h := NewHash()
+
+	input := randomState(Width)
+	state := append([]fr.Element(nil), input...)
+	assert.NoError(h.Permutation(state))
+	assert.NotEqual(input, state)
+	assert.ErrorIs(h.Permutation(input[1:]), ErrInvalidSizebuffer)
+}
+
+func TestCompress(t *testing.T) {
+	assert := require.New(t)
+	h := NewHash()
+
+	left, right := randomState(NbColumns), randomState(NbColumns)
+	res, err := h.Compress(left, right)
+	assert.NoError(err)
+
+	state := append(append([]fr.Element(nil), left...), right...)
+	assert.NoError(h.Permutation(state))
+	for i := range res {
+		var expected fr.Element
+		expected.Add(&left[i], &right[i]).Add(&expected, &state[i]).Add(&expected, &state[NbColumns+i])
+		assert.True(expected.Equal(&res[i]))
+	}
+
+	_, err = h.Compress(left[1:], right)
+	assert.ErrorIs(err, ErrInvalidSizebuffer)
+}
+
+func TestSponge(t *testing.T) {
+	assert := require.New(t)
+
+	assert.True(hash.ANEMOI_BLS12_381.Available())
+	h := hash.ANEMOI_BLS12_381.New()
+	assert.Equal(hash.ANEMOI_BLS12_381.Size(), h.Size())
+
+	var digests [][]byte
+	for _, n := range []int{0, 1, Rate + 1, 3 * Rate} {
+		elems := randomState(n)
+		var buf []byte
+		for i := range elems {
+			b := elems[i].Bytes()
+			buf = append(buf, b[:]...)
+		}
+
+		h.Reset()
+		_, err := h.Write(buf)
+		assert.NoError(err)
+		digest := h.Sum(nil)
+		assert.Equal(h.Size(), len(digest))
+		assert.Equal(digest, h.Sum(nil), "Sum should not change the state")
+
+		// writes can be split
+		h.Reset()
+		for i := 0; i < len(buf); i += fr.Bytes {
+			_, err = h.Write(buf[i : i+fr.Bytes])
+			assert.NoError(err)
+		}
+		assert.Equal(digest, h.Sum(nil))
+
+		// the state can be stored and restored
+		s := NewSponge()
+		assert.NoError(s.SetState(h.(hash.StateStorer).State()))
+		assert.Equal(digest, s.Sum(nil))
+
+		for _, d := range digests {
+			assert.False(bytes.Equal(d, digest))
+		}
+		digests = append(digests, digest)
+	}
+
+	_, err := h.Write(make([]byte, fr.Bytes+1))
+	assert.Error(err)
+}
+
+func BenchmarkAnemoi(b *testing.B) {
+	h := NewHash()
+	input := randomState(Width)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = h.Permutation(input)
+	}
+}
+
+func BenchmarkCompress(b *testing.B) {
+	h := NewHash()
+	left, right := randomState(NbColumns), randomState(NbColumns)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = h.Compress(left, right)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package anemoi implements the Anemoi permutation over the scalar field of bls12-381, its
+// Jive compression mode, and a sponge hash function built on it.
+//
+// The permutation acts on 1 column of 2 elements, with 21 rounds and the open
+// Flystel of degree α = 5, for 128 bits of security. The round constants are
+// derived from the digits of π and the generator g = 7 of 𝔽ₚ* as in the
+// reference implementation.
+//
+// [Hash.Compress] is the Jive compression of two digests of 1 element, for
+// Merkle trees. The sponge hash function ([NewSponge]) is registered as
+// hash.ANEMOI_BLS12_381, and has a rate of 1 element and digests of 1 element.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package anemoi
@@ -0,0 +1,142 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package fri
+
+import (
+	"errors"
+	"math/bits"
+)
+
+var ErrInvalidParameters = errors.New("invalid FRI parameters")
+
+// Parameters are the parameters of the FRI protocol returned by [New].
+type Parameters struct {
+
+	// FoldingFactor is the arity k of the folding: each round maps the
+	// evaluations of a polynomial on a fiber of x ↦ xᵏ to one evaluation of
+	// the folded polynomial, of degree k times smaller. It is 2, 4, 8 or 16.
+	FoldingFactor int
+
+	// Blowup is the ratio of the size of the evaluation domain to the degree
+	// bound, a power of 2. The rate of the Reed-Solomon code is 1/Blowup.
+	Blowup int
+
+	// NbQueries is the number of queries of the verifier.
+	NbQueries int
+
+	// GrindingBits is the number of trailing zero bits of the digest of the
+	// proof of work the prover computes before the queries are derived.
+	GrindingBits int
+
+	// CapHeight is the height of the Merkle caps: the commitments of the
+	// layers are the 2^CapHeight nodes at this height, instead of the root,
+	// which saves CapHeight nodes per opening.
+	CapHeight int
+}
+
+// Option sets the parameters of the FRI protocol. The prover and the verifier
+// must use the same options.
+type Option func(*friConfig)
+
+type friConfig struct {
+	Parameters
+	securityLevel int
+	seed          []byte
+}
+
+// WithFoldingFactor sets the arity of the folding, 2, 4, 8 or 16. The default
+// is 2.
+func WithFoldingFactor(k int) Option {
+	return func(cfg *friConfig) {
+		cfg.FoldingFactor = k
+	}
+}
+
+// WithBlowup sets the blowup factor, a power of 2. The default is GetRho().
+func WithBlowup(blowup int) Option {
+	return func(cfg *friConfig) {
+		cfg.Blowup = blowup
+	}
+}
+
+// WithNbQueries sets the number of queries, overriding the number of queries
+// derived from the security level.
+func WithNbQueries(nbQueries int) Option {
+	return func(cfg *friConfig) {
+		cfg.NbQueries = nbQueries
+	}
+}
+
+// WithSecurityLevel sets the number of queries from the target security level
+// in bits, under the conjecture that each query adds log₂(Blowup) bits of
+// security, on top of the bits of the proof of work:
+//
+//	NbQueries = ⌈(securityLevel - GrindingBits) / log₂(Blowup)⌉
+//
+// The default is 128 bits. The soundness of the protocol is also bounded by
+// the size of the field, from which the challenges are drawn.
+func WithSecurityLevel(securityLevel int) Option {
+	return func(cfg *friConfig) {
+		cfg.securityLevel = securityLevel
+	}
+}
+
+// WithGrinding requires a proof of work of grindingBits bits from the prover
+// before the queries are derived.
+func WithGrinding(grindingBits int) Option {
+	return func(cfg *friConfig) {
+		cfg.GrindingBits = grindingBits
+	}
+}
+
+// WithMerkleCap commits to the layers with the 2^capHeight nodes of the Merkle
+// trees at height capHeight, instead of their roots.
+func WithMerkleCap(capHeight int) Option {
+	return func(cfg *friConfig) {
+		cfg.CapHeight = capHeight
+	}
+}
+
+// WithTranscriptSeed binds seed to the Fiat-Shamir transcript of the protocol,
+// before the first challenge. A protocol which opens its own commitments at the
+// positions queried by FRI must seed it with its transcript, so that the
+// queries are derived after its commitments.
+func WithTranscriptSeed(seed []byte) Option {
+	return func(cfg *friConfig) {
+		cfg.seed = seed
+	}
+}
+
+// friOptions returns the configuration set by opts.
+func friOptions(opts ...Option) (friConfig, error) {
+	cfg := friConfig{
+		Parameters: Parameters{
+			FoldingFactor: 2,
+			Blowup:        rho,
+		},
+		securityLevel: 128,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	switch cfg.FoldingFactor {
+	case 2, 4, 8, 16:
+	default:
+		return friConfig{}, ErrInvalidParameters
+	}
+	if cfg.Blowup < 2 || bits.OnesCount(uint(cfg.Blowup)) != 1 {
+		return friConfig{}, ErrInvalidParameters
+	}
+	if cfg.GrindingBits < 0 || cfg.GrindingBits > 32 || cfg.CapHeight < 0 || cfg.NbQueries < 0 {
+		return friConfig{}, ErrInvalidParameters
+	}
+	if cfg.NbQueries == 0 {
+		logBlowup := bits.TrailingZeros(uint(cfg.Blowup))
+		cfg.NbQueries = max(1, (cfg.securityLevel-cfg.GrindingBits+logBlowup-1)/logBlowup)
+	}
+	return cfg, nil
+}
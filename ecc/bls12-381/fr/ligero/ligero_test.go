@@ -0,0 +1,226 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ligero
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/mlpcs"
+	"github.com/stretchr/testify/require"
+)
+
+func randomVector(size int) []fr.Element {
+	res := make([]fr.Element, size)
+	for i := range res {
+		res[i].SetRandom()
+	}
+	return res
+}
+
+// evaluate returns the evaluation at point of the multilinear polynomial p,
+// folding its first variable first.
+func evaluate(p, point []fr.Element) fr.Element {
+	p = append([]fr.Element(nil), p...)
+	for _, r := range point {
+		mid := len(p) / 2
+		for i := 0; i < mid; i++ {
+			var tmp fr.Element
+			tmp.Sub(&p[i+mid], &p[i]).Mul(&tmp, &r)
+			p[i].Add(&p[i], &tmp)
+		}
+		p = p[:mid]
+	}
+	return p[0]
+}
+
+func TestOptions(t *testing.T) {
+	assert := require.New(t)
+
+	s, err := New(16, sha256.New())
+	assert.NoError(err)
+	params := s.Parameters()
+	assert.Equal(4, params.Blowup)
+	assert.Equal(189, params.NbQueries) // ⌈128 / -log₂(5/8)⌉
+	assert.Equal((128+fr.Bits-21)/(fr.Bits-20), params.NbProximityTests)
+
+	s, err = New(16, sha256.New(), WithBlowup(8), WithNbQueries(10), WithNbProximityTests(3))
+	assert.NoError(err)
+	assert.Equal(Parameters{NbColumns: 16, Blowup: 8, NbQueries: 10, NbProximityTests: 3}, s.Parameters())
+
+	for _, opts := range [][]Option{
+		{WithBlowup(3)},
+		{WithBlowup(1)},
+		{WithNbQueries(-1)},
+		{WithSecurityLevel(0)},
+	} {
+		_, err = New(16, sha256.New(), opts...)
+		assert.ErrorIs(err, ErrInvalidParameters)
+	}
+	_, err = New(12, sha256.New())
+	assert.ErrorIs(err, ErrInvalidParameters)
+}
+
+func TestOpen(t *testing.T) {
+	for _, nbVars := range []int{4, 7} {
+		t.Run(fmt.Sprintf("nbVars=%d", nbVars), func(t *testing.T) {
+			assert := require.New(t)
+			s, err := New(16, sha256.New(), WithNbQueries(30))
+			assert.NoError(err)
+
+			p := randomVector(1 << nbVars)
+			point := randomVector(nbVars)
+			digest, err := s.Commit(p)
+			assert.NoError(err)
+
+			proof, err := s.Open(p, point)
+			assert.NoError(err)
+			assert.Equal(evaluate(p, point), proof.ClaimedValue)
+			assert.NoError(s.Verify(&digest, &proof, point))
+
+			// wrong value
+			proof.ClaimedValue.Add(&proof.ClaimedValue, &point[0])
+			assert.Error(s.Verify(&digest, &proof, point))
+			proof.ClaimedValue.Sub(&proof.ClaimedValue, &point[0])
+
+			// wrong point
+			point[0].Add(&point[0], &point[1])
+			assert.Error(s.Verify(&digest, &proof, point))
+			point[0].Sub(&point[0], &point[1])
+			assert.Error(s.Verify(&digest, &proof, point[1:]))
+
+			// wrong column
+			proof.Columns[0][0].Add(&proof.Columns[0][0], &point[0])
+			assert.ErrorIs(s.Verify(&digest, &proof, point), ErrMerkleProof)
+			proof.Columns[0][0].Sub(&proof.Columns[0][0], &point[0])
+
+			// wrong digest
+			other, err := s.Commit(randomVector(1 << nbVars))
+			assert.NoError(err)
+			assert.Error(s.Verify(&other, &proof, point))
+
+			assert.NoError(s.Verify(&digest, &proof, point))
+		})
+	}
+}
+
+func TestInvalidSize(t *testing.T) {
+	assert := require.New(t)
+	s, err := New(16, sha256.New())
+	assert.NoError(err)
+
+	_, err = s.Commit(randomVector(8))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = s.Commit(randomVector(48))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+	_, err = s.Open(randomVector(32), randomVector(4))
+	assert.ErrorIs(err, ErrInvalidPolynomialSize)
+}
+
+func TestBatchOpen(t *testing.T) {
+	assert := require.New(t)
+	const nbVars = 6
+	s, err := New(8, sha256.New(), WithBlowup(2), WithNbQueries(20))
+	assert.NoError(err)
+
+	polynomials := make([][]fr.Element, 3)
+	digests := make([]Digest, len(polynomials))
+	for i := range polynomials {
+		polynomials[i] = randomVector(1 << nbVars)
+		digests[i], err = s.Commit(polynomials[i])
+		assert.NoError(err)
+	}
+	point := randomVector(nbVars)
+
+	_, err = s.BatchOpen(nil, nil, point)
+	assert.ErrorIs(err, ErrZeroNbDigests)
+	_, err = s.BatchOpen(polynomials, digests[:2], point)
+	assert.ErrorIs(err, ErrInvalidNbDigests)
+
+	proof, err := s.BatchOpen(polynomials, digests, point, []byte("data"))
+	assert.NoError(err)
+	for i := range polynomials {
+		assert.Equal(evaluate(polynomials[i], point), proof.ClaimedValues[i])
+	}
+	assert.NoError(s.BatchVerify(digests, &proof, point, []byte("data")))
+
+	// wrong transcript
+	assert.Error(s.BatchVerify(digests, &proof, point, []byte("atad")))
+
+	// wrong order of the digests
+	digests[0], digests[1] = digests[1], digests[0]
+	assert.Error(s.BatchVerify(digests, &proof, point, []byte("data")))
+	digests[0], digests[1] = digests[1], digests[0]
+
+	// wrong proximity row
+	proof.ProximityRows[0][1].Add(&proof.ProximityRows[0][1], &point[0])
+	assert.Error(s.BatchVerify(digests, &proof, point, []byte("data")))
+	proof.ProximityRows[0][1].Sub(&proof.ProximityRows[0][1], &point[0])
+
+	// wrong row
+	proof.Rows[2][0].Add(&proof.Rows[2][0], &point[0])
+	assert.Error(s.BatchVerify(digests, &proof, point, []byte("data")))
+	proof.Rows[2][0].Sub(&proof.Rows[2][0], &point[0])
+
+	assert.NoError(s.BatchVerify(digests, &proof, point, []byte("data")))
+}
+
+func TestScheme(t *testing.T) {
+	assert := require.New(t)
+	s, err := New(4, sha256.New(), WithNbQueries(10))
+	assert.NoError(err)
+
+	var scheme mlpcs.Scheme[fr.Element, Digest, OpeningProof, BatchOpeningProof] = s
+	p := randomVector(32)
+	point := randomVector(5)
+	digest, err := scheme.Commit(p)
+	assert.NoError(err)
+	proof, err := scheme.Open(p, point)
+	assert.NoError(err)
+	assert.NoError(scheme.Verify(&digest, &proof, point))
+}
+
+func BenchmarkCommit(b *testing.B) {
+	s, err := New(1<<10, sha256.New())
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomVector(1 << 20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.Commit(p)
+	}
+}
+
+func BenchmarkOpen(b *testing.B) {
+	s, err := New(1<<10, sha256.New())
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomVector(1 << 20)
+	point := randomVector(20)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = s.Open(p, point)
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	s, err := New(1<<10, sha256.New())
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := randomVector(1 << 20)
+	point := randomVector(20)
+	digest, _ := s.Commit(p)
+	proof, _ := s.Open(p, point)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.Verify(&digest, &proof, point)
+	}
+}
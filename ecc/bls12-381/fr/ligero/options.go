@@ -0,0 +1,119 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package ligero
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+)
+
+var ErrInvalidParameters = errors.New("invalid ligero parameters")
+
+// Parameters are the parameters of the scheme returned by [New].
+type Parameters struct {
+
+	// NbColumns is the number of columns of the matrices of the polynomials,
+	// a power of 2.
+	NbColumns int
+
+	// Blowup is the inverse of the rate of the Reed-Solomon code of the rows,
+	// a power of 2.
+	Blowup int
+
+	// NbQueries is the number of columns opened to the verifier.
+	NbQueries int
+
+	// NbProximityTests is the number of random combinations of the rows sent
+	// to the verifier.
+	NbProximityTests int
+}
+
+// Option sets the parameters of the scheme. The prover and the verifier must
+// use the same options.
+type Option func(*ligeroConfig)
+
+type ligeroConfig struct {
+	Parameters
+	securityLevel int
+}
+
+// WithBlowup sets the blowup factor, a power of 2. The default is 4.
+func WithBlowup(blowup int) Option {
+	return func(cfg *ligeroConfig) {
+		cfg.Blowup = blowup
+	}
+}
+
+// WithNbQueries sets the number of queries, overriding the number of queries
+// derived from the security level.
+func WithNbQueries(nbQueries int) Option {
+	return func(cfg *ligeroConfig) {
+		cfg.NbQueries = nbQueries
+	}
+}
+
+// WithNbProximityTests sets the number of proximity tests, overriding the
+// number of tests derived from the security level.
+func WithNbProximityTests(nbTests int) Option {
+	return func(cfg *ligeroConfig) {
+		cfg.NbProximityTests = nbTests
+	}
+}
+
+// WithSecurityLevel sets the number of queries and of proximity tests from
+// the target security level λ in bits. Each query of a column adds
+// -log₂(1-δ) bits of security, where δ = (1-1/Blowup)/2 is the unique
+// decoding radius of the code:
+//
+//	NbQueries = ⌈λ / -log₂(1-δ)⌉
+//
+// and each proximity test adds log₂|𝔽| - 20 bits, for matrices of up to 2²⁰
+// rows:
+//
+//	NbProximityTests = ⌈λ / (log₂|𝔽| - 20)⌉
+//
+// The default is 128 bits.
+func WithSecurityLevel(securityLevel int) Option {
+	return func(cfg *ligeroConfig) {
+		cfg.securityLevel = securityLevel
+	}
+}
+
+// ligeroOptions returns the parameters set by opts.
+func ligeroOptions(nbColumns int, opts ...Option) (Parameters, error) {
+	cfg := ligeroConfig{
+		Parameters: Parameters{
+			NbColumns: nbColumns,
+			Blowup:    4,
+		},
+		securityLevel: 128,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.NbColumns < 1 || bits.OnesCount(uint(cfg.NbColumns)) != 1 {
+		return Parameters{}, ErrInvalidParameters
+	}
+	if cfg.Blowup < 2 || bits.OnesCount(uint(cfg.Blowup)) != 1 {
+		return Parameters{}, ErrInvalidParameters
+	}
+	if cfg.NbQueries < 0 || cfg.NbProximityTests < 0 || cfg.securityLevel < 1 {
+		return Parameters{}, ErrInvalidParameters
+	}
+	if cfg.NbQueries == 0 {
+		delta := (1 - 1/float64(cfg.Blowup)) / 2
+		cfg.NbQueries = int(math.Ceil(float64(cfg.securityLevel) / -math.Log2(1-delta)))
+	}
+	if cfg.NbProximityTests == 0 {
+		bitsPerTest := max(1, fr.Bits-20)
+		cfg.NbProximityTests = (cfg.securityLevel + bitsPerTest - 1) / bitsPerTest
+	}
+	return cfg.Parameters, nil
+}
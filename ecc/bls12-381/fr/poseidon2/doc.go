@@ -0,0 +1,26 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package poseidon2 implements the Poseidon2 permutation over fr of bls12-381, and
+// hash functions built on it.
+//
+// [NewDefaultHash] returns the permutations of widths 2 and 3 derived as in the reference
+// implementation (https://github.com/HorizenLabs/poseidon2), with the s-box
+// x ↦ x^5 and the numbers of rounds for 128 bits of security:
+// 8 full rounds and 56 partial rounds for the width 2,
+// 8 full rounds and 56 partial rounds for the width 3.
+// The permutations of width 2 and 3 are instances of the reference
+// implementation, and are checked against its known answers.
+//
+// The hash functions over the default permutations are
+//   - the Merkle-Damgård construction over the compression function of width 2
+//     ([NewMerkleDamgardHasher]), registered as hash.POSEIDON2_BLS12_381, and a
+//     drop-in replacement of MiMC;
+//   - the sponge construction ([NewSponge]), of configurable rate and capacity.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package poseidon2
@@ -0,0 +1,161 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package rescue
+
+import (
+	"errors"
+	"math/big"
+	"math/bits"
+	"sync"
+
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"golang.org/x/crypto/sha3"
+)
+
+var ErrInvalidSizebuffer = errors.New("the size of the input should match the size of the hash buffer")
+
+// reference implementation: https://github.com/ASDiscreteMathematics/rpo
+// original paper: https://eprint.iacr.org/2022/1577.pdf
+
+const (
+	// Width is the number of elements of the state
+	Width = 3
+
+	// NbRounds is the number of rounds of the permutation
+	NbRounds = 13
+
+	// Alpha is the degree α of the s-box x ↦ xᵅ, the smallest α such that
+	// gcd(α, p-1) = 1
+	Alpha = 5
+
+	// seed of the SHAKE256 stream of the round constants
+	seed = "RPO(52435875175126190479447740508185965837690552500527637822603658699938581184513,3,1,128)"
+)
+
+// parameters of the permutation, computed on first use
+var (
+	initOnce sync.Once
+
+	// alphaInv = α⁻¹ mod p-1, the exponent of the inverse s-box
+	alphaInv big.Int
+
+	// mds is the matrix of the linear layer
+	mds [Width][Width]fr.Element
+
+	// roundKeys[2r] and roundKeys[2r+1] are the round constants of the two
+	// halves of the r-th round
+	roundKeys [2 * NbRounds][Width]fr.Element
+)
+
+func initParameters() {
+	alphaInv.SetString("20974350070050476191779096203274386335076221000211055129041463479975432473805", 10)
+	for i, row := range [Width][Width]string{
+		{"343", "52435875175126190479447740508185965837690552500527637822603658699938581184114", "57"},
+		{"19551", "52435875175126190479447740508185965837690552500527637822603658699938581162113", "2850"},
+		{"977550", "52435875175126190479447740508185965837690552500527637822603658699938580066914", "140050"},
+	} {
+		for j := range row {
+			if _, err := mds[i][j].SetString(row[j]); err != nil {
+				panic(err)
+			}
+		}
+	}
+	initRC()
+}
+
+// initRC derives the round constants as in the reference implementation: the
+// SHAKE256 stream of the seed is split in chunks of ⌈log₂(p)/8⌉+1 bytes, read as
+// little endian integers reduced mod p.
+func initRC() {
+	const bytesPerElement = (fr.Bits+7)/8 + 1
+	stream := make([]byte, bytesPerElement*2*NbRounds*Width)
+	sha3.ShakeSum256(stream, []byte(seed))
+
+	var chunk [bytesPerElement]byte
+	for i := range roundKeys {
+		for j := range roundKeys[i] {
+			copy(chunk[:], stream[:bytesPerElement])
+			stream = stream[bytesPerElement:]
+			for k := 0; k < bytesPerElement/2; k++ {
+				chunk[k], chunk[bytesPerElement-1-k] = chunk[bytesPerElement-1-k], chunk[k]
+			}
+			roundKeys[i][j].SetBytes(chunk[:])
+		}
+	}
+}
+
+// Hash provides the Rescue-Prime Optimized permutation of width Width on
+// buffers.
+type Hash struct{}
+
+// NewHash returns the Rescue-Prime Optimized permutation.
+func NewHash() Hash {
+	initOnce.Do(initParameters)
+	return Hash{}
+}
+
+// sBox sets x to xᵅ
+func sBox(x *fr.Element) {
+	var tmp fr.Element
+	tmp.Set(x)
+	for i := bits.Len(Alpha) - 2; i >= 0; i-- {
+		x.Square(x)
+		if (Alpha>>i)&1 == 1 {
+			x.Mul(x, &tmp)
+		}
+	}
+}
+
+// sBoxInv sets x to x^(1/α)
+func sBoxInv(x *fr.Element) {
+	x.Exp(*x, &alphaInv)
+}
+
+// matMulInPlace multiplies the state by the MDS matrix
+func matMulInPlace(state []fr.Element) {
+	var res [Width]fr.Element
+	var tmp fr.Element
+	for i := range res {
+		for j := range state {
+			tmp.Mul(&mds[i][j], &state[j])
+			res[i].Add(&res[i], &tmp)
+		}
+	}
+	copy(state, res[:])
+}
+
+// addRoundKeyInPlace adds the i-th round key to the state
+func addRoundKeyInPlace(i int, state []fr.Element) {
+	for j := range state {
+		state[j].Add(&state[j], &roundKeys[i][j])
+	}
+}
+
+// Permutation applies the permutation on input, and stores the result in input.
+//
+// Each round is
+//
+//	x ← M⋅x + C₂ᵣ, x ← xᵅ, x ← M⋅x + C₂ᵣ₊₁, x ← x^(1/α)
+//
+// where the s-boxes are applied on each element of the state.
+func (h *Hash) Permutation(input []fr.Element) error {
+	if len(input) != Width {
+		return ErrInvalidSizebuffer
+	}
+	for r := 0; r < NbRounds; r++ {
+		matMulInPlace(input)
+		addRoundKeyInPlace(2*r, input)
+		for i := range input {
+			sBox(&input[i])
+		}
+		matMulInPlace(input)
+		addRoundKeyInPlace(2*r+1, input)
+		for i := range input {
+			sBoxInv(&input[i])
+		}
+	}
+	return nil
+}
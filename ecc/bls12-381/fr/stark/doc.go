@@ -0,0 +1,38 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package stark provides a STARK prover and verifier for computations
+// described by an algebraic intermediate representation ([AIR]) over fr.
+//
+// The execution trace is a matrix of NbRows rows and NbColumns columns, where
+// NbRows is a power of 2, whose columns are interpolated on the subgroup ⟨g⟩ of
+// size NbRows. The transition constraints hold on all the pairs of consecutive
+// rows, and the boundary constraints fix the values of some cells.
+//
+// The protocol, made non interactive with Fiat-Shamir, is the following:
+//  1. the prover commits to the low degree extension of the trace on a
+//     domain [WithBlowup] times larger than the trace;
+//  2. from a challenge α, it computes the composition polynomial H, the
+//     random linear combination of the constraints divided by their vanishing
+//     polynomials, on a coset of a domain large enough for its degree, and
+//     commits to its segments H = ∑ Xⁱⁿ⋅Hᵢ of degree < n;
+//  3. it sends the evaluations of the trace at an out-of-domain point z and at
+//     g⋅z, and of the segments at z, which the verifier checks against the
+//     constraints (DEEP-ALI);
+//  4. from a challenge γ, it computes the DEEP composition polynomial
+//     ∑ γᵏ⋅(P(X) - P(z))/(X - z), of degree < n, and proves its proximity to
+//     a low degree polynomial with FRI;
+//  5. at the positions opened by the proof of proximity, whose transcript is
+//     seeded with the one of the protocol, it opens the trace and the
+//     segments, which the verifier checks against the DEEP composition
+//     polynomial.
+//
+// The challenges α, z and γ are drawn from fr, which is large enough for
+// the soundness of the protocol.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package stark
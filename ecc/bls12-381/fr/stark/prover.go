@@ -0,0 +1,542 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/big"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fri"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+// Proof is a STARK proof that the prover knows a trace satisfying an AIR.
+type Proof struct {
+
+	// TraceRoot and CompositionRoot are the Merkle roots of the rows of the
+	// low degree extensions of the trace and of the segments of the composition polynomial.
+	TraceRoot, CompositionRoot []byte
+
+	// TraceAtZ and TraceAtGZ are the columns of the trace at the out-of-domain
+	// point z and at g⋅z.
+	TraceAtZ, TraceAtGZ []fr.Element
+
+	// CompositionAtZ are the segments of the composition polynomial at z.
+	CompositionAtZ []fr.Element
+
+	// ProofOfProximity is the FRI proof of proximity of the DEEP composition
+	// polynomial, which opens it at the query positions.
+	ProofOfProximity *fri.BatchProofOfProximity
+
+	// Queries are the openings at the query positions.
+	Queries []Query
+}
+
+// Query contains the openings of the committed polynomials at a query
+// position, one of the positions of the values opened by the proof of
+// proximity.
+type Query struct {
+
+	// Trace and Composition are the rows of the low degree extensions of the
+	// trace and of the coordinates of the segments of the composition
+	// polynomial.
+	Trace, Composition Opening
+}
+
+// Opening is a row of a matrix committed in a Merkle tree, with its Merkle
+// path.
+type Opening struct {
+	Values []fr.Element
+
+	// Path is the Merkle path of the row, without the leaf.
+	Path [][]byte
+}
+
+// Option sets the parameters of the prover and the verifier, which must
+// match.
+type Option func(*config)
+
+type config struct {
+	nbQueries int
+	blowup    int
+}
+
+// WithNbQueries sets the number of queries of the proof of proximity, each
+// opening the committed polynomials at FoldingFactor positions. The default is
+// 32.
+func WithNbQueries(nbQueries int) Option {
+	return func(cfg *config) {
+		cfg.nbQueries = nbQueries
+	}
+}
+
+// WithBlowup sets the ratio of the size of the low degree extensions to the
+// number of rows of the trace, which is the blowup of the proof of proximity.
+// It is a power of 2, and the default is fri.GetRho().
+func WithBlowup(blowup int) Option {
+	return func(cfg *config) {
+		cfg.blowup = blowup
+	}
+}
+
+func options(opts ...Option) config {
+	cfg := config{nbQueries: 32, blowup: fri.GetRho()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// check returns an error if the parameters are invalid.
+func (cfg *config) check() error {
+	if cfg.blowup < 2 || bits.OnesCount(uint(cfg.blowup)) != 1 || cfg.nbQueries < 1 {
+		return fri.ErrInvalidParameters
+	}
+	return nil
+}
+
+// newFRI returns the proof of proximity of the polynomials of size n, on the
+// domain of the low degree extensions, whose transcript is seeded with seed.
+func (cfg *config) newFRI(n uint64, h hash.Hash, seed []byte) (*fri.FRI, error) {
+	return fri.New(n, h, fri.WithNbQueries(cfg.nbQueries), fri.WithBlowup(cfg.blowup), fri.WithTranscriptSeed(seed))
+}
+
+// Prove returns a proof that trace, given as a list of columns, satisfies the
+// constraints of air. h is used for the Merkle trees, the proof of proximity
+// and the Fiat-Shamir transcript.
+func Prove(air *AIR, trace [][]fr.Element, h hash.Hash, opts ...Option) (Proof, error) {
+	if err := air.Check(trace); err != nil {
+		return Proof{}, err
+	}
+	return prove(air, trace, h, options(opts...))
+}
+
+// prove returns a proof for trace, which is not checked against air.
+func prove(air *AIR, trace [][]fr.Element, h hash.Hash, cfg config) (Proof, error) {
+	var proof Proof
+	if err := cfg.check(); err != nil {
+		return proof, err
+	}
+
+	fs, err := newTranscript(h, air)
+	if err != nil {
+		return proof, err
+	}
+
+	n := uint64(air.NbRows)
+	domain := fft.NewDomain(n)
+	lde := fft.NewDomain(n * uint64(cfg.blowup))
+
+	// interpolate the trace, and commit to its low degree extension
+	traceCoeffs := make([][]fr.Element, len(trace))
+	for i := range trace {
+		traceCoeffs[i] = make([]fr.Element, n)
+		copy(traceCoeffs[i], trace[i])
+		domain.FFTInverse(traceCoeffs[i], fft.DIF)
+		fft.BitReverse(traceCoeffs[i])
+	}
+	traceLDE := evaluate(lde, traceCoeffs)
+	proof.TraceRoot = merkleRoot(h, traceLDE)
+	alpha, err := deriveChallenge(fs, "alpha", proof.TraceRoot)
+	if err != nil {
+		return proof, err
+	}
+
+	// composition polynomial, committed to with its coordinates
+	compositionCoeffs := air.composition(domain, traceCoeffs, alpha)
+	compositionLDE := evaluate(lde, coordinates(compositionCoeffs...))
+	proof.CompositionRoot = merkleRoot(h, compositionLDE)
+	z, err := deriveChallenge(fs, "z", proof.CompositionRoot)
+	if err != nil {
+		return proof, err
+	}
+
+	// out-of-domain evaluations
+	var gz fr.Element
+	gz.Mul(&z, &domain.Generator)
+	proof.TraceAtZ = make([]fr.Element, len(traceCoeffs))
+	proof.TraceAtGZ = make([]fr.Element, len(traceCoeffs))
+	for i := range traceCoeffs {
+		proof.TraceAtZ[i] = evalPolynomial(traceCoeffs[i], z)
+		proof.TraceAtGZ[i] = evalPolynomial(traceCoeffs[i], gz)
+	}
+	proof.CompositionAtZ = make([]fr.Element, len(compositionCoeffs))
+	for i := range compositionCoeffs {
+		proof.CompositionAtZ[i] = evalExtensionPolynomial(compositionCoeffs[i], z)
+	}
+	gamma, err := deriveChallenge(fs, "gamma", marshal(proof.TraceAtZ, proof.TraceAtGZ, proof.CompositionAtZ)...)
+	if err != nil {
+		return proof, err
+	}
+
+	// DEEP composition polynomial, and the proof of proximity of its
+	// coordinates, whose transcript follows the one of the protocol so that
+	// the positions are derived after the commitments and the statement
+	deep := deepComposition(traceCoeffs, compositionCoeffs, &proof, z, gz, gamma)
+	seed, err := fs.ComputeChallenge("fri")
+	if err != nil {
+		return proof, err
+	}
+	iopp, err := cfg.newFRI(n, h, seed)
+	if err != nil {
+		return proof, err
+	}
+	if proof.ProofOfProximity, err = iopp.BuildProofOfProximity(coordinates(deep)...); err != nil {
+		return proof, err
+	}
+
+	// queries, at the positions opened by the proof of proximity
+	positions, err := iopp.Positions(proof.ProofOfProximity)
+	if err != nil {
+		return proof, err
+	}
+	proof.Queries = make([]Query, len(positions))
+	for i, pos := range positions {
+		if proof.Queries[i].Trace, err = merkleOpen(h, traceLDE, pos); err != nil {
+			return proof, err
+		}
+		if proof.Queries[i].Composition, err = merkleOpen(h, compositionLDE, pos); err != nil {
+			return proof, err
+		}
+	}
+
+	return proof, nil
+}
+
+// composition returns the segments H₀, …, H_{s-1} of degree < n, in canonical
+// basis, of the composition polynomial H = ∑ Xⁱⁿ⋅Hᵢ. H is evaluated on a coset
+// of a domain larger than its degree, where the vanishing polynomials of the
+// constraints are invertible, and interpolated.
+func (air *AIR) composition(domain *fft.Domain, traceCoeffs [][]fr.Element, alpha fr.Element) [][]fr.Element {
+	n := int(domain.Cardinality)
+	nbSegments := air.nbSegments()
+	blowup := int(ecc.NextPowerOfTwo(uint64(nbSegments)))
+	m := n * blowup
+	coset := fft.NewDomain(uint64(m))
+	traceOnCoset := evaluate(coset, traceCoeffs, fft.OnCoset())
+
+	// xₖ = shift⋅ωᵏ, where ω generates the domain of size m
+	x := make([]fr.Element, m)
+	x[0].Set(&coset.FrMultiplicativeGen)
+	for k := 1; k < m; k++ {
+		x[k].Mul(&x[k-1], &coset.Generator)
+	}
+
+	// xₖⁿ - 1 only takes blowup values, as ωⁿ is of order blowup
+	zInv := make([]fr.Element, blowup)
+	var one fr.Element
+	one.SetOne()
+	for k := range zInv {
+		zInv[k].Exp(x[k], big.NewInt(int64(n))).Sub(&zInv[k], &one)
+	}
+	zInv = fr.BatchInvert(zInv)
+
+	// 1/(xₖ - g^{rⱼ}) for the boundary constraints
+	boundaryFactors := make([][]fr.Element, len(air.Boundaries))
+	var gr fr.Element
+	for j, b := range air.Boundaries {
+		gr.Exp(domain.Generator, big.NewInt(int64(b.Row)))
+		boundaryFactors[j] = make([]fr.Element, m)
+		for k := range boundaryFactors[j] {
+			boundaryFactors[j][k].Sub(&x[k], &gr)
+		}
+		boundaryFactors[j] = fr.BatchInvert(boundaryFactors[j])
+	}
+
+	res := make([]fr.Element, m)
+	parallel.Execute(m, func(start, end int) {
+		current, next := make([]fr.Element, air.NbColumns), make([]fr.Element, air.NbColumns)
+		bf := make([]fr.Element, len(air.Boundaries))
+		scratch := make([]fr.Element, air.NbTransitionConstraints)
+		var transitionFactor fr.Element
+		var tf fr.Element
+		for k := start; k < end; k++ {
+			// g⋅xₖ = xₖ₊blowup
+			for i := range traceOnCoset {
+				current[i].Set(&traceOnCoset[i][k])
+				next[i].Set(&traceOnCoset[i][(k+blowup)%m])
+			}
+			for j := range bf {
+				bf[j].Set(&boundaryFactors[j][k])
+			}
+			transitionFactor.Sub(&x[k], &domain.GeneratorInv).Mul(&transitionFactor, &zInv[k%blowup])
+			tf.Set(&transitionFactor)
+			res[k] = air.evaluateConstraints(current, next, tf, bf, alpha, scratch)
+		}
+	})
+
+	// the interpolation is fr-linear, so it is computed on the coordinates
+	c := coordinates(res)
+	for j := range c {
+		coset.FFTInverse(c[j], fft.DIF, fft.OnCoset())
+		fft.BitReverse(c[j])
+	}
+	res = fromCoordinatesPolynomials(c)
+
+	segments := make([][]fr.Element, nbSegments)
+	for i := range segments {
+		segments[i] = res[i*n : (i+1)*n]
+	}
+	return segments
+}
+
+// deepComposition returns the DEEP composition polynomial, in canonical basis
+//
+//	∑ⱼ γʲ⋅(Tⱼ(X) - Tⱼ(z))/(X - z) + ∑ⱼ γᶜ⁺ʲ⋅(Tⱼ(X) - Tⱼ(g⋅z))/(X - g⋅z) + ∑ᵢ γ²ᶜ⁺ⁱ⋅(Hᵢ(X) - Hᵢ(z))/(X - z)
+//
+// where c is the number of columns of the trace.
+func deepComposition(traceCoeffs [][]fr.Element, compositionCoeffs [][]fr.Element, proof *Proof, z, gz, gamma fr.Element) []fr.Element {
+	n := len(traceCoeffs[0])
+	atZ := make([]fr.Element, n)
+	atGZ := make([]fr.Element, n)
+
+	var coeff, tmp fr.Element
+	coeff.SetOne()
+	subtract := func(res []fr.Element, value fr.Element) {
+		tmp.Mul(&value, &coeff)
+		res[0].Sub(&res[0], &tmp)
+		coeff.Mul(&coeff, &gamma)
+	}
+	accumulate := func(res []fr.Element, p []fr.Element, value fr.Element) {
+		for i := range p {
+			tmp.Mul(&coeff, &p[i])
+			res[i].Add(&res[i], &tmp)
+		}
+		subtract(res, value)
+	}
+	for j := range traceCoeffs {
+		accumulate(atZ, traceCoeffs[j], proof.TraceAtZ[j])
+	}
+	for j := range traceCoeffs {
+		accumulate(atGZ, traceCoeffs[j], proof.TraceAtGZ[j])
+	}
+	for i := range compositionCoeffs {
+		for l := range compositionCoeffs[i] {
+			tmp.Mul(&compositionCoeffs[i][l], &coeff)
+			atZ[l].Add(&atZ[l], &tmp)
+		}
+		subtract(atZ, proof.CompositionAtZ[i])
+	}
+
+	divideByLinear(atZ, z)
+	divideByLinear(atGZ, gz)
+	for i := range atZ {
+		atZ[i].Add(&atZ[i], &atGZ[i])
+	}
+	return atZ
+}
+
+// divideByLinear sets p to the quotient of p by X - a, dropping the
+// remainder.
+func divideByLinear(p []fr.Element, a fr.Element) {
+	var carry, tmp fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		tmp.Set(&p[i])
+		p[i].Set(&carry)
+		carry.Mul(&carry, &a).Add(&carry, &tmp)
+	}
+}
+
+// evalPolynomial returns p(x), p being in canonical basis.
+func evalPolynomial(p []fr.Element, x fr.Element) fr.Element {
+	var res, c fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		c.Set(&p[i])
+		res.Mul(&res, &x).Add(&res, &c)
+	}
+	return res
+}
+
+// evalExtensionPolynomial returns p(x), p being in canonical basis.
+func evalExtensionPolynomial(p []fr.Element, x fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(&res, &x).Add(&res, &p[i])
+	}
+	return res
+}
+
+// evaluate returns the evaluations of the polynomials p, in canonical basis,
+// on domain.
+func evaluate(domain *fft.Domain, p [][]fr.Element, opts ...fft.Option) [][]fr.Element {
+	res := make([][]fr.Element, len(p))
+	for i := range p {
+		res[i] = make([]fr.Element, domain.Cardinality)
+		copy(res[i], p[i])
+		domain.FFT(res[i], fft.DIF, opts...)
+		fft.BitReverse(res[i])
+	}
+	return res
+}
+
+// leaf returns the leaf of the Merkle tree of a row.
+func leaf(row []fr.Element) []byte {
+	res := make([]byte, 0, len(row)*fr.Bytes)
+	for i := range row {
+		b := row[i].Bytes()
+		res = append(res, b[:]...)
+	}
+	return res
+}
+
+// pushRows pushes the rows of columns in t.
+func pushRows(t *merkletree.Tree, columns [][]fr.Element) {
+	row := make([]fr.Element, len(columns))
+	for i := range columns[0] {
+		for j := range columns {
+			row[j] = columns[j][i]
+		}
+		t.Push(leaf(row))
+	}
+}
+
+// merkleRoot returns the root of the Merkle tree of the rows of columns.
+func merkleRoot(h hash.Hash, columns [][]fr.Element) []byte {
+	t := merkletree.New(h)
+	pushRows(t, columns)
+	return t.Root()
+}
+
+// merkleOpen returns the opening of the row pos of columns.
+func merkleOpen(h hash.Hash, columns [][]fr.Element, pos uint64) (Opening, error) {
+	t := merkletree.New(h)
+	if err := t.SetIndex(pos); err != nil {
+		return Opening{}, err
+	}
+	pushRows(t, columns)
+	_, proofSet, _, _ := t.Prove()
+
+	res := Opening{Values: make([]fr.Element, len(columns)), Path: proofSet[1:]}
+	for j := range columns {
+		res.Values[j] = columns[j][pos]
+	}
+	return res, nil
+}
+
+// newTranscript returns the Fiat-Shamir transcript of the protocol, bound to
+// the statement: the dimensions of the AIR and its boundary constraints.
+func newTranscript(h hash.Hash, air *AIR) (*fiatshamir.Transcript, error) {
+	fs := fiatshamir.NewTranscript(h, "alpha", "z", "gamma", "fri")
+	var buf [8]byte
+	for _, v := range []int{air.NbColumns, air.NbRows, air.NbTransitionConstraints, air.TransitionDegree} {
+		binary.BigEndian.PutUint64(buf[:], uint64(v))
+		if err := fs.Bind("alpha", buf[:]); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range air.Boundaries {
+		binary.BigEndian.PutUint64(buf[:], uint64(b.Column))
+		if err := fs.Bind("alpha", buf[:]); err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint64(buf[:], uint64(b.Row))
+		if err := fs.Bind("alpha", buf[:]); err != nil {
+			return nil, err
+		}
+		if err := fs.Bind("alpha", b.Value.Marshal()); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// deriveChallenge binds data to the challenge name, and returns it. Each
+// coordinate of the challenge is derived from a chunk of the digest.
+func deriveChallenge(fs *fiatshamir.Transcript, name string, data ...[]byte) (fr.Element, error) {
+	var res fr.Element
+	for i := range data {
+		if err := fs.Bind(name, data[i]); err != nil {
+			return res, err
+		}
+	}
+	b, err := fs.ComputeChallenge(name)
+	if err != nil {
+		return res, err
+	}
+	var c [1]fr.Element
+	chunkSize := len(b) / len(c)
+	for j := range c {
+		c[j].SetBytes(b[j*chunkSize : (j+1)*chunkSize])
+	}
+	fromCoordinates(&res, &c)
+	return res, nil
+}
+
+func marshal(vectors ...[]fr.Element) [][]byte {
+	var res [][]byte
+	for _, v := range vectors {
+		for i := range v {
+			c := toCoordinates(&v[i])
+			b := make([]byte, 0, len(c)*fr.Bytes)
+			for j := range c {
+				b = append(b, c[j].Marshal()...)
+			}
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+// coordinates returns copies of the polynomials: the challenges are in fr,
+// which is its own coordinate.
+func coordinates(p ...[]fr.Element) [][]fr.Element {
+	res := make([][]fr.Element, 0, len(p)*1)
+	for i := range p {
+		var c [1][]fr.Element
+		for j := range c {
+			c[j] = make([]fr.Element, len(p[i]))
+		}
+		for l := range p[i] {
+			v := toCoordinates(&p[i][l])
+			for j := range c {
+				c[j][l] = v[j]
+			}
+		}
+		res = append(res, c[:]...)
+	}
+	return res
+}
+
+// fromCoordinatesPolynomials returns the polynomial over fr of the given
+// coordinates, see coordinates.
+func fromCoordinatesPolynomials(c [][]fr.Element) []fr.Element {
+	res := make([]fr.Element, len(c[0]))
+	var v [1]fr.Element
+	for l := range res {
+		for j := range v {
+			v[j] = c[j][l]
+		}
+		fromCoordinates(&res[l], &v)
+	}
+	return res
+}
+
+// fromCoordinatesVector returns the elements of fr of the given coordinates,
+// 1 consecutive coordinates for each of them.
+func fromCoordinatesVector(v []fr.Element) []fr.Element {
+	res := make([]fr.Element, len(v)/1)
+	for i := range res {
+		fromCoordinates(&res[i], (*[1]fr.Element)(v[i*1:]))
+	}
+	return res
+}
+
+// toCoordinates returns the coordinates of x over fr.
+func toCoordinates(x *fr.Element) [1]fr.Element {
+	return [1]fr.Element{*x}
+}
+
+// fromCoordinates sets z from its coordinates over fr.
+func fromCoordinates(z *fr.Element, c *[1]fr.Element) {
+	*z = c[0]
+}
@@ -0,0 +1,193 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package stark
+
+import (
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	fr "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+)
+
+var (
+	ErrProofShape       = errors.New("the proof does not have the shape of the AIR")
+	ErrOutOfDomainCheck = errors.New("the composition polynomial does not match the constraints at the out-of-domain point")
+	ErrMerklePath       = errors.New("merkle path proof is wrong")
+	ErrDEEPComposition  = errors.New("the DEEP composition polynomial does not match the openings")
+)
+
+// Verify verifies a proof that the prover knows a trace satisfying the
+// constraints of air. h and the options must be those of the prover.
+func Verify(air *AIR, proof *Proof, h hash.Hash, opts ...Option) error {
+	cfg := options(opts...)
+	if err := cfg.check(); err != nil {
+		return err
+	}
+	if err := air.check(); err != nil {
+		return err
+	}
+	nbSegments := air.nbSegments()
+	if len(proof.TraceAtZ) != air.NbColumns || len(proof.TraceAtGZ) != air.NbColumns ||
+		len(proof.CompositionAtZ) != nbSegments || proof.ProofOfProximity == nil {
+		return ErrProofShape
+	}
+
+	alpha, z, gamma, seed, err := replay(air, proof, h)
+	if err != nil {
+		return err
+	}
+
+	n := uint64(air.NbRows)
+	ldeSize := n * uint64(cfg.blowup)
+	g, err := fft.Generator(n)
+	if err != nil {
+		return err
+	}
+	omega, err := fft.Generator(ldeSize)
+	if err != nil {
+		return err
+	}
+	var gz fr.Element
+	gz.Mul(&z, &g)
+
+	// out-of-domain check: the constraints divided by their vanishing
+	// polynomials at z should match H(z) = ∑ zⁱⁿ⋅Hᵢ(z)
+	var zn, one, transitionFactor, tmp fr.Element
+	var gInv, gr fr.Element
+	one.SetOne()
+	zn.Exp(z, big.NewInt(int64(n)))
+	transitionFactor.Sub(&zn, &one).Inverse(&transitionFactor)
+	gInv.Inverse(&g)
+	tmp.Set(&gInv)
+	tmp.Sub(&z, &tmp)
+	transitionFactor.Mul(&transitionFactor, &tmp)
+	boundaryFactors := make([]fr.Element, len(air.Boundaries))
+	for j, b := range air.Boundaries {
+		gr.Exp(g, big.NewInt(int64(b.Row)))
+		boundaryFactors[j].Set(&gr)
+		boundaryFactors[j].Sub(&z, &boundaryFactors[j]).Inverse(&boundaryFactors[j])
+	}
+	expected := air.evaluateConstraints(proof.TraceAtZ, proof.TraceAtGZ, transitionFactor, boundaryFactors, alpha, make([]fr.Element, air.NbTransitionConstraints))
+	composition := evalExtensionPolynomial(proof.CompositionAtZ, zn)
+	if !expected.Equal(&composition) {
+		return ErrOutOfDomainCheck
+	}
+
+	// proof of proximity of the coordinates of the DEEP composition
+	// polynomial
+	iopp, err := cfg.newFRI(n, h, seed)
+	if err != nil {
+		return err
+	}
+	if err := iopp.VerifyProofOfProximity(proof.ProofOfProximity); err != nil {
+		return err
+	}
+
+	// queries, at the positions opened by the proof of proximity
+	positions, err := iopp.Positions(proof.ProofOfProximity)
+	if err != nil {
+		return err
+	}
+	if len(proof.Queries) != len(positions) {
+		return ErrProofShape
+	}
+	k := iopp.Parameters().FoldingFactor
+	var x fr.Element
+	var deep fr.Element
+	for i, pos := range positions {
+		q := &proof.Queries[i]
+		opened := proof.ProofOfProximity.Layers[0].Values[i/k]
+		if len(q.Trace.Values) != air.NbColumns || len(q.Composition.Values) != nbSegments*1 ||
+			len(opened) != 1*k {
+			return ErrProofShape
+		}
+		if !merkleVerify(h, proof.TraceRoot, &q.Trace, pos, ldeSize) ||
+			!merkleVerify(h, proof.CompositionRoot, &q.Composition, pos, ldeSize) {
+			return ErrMerklePath
+		}
+
+		// the value of the DEEP composition polynomial opened by the proof of
+		// proximity, from its coordinates
+		var c [1]fr.Element
+		for j := range c {
+			c[j] = opened[j*k+i%k]
+		}
+		fromCoordinates(&deep, &c)
+
+		x.Exp(omega, new(big.Int).SetUint64(pos))
+		expected := evalDEEPComposition(proof, q, x, z, gz, gamma)
+		if !expected.Equal(&deep) {
+			return ErrDEEPComposition
+		}
+	}
+
+	return nil
+}
+
+// replay replays the transcript of the proof, and returns the challenges α, z
+// and γ, and the seed of the transcript of the proof of proximity.
+func replay(air *AIR, proof *Proof, h hash.Hash) (alpha, z, gamma fr.Element, seed []byte, err error) {
+	fs, err := newTranscript(h, air)
+	if err != nil {
+		return alpha, z, gamma, nil, err
+	}
+	if alpha, err = deriveChallenge(fs, "alpha", proof.TraceRoot); err != nil {
+		return alpha, z, gamma, nil, err
+	}
+	if z, err = deriveChallenge(fs, "z", proof.CompositionRoot); err != nil {
+		return alpha, z, gamma, nil, err
+	}
+	if gamma, err = deriveChallenge(fs, "gamma", marshal(proof.TraceAtZ, proof.TraceAtGZ, proof.CompositionAtZ)...); err != nil {
+		return alpha, z, gamma, nil, err
+	}
+	seed, err = fs.ComputeChallenge("fri")
+	return alpha, z, gamma, seed, err
+}
+
+// evalDEEPComposition returns the DEEP composition polynomial at x, from the
+// openings of the trace and of the segments of the composition polynomial at
+// x.
+func evalDEEPComposition(proof *Proof, q *Query, x fr.Element, z, gz, gamma fr.Element) fr.Element {
+	var atZ, atGZ, coeff, tmp, v fr.Element
+	coeff.SetOne()
+	accumulate := func(res *fr.Element, value, claimed fr.Element) {
+		tmp.Sub(&value, &claimed).Mul(&tmp, &coeff)
+		res.Add(res, &tmp)
+		coeff.Mul(&coeff, &gamma)
+	}
+	for j := range q.Trace.Values {
+		v.Set(&q.Trace.Values[j])
+		accumulate(&atZ, v, proof.TraceAtZ[j])
+	}
+	for j := range q.Trace.Values {
+		v.Set(&q.Trace.Values[j])
+		accumulate(&atGZ, v, proof.TraceAtGZ[j])
+	}
+	segments := fromCoordinatesVector(q.Composition.Values)
+	for i := range segments {
+		accumulate(&atZ, segments[i], proof.CompositionAtZ[i])
+	}
+
+	var xz, xgz fr.Element
+	v.Set(&x)
+	xz.Sub(&v, &z).Inverse(&xz)
+	xgz.Sub(&v, &gz).Inverse(&xgz)
+	atZ.Mul(&atZ, &xz)
+	atGZ.Mul(&atGZ, &xgz)
+	return *atZ.Add(&atZ, &atGZ)
+}
+
+// merkleVerify verifies the opening o of the row pos of a Merkle tree of root
+// with numLeaves leaves.
+func merkleVerify(h hash.Hash, root []byte, o *Opening, pos, numLeaves uint64) bool {
+	proofSet := make([][]byte, 0, len(o.Path)+1)
+	proofSet = append(proofSet, leaf(o.Values))
+	proofSet = append(proofSet, o.Path...)
+	return merkletree.VerifyProof(h, root, proofSet, pos, numLeaves)
+}
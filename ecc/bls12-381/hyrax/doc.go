@@ -0,0 +1,31 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+// Package hyrax provides the transparent commitment scheme for
+// multilinear polynomials of Hyrax.
+//
+// The evaluations of a multilinear polynomial f on the hypercube are laid out
+// as a matrix M of 2ᵐ columns, where 2ᵐ is the size of the setup, so that
+//
+//	f(z) = L(z₁, …, zₖ)ᵀ⋅M⋅R(zₖ₊₁, …, zₙ)
+//
+// where L and R are the tables of eq on the hypercube. The commitment is the
+// vector of the Pedersen commitments ∑ⱼMᵢⱼGⱼ of the rows, and an opening proof
+// at z is the row u = LᵀM, which the verifier checks against the commitments
+// with a single MSM, and against the claimed value with ⟨u, R⟩. The setup is
+// a vector of curve points with unknown discrete logarithm relations, obtained
+// by hashing to the curve. For polynomials of size N = 2ᵐ⁺ᵏ with k ≈ m, the
+// commitments and the proofs are of size O(√N).
+//
+// The polynomials are given by their evaluations on the hypercube, in the
+// order of polynomial.MultiLin, and implement the mlpcs.Scheme interface with
+// [Scheme]. The commitments are binding but not hiding.
+//
+// See https://eprint.iacr.org/2017/1132.pdf.
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package hyrax
@@ -0,0 +1,387 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Code generated by consensys/gnark-crypto DO NOT EDIT
+
+package hyrax
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/bits"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	fiatshamir "github.com/consensys/gnark-crypto/fiat-shamir"
+	"github.com/consensys/gnark-crypto/mlpcs"
+
+	"github.com/consensys/gnark-crypto/internal/parallel"
+)
+
+var (
+	ErrInvalidNbDigests      = errors.New("number of digests is not the same as the number of polynomials")
+	ErrZeroNbDigests         = errors.New("number of digests is zero")
+	ErrInvalidPolynomialSize = errors.New("invalid polynomial size (not a power of 2 or not matching the point)")
+	ErrInvalidSRSSize        = errors.New("srs size must be a power of 2")
+	ErrInvalidProof          = errors.New("the shape of the proof doesn't match the digest and the point")
+	ErrVerifyOpeningProof    = errors.New("can't verify opening proof")
+)
+
+// srsDST is the domain separation tag used to hash the SRS points to the curve
+const srsDST = "HYRAX_SRS_BLS12-381_V1_"
+
+// Digest commitment of a multilinear polynomial, the commitments of the rows
+// of its matrix.
+type Digest []bls12381.G1Affine
+
+// SRS is the transparent setup of the scheme. The points are obtained by
+// hashing to the curve, so that their discrete logarithm relations are unknown.
+type SRS struct {
+	G []bls12381.G1Affine // basis of the commitments of the rows, of size a power of 2
+}
+
+// OpeningProof proves that a committed polynomial evaluates to ClaimedValue
+// at a point.
+type OpeningProof struct {
+	// Row is the combination LᵀM of the rows of the matrix
+	Row []fr.Element
+
+	// ClaimedValue purported value
+	ClaimedValue fr.Element
+}
+
+// BatchOpeningProof proves the evaluations of several committed polynomials
+// at the same point.
+type BatchOpeningProof struct {
+	// Row is the combination LᵀM of the rows of the matrix M = ∑ᵢγⁱMᵢ
+	Row []fr.Element
+
+	// ClaimedValues purported values
+	ClaimedValues []fr.Element
+}
+
+// NewSRS returns an SRS of the given size, the number of columns of the
+// matrices, which must be a power of 2. The points are obtained by hashing
+// seed and their index to the curve.
+func NewSRS(size uint64, seed []byte) (*SRS, error) {
+	if size == 0 || bits.OnesCount64(size) != 1 {
+		return nil, ErrInvalidSRSSize
+	}
+	points := make([]bls12381.G1Affine, size)
+	errs := make([]error, size)
+	parallel.Execute(len(points), func(start, end int) {
+		msg := make([]byte, len(seed)+8)
+		copy(msg, seed)
+		for i := start; i < end; i++ {
+			binary.BigEndian.PutUint64(msg[len(seed):], uint64(i))
+			points[i], errs[i] = bls12381.HashToG1(msg, []byte(srsDST))
+		}
+	})
+	for i := range errs {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+	}
+	return &SRS{G: points}, nil
+}
+
+// shape returns the log of the numbers of rows and of columns of the matrix
+// of a polynomial in nbVars variables: the number of columns is the size of
+// the SRS, or the size of the polynomial if it is smaller.
+func (srs *SRS) shape(nbVars int) (logRows, logColumns int) {
+	logColumns = min(nbVars, bits.TrailingZeros(uint(len(srs.G))))
+	return nbVars - logColumns, logColumns
+}
+
+// Commit commits to the multilinear polynomial of evaluations p, of size a
+// power of 2.
+func Commit(p []fr.Element, srs *SRS, nbTasks ...int) (Digest, error) {
+	if len(p) == 0 || bits.OnesCount(uint(len(p))) != 1 {
+		return nil, ErrInvalidPolynomialSize
+	}
+	logRows, logColumns := srs.shape(bits.TrailingZeros(uint(len(p))))
+	nbColumns := 1 << logColumns
+
+	config := ecc.MultiExpConfig{}
+	if len(nbTasks) > 0 {
+		config.NbTasks = nbTasks[0]
+	}
+
+	res := make(Digest, 1<<logRows)
+	for i := range res {
+		if _, err := res[i].MultiExp(srs.G[:nbColumns], p[i*nbColumns:(i+1)*nbColumns], config); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Open computes an opening proof of the multilinear polynomial p at point.
+func Open(p []fr.Element, point []fr.Element, srs *SRS) (OpeningProof, error) {
+	if len(p) != 1<<len(point) {
+		return OpeningProof{}, ErrInvalidPolynomialSize
+	}
+	logRows, _ := srs.shape(len(point))
+
+	row := combineRows(p, eqTable(point[:logRows]))
+	return OpeningProof{
+		Row:          row,
+		ClaimedValue: innerProduct(row, eqTable(point[logRows:])),
+	}, nil
+}
+
+// Verify verifies a Hyrax opening proof at point.
+func Verify(commitment *Digest, proof *OpeningProof, point []fr.Element, srs *SRS) error {
+	return verify(*commitment, proof.Row, proof.ClaimedValue, nil, point, srs)
+}
+
+// BatchOpenSinglePoint creates a batch opening proof at point of a list of polynomials.
+// It's an interactive protocol, made non-interactive using Fiat Shamir.
+//
+// * point is the point at which the polynomials are opened.
+// * digests is the list of committed polynomials to open, need to derive the challenge using Fiat Shamir.
+// * polynomials is the list of polynomials to open, they are supposed to be of the same size.
+// * dataTranscript extra data that might be needed to derive the challenge used for folding
+func BatchOpenSinglePoint(polynomials [][]fr.Element, digests []Digest, point []fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	if len(polynomials) == 0 {
+		return BatchOpeningProof{}, ErrZeroNbDigests
+	}
+	if len(digests) != len(polynomials) {
+		return BatchOpeningProof{}, ErrInvalidNbDigests
+	}
+	for i := range polynomials {
+		if len(polynomials[i]) != 1<<len(point) {
+			return BatchOpeningProof{}, ErrInvalidPolynomialSize
+		}
+	}
+	logRows, _ := srs.shape(len(point))
+	left, right := eqTable(point[:logRows]), eqTable(point[logRows:])
+
+	// the rows of the polynomials, from which are computed the claimed values
+	// and the row of the combination
+	rows := make([][]fr.Element, len(polynomials))
+	res := BatchOpeningProof{ClaimedValues: make([]fr.Element, len(polynomials))}
+	for i := range polynomials {
+		rows[i] = combineRows(polynomials[i], left)
+		res.ClaimedValues[i] = innerProduct(rows[i], right)
+	}
+
+	gamma, err := deriveGamma(point, digests, res.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return BatchOpeningProof{}, err
+	}
+
+	// ∑ᵢγⁱLᵀMᵢ
+	res.Row = make([]fr.Element, len(rows[0]))
+	for i := len(rows) - 1; i >= 0; i-- {
+		for j := range res.Row {
+			res.Row[j].Mul(&res.Row[j], &gamma).Add(&res.Row[j], &rows[i][j])
+		}
+	}
+
+	return res, nil
+}
+
+// BatchVerifySinglePoint verifies a batched opening proof at a single point of a list of polynomials.
+//
+// * digests list of digests on which opening proof is done
+// * batchOpeningProof proof of correct opening on the digests
+// * dataTranscript extra data that might be needed to derive the challenge used for the folding
+func BatchVerifySinglePoint(digests []Digest, batchOpeningProof *BatchOpeningProof, point []fr.Element, hf hash.Hash, srs *SRS, dataTranscript ...[]byte) error {
+	if len(digests) == 0 {
+		return ErrZeroNbDigests
+	}
+	if len(digests) != len(batchOpeningProof.ClaimedValues) {
+		return ErrInvalidNbDigests
+	}
+	gamma, err := deriveGamma(point, digests, batchOpeningProof.ClaimedValues, hf, dataTranscript...)
+	if err != nil {
+		return err
+	}
+
+	// the digests are folded in the MSM of the verification, and the claimed
+	// values here
+	gammai := make([]fr.Element, len(digests))
+	gammai[0].SetOne()
+	for i := 1; i < len(gammai); i++ {
+		gammai[i].Mul(&gammai[i-1], &gamma)
+	}
+	var value, tmp fr.Element
+	for i := range gammai {
+		tmp.Mul(&gammai[i], &batchOpeningProof.ClaimedValues[i])
+		value.Add(&value, &tmp)
+	}
+
+	var commitments Digest
+	for i := range digests {
+		commitments = append(commitments, digests[i]...)
+	}
+	return verify(commitments, batchOpeningProof.Row, value, gammai, point, srs)
+}
+
+// verify checks that row = LᵀM and ⟨row, R⟩ = value, where M = ∑ᵢγᵢMᵢ is the
+// combination with coefficients gammai of the matrices committed to in
+// commitments, the concatenation of their digests (gammai = nil for a
+// single digest):
+//
+//	∑ⱼ rowⱼGⱼ - ∑ᵢ∑ₖ γᵢLₖCᵢₖ == 0
+func verify(commitments Digest, row []fr.Element, value fr.Element, gammai []fr.Element, point []fr.Element, srs *SRS) error {
+	logRows, logColumns := srs.shape(len(point))
+	nbDigests := max(1, len(gammai))
+	if len(commitments) != nbDigests<<logRows || len(row) != 1<<logColumns {
+		return ErrInvalidProof
+	}
+
+	if v := innerProduct(row, eqTable(point[logRows:])); !v.Equal(&value) {
+		return ErrVerifyOpeningProof
+	}
+
+	left := eqTable(point[:logRows])
+	points := make([]bls12381.G1Affine, 0, len(row)+len(commitments))
+	scalars := make([]fr.Element, 0, len(row)+len(commitments))
+	points = append(points, srs.G[:len(row)]...)
+	scalars = append(scalars, row...)
+	points = append(points, commitments...)
+	for i := 0; i < nbDigests; i++ {
+		for k := range left {
+			var s fr.Element
+			s.Neg(&left[k])
+			if gammai != nil {
+				s.Mul(&s, &gammai[i])
+			}
+			scalars = append(scalars, s)
+		}
+	}
+
+	var check bls12381.G1Jac
+	if _, err := check.MultiExp(points, scalars, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	if !check.Z.IsZero() {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// Scheme implements mlpcs.Scheme with an SRS, and the hash function used by
+// Fiat-Shamir for the batch openings.
+type Scheme struct {
+	srs *SRS
+	hf  hash.Hash
+}
+
+var _ mlpcs.Scheme[fr.Element, Digest, OpeningProof, BatchOpeningProof] = (*Scheme)(nil)
+
+// NewScheme returns the Hyrax commitment scheme with the given SRS, using hf
+// for Fiat-Shamir.
+func NewScheme(srs *SRS, hf hash.Hash) *Scheme {
+	return &Scheme{srs: srs, hf: hf}
+}
+
+// Commit commits to p, see [Commit].
+func (s *Scheme) Commit(p []fr.Element) (Digest, error) {
+	return Commit(p, s.srs)
+}
+
+// Open opens p at point, see [Open].
+func (s *Scheme) Open(p []fr.Element, point []fr.Element) (OpeningProof, error) {
+	return Open(p, point, s.srs)
+}
+
+// Verify verifies an opening proof, see [Verify].
+func (s *Scheme) Verify(digest *Digest, proof *OpeningProof, point []fr.Element) error {
+	return Verify(digest, proof, point, s.srs)
+}
+
+// BatchOpen opens polynomials at point, see [BatchOpenSinglePoint].
+func (s *Scheme) BatchOpen(polynomials [][]fr.Element, digests []Digest, point []fr.Element, dataTranscript ...[]byte) (BatchOpeningProof, error) {
+	return BatchOpenSinglePoint(polynomials, digests, point, s.hf, s.srs, dataTranscript...)
+}
+
+// BatchVerify verifies a batch opening proof, see [BatchVerifySinglePoint].
+func (s *Scheme) BatchVerify(digests []Digest, proof *BatchOpeningProof, point []fr.Element, dataTranscript ...[]byte) error {
+	return BatchVerifySinglePoint(digests, proof, point, s.hf, s.srs, dataTranscript...)
+}
+
+// eqTable returns the evaluations of eq(q, ⋅) on the hypercube, in the order
+// of polynomial.MultiLin.
+func eqTable(q []fr.Element) []fr.Element {
+	res := make([]fr.Element, 1<<len(q))
+	res[0].SetOne()
+	for i := range q {
+		// res[j] = eq((q₁, …, qᵢ), j) for j < 2ⁱ, extended with qᵢ₊₁
+		for j := (1 << i) - 1; j >= 0; j-- {
+			res[2*j+1].Mul(&res[j], &q[i])
+			res[2*j].Sub(&res[j], &res[2*j+1])
+		}
+	}
+	return res
+}
+
+// combineRows returns LᵀM, where the rows of M are the len(left) chunks of p.
+func combineRows(p, left []fr.Element) []fr.Element {
+	nbColumns := len(p) / len(left)
+	res := make([]fr.Element, nbColumns)
+	parallel.Execute(nbColumns, func(start, end int) {
+		var tmp fr.Element
+		for i := range left {
+			row := p[i*nbColumns : (i+1)*nbColumns]
+			for j := start; j < end; j++ {
+				tmp.Mul(&row[j], &left[i])
+				res[j].Add(&res[j], &tmp)
+			}
+		}
+	})
+	return res
+}
+
+func innerProduct(a, b []fr.Element) fr.Element {
+	var res, tmp fr.Element
+	for i := range a {
+		tmp.Mul(&a[i], &b[i])
+		res.Add(&res, &tmp)
+	}
+	return res
+}
+
+// deriveGamma derives a challenge using Fiat Shamir to fold proofs.
+func deriveGamma(point []fr.Element, digests []Digest, claimedValues []fr.Element, hf hash.Hash, dataTranscript ...[]byte) (fr.Element, error) {
+
+	// derive the challenge gamma, binded to the point and the commitments
+	fs := fiatshamir.NewTranscript(hf, "gamma")
+	for i := range point {
+		if err := fs.Bind("gamma", point[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+	for i := range digests {
+		for j := range digests[i] {
+			b := digests[i][j].RawBytes()
+			if err := fs.Bind("gamma", b[:]); err != nil {
+				return fr.Element{}, err
+			}
+		}
+	}
+	for i := range claimedValues {
+		if err := fs.Bind("gamma", claimedValues[i].Marshal()); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	for i := 0; i < len(dataTranscript); i++ {
+		if err := fs.Bind("gamma", dataTranscript[i]); err != nil {
+			return fr.Element{}, err
+		}
+	}
+
+	gammaByte, err := fs.ComputeChallenge("gamma")
+	if err != nil {
+		return fr.Element{}, err
+	}
+	var gamma fr.Element
+	gamma.SetBytes(gammaByte)
+
+	return gamma, nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+// Package eip4844 implements the KZG polynomial commitment API of the Ethereum
+// consensus specs for EIP-4844 (Deneb), on top of the kzg package.
+//
+// A blob is a vector of 4096 field elements, the evaluations of a polynomial
+// of degree < 4096 on the 4096-th roots of unity in bit-reversed order. The
+// package commits to blobs with the Lagrange form of the Ethereum KZG
+// ceremony trusted setup (see [kzg.TrustedSetup]), opens them at points
+// inside or outside the domain using the barycentric formula, and derives
+// the evaluation challenges with the Fiat-Shamir transform of the specs.
+//
+// All inputs and outputs are byte arrays in the big-endian encodings of the
+// specs, and are validated (canonical field elements, points on the curve
+// and in the prime order subgroup).
+//
+// Documentation:
+//   - https://github.com/ethereum/consensus-specs/blob/dev/specs/deneb/polynomial-commitments.md
+//   - https://eips.ethereum.org/EIPS/eip-4844
+//
+// # Warning
+//
+// This code has not been audited and is provided as-is, we make no guarantees or warranties to its safety and reliability.
+package eip4844
@@ -0,0 +1,408 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+)
+
+const (
+	// BytesPerFieldElement is the size of a serialized field element.
+	BytesPerFieldElement = fr.Bytes
+	// FieldElementsPerBlob is the number of field elements in a blob.
+	FieldElementsPerBlob = 4096
+	// BytesPerBlob is the size of a serialized blob.
+	BytesPerBlob = BytesPerFieldElement * FieldElementsPerBlob
+	// BytesPerCommitment is the size of a serialized commitment.
+	BytesPerCommitment = bls12381.SizeOfG1AffineCompressed
+	// BytesPerProof is the size of a serialized proof.
+	BytesPerProof = bls12381.SizeOfG1AffineCompressed
+)
+
+const (
+	fiatShamirProtocolDomain   = "FSBLOBVERIFY_V1_"
+	randomChallengeBatchDomain = "RCKZGBATCH___V1_"
+)
+
+var (
+	ErrInvalidTrustedSetup = errors.New("trusted setup must have 4096 Lagrange points")
+	ErrInvalidFieldElement = errors.New("field element is not canonical")
+	ErrInvalidInputSize    = errors.New("number of blobs, commitments and proofs mismatch")
+	ErrVerifyOpeningProof  = kzg.ErrVerifyOpeningProof
+)
+
+// Blob is a serialized polynomial in evaluation form.
+type Blob [BytesPerBlob]byte
+
+// Bytes32 is a serialized field element, in big-endian.
+type Bytes32 [BytesPerFieldElement]byte
+
+// KZGCommitment is a compressed commitment to a blob.
+type KZGCommitment [BytesPerCommitment]byte
+
+// KZGProof is a compressed KZG opening proof.
+type KZGProof [BytesPerProof]byte
+
+// Context holds the trusted setup and the precomputed domain of the blobs.
+type Context struct {
+	lagrange []bls12381.G1Affine // Lagrange points, in bit-reversed order
+	vk       kzg.VerifyingKey
+
+	// roots are the 4096-th roots of unity in bit-reversed order
+	roots []fr.Element
+}
+
+// NewContext returns a context for the given trusted setup. The trusted setup
+// is not verified, see [kzg.TrustedSetup.Verify].
+func NewContext(ts *kzg.TrustedSetup) (*Context, error) {
+	if len(ts.G1Lagrange) != FieldElementsPerBlob || len(ts.G1Monomial) < 1 || len(ts.G2Monomial) < 2 {
+		return nil, ErrInvalidTrustedSetup
+	}
+	ctx := &Context{lagrange: ts.G1Lagrange}
+	ctx.vk.G1 = ts.G1Monomial[0]
+	ctx.vk.G2[0] = ts.G2Monomial[0]
+	ctx.vk.G2[1] = ts.G2Monomial[1]
+	ctx.vk.Lines[0] = bls12381.PrecomputeLines(ctx.vk.G2[0])
+	ctx.vk.Lines[1] = bls12381.PrecomputeLines(ctx.vk.G2[1])
+
+	domain := fft.NewDomain(FieldElementsPerBlob, fft.WithoutPrecompute())
+	ctx.roots = make([]fr.Element, FieldElementsPerBlob)
+	fft.BuildExpTable(domain.Generator, ctx.roots)
+	fft.BitReverse(ctx.roots)
+	return ctx, nil
+}
+
+// BlobToKZGCommitment returns the commitment to the polynomial of the blob.
+func (ctx *Context) BlobToKZGCommitment(blob *Blob) (KZGCommitment, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	commitment, err := ctx.commit(polynomial)
+	if err != nil {
+		return KZGCommitment{}, err
+	}
+	return commitment.Bytes(), nil
+}
+
+// ComputeKZGProof returns the proof of the evaluation of the polynomial of the
+// blob at z, together with the evaluation y.
+func (ctx *Context) ComputeKZGProof(blob *Blob, z Bytes32) (KZGProof, Bytes32, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	zElement, err := bytesToField(z)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	proof, y, err := ctx.computeKZGProof(polynomial, zElement)
+	if err != nil {
+		return KZGProof{}, Bytes32{}, err
+	}
+	return proof.Bytes(), y.Bytes(), nil
+}
+
+// ComputeBlobKZGProof returns the proof of the evaluation of the polynomial of
+// the blob at the Fiat-Shamir challenge derived from the blob and its
+// commitment.
+func (ctx *Context) ComputeBlobKZGProof(blob *Blob, commitment KZGCommitment) (KZGProof, error) {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	if _, err := bytesToPoint(commitment); err != nil {
+		return KZGProof{}, err
+	}
+	z := computeChallenge(blob, commitment)
+	proof, _, err := ctx.computeKZGProof(polynomial, z)
+	if err != nil {
+		return KZGProof{}, err
+	}
+	return proof.Bytes(), nil
+}
+
+// VerifyKZGProof verifies the proof that the polynomial committed to evaluates
+// to y at z. It returns [ErrVerifyOpeningProof] if the proof is invalid.
+func (ctx *Context) VerifyKZGProof(commitment KZGCommitment, z, y Bytes32, proof KZGProof) error {
+	c, err := bytesToPoint(commitment)
+	if err != nil {
+		return err
+	}
+	zElement, err := bytesToField(z)
+	if err != nil {
+		return err
+	}
+	yElement, err := bytesToField(y)
+	if err != nil {
+		return err
+	}
+	p, err := bytesToPoint(proof)
+	if err != nil {
+		return err
+	}
+	return kzg.Verify(&c, &kzg.OpeningProof{H: p, ClaimedValue: yElement}, zElement, ctx.vk)
+}
+
+// VerifyBlobKZGProof verifies the proof of the evaluation of the polynomial
+// of the blob at the Fiat-Shamir challenge. It returns
+// [ErrVerifyOpeningProof] if the proof is invalid.
+func (ctx *Context) VerifyBlobKZGProof(blob *Blob, commitment KZGCommitment, proof KZGProof) error {
+	polynomial, err := blobToPolynomial(blob)
+	if err != nil {
+		return err
+	}
+	c, err := bytesToPoint(commitment)
+	if err != nil {
+		return err
+	}
+	p, err := bytesToPoint(proof)
+	if err != nil {
+		return err
+	}
+	z := computeChallenge(blob, commitment)
+	y := ctx.evaluate(polynomial, z)
+	return kzg.Verify(&c, &kzg.OpeningProof{H: p, ClaimedValue: y}, z, ctx.vk)
+}
+
+// VerifyBlobKZGProofBatch verifies the proofs of many blobs at once, with a
+// random linear combination of the pairing equations whose coefficients are
+// derived with Fiat-Shamir as in the specs. It returns
+// [ErrVerifyOpeningProof] if one of the proofs is invalid.
+func (ctx *Context) VerifyBlobKZGProofBatch(blobs []Blob, commitments []KZGCommitment, proofs []KZGProof) error {
+	n := len(blobs)
+	if len(commitments) != n || len(proofs) != n {
+		return ErrInvalidInputSize
+	}
+	if n == 0 {
+		return nil
+	}
+	if n == 1 {
+		return ctx.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0])
+	}
+
+	cs := make([]bls12381.G1Affine, n)
+	ps := make([]bls12381.G1Affine, n)
+	zs := make([]fr.Element, n)
+	ys := make([]fr.Element, n)
+	for i := range blobs {
+		polynomial, err := blobToPolynomial(&blobs[i])
+		if err != nil {
+			return err
+		}
+		if cs[i], err = bytesToPoint(commitments[i]); err != nil {
+			return err
+		}
+		if ps[i], err = bytesToPoint(proofs[i]); err != nil {
+			return err
+		}
+		zs[i] = computeChallenge(&blobs[i], commitments[i])
+		ys[i] = ctx.evaluate(polynomial, zs[i])
+	}
+	return ctx.verifyKZGProofBatch(cs, zs, ys, ps, commitments, proofs)
+}
+
+// verifyKZGProofBatch checks
+//
+//	e(∑ᵢrⁱπᵢ, [τ]G₂) = e(∑ᵢrⁱ(Cᵢ - [yᵢ]G₁ + [zᵢ]πᵢ), G₂)
+//
+// where r is derived from the serialized inputs.
+func (ctx *Context) verifyKZGProofBatch(cs []bls12381.G1Affine, zs, ys []fr.Element, ps []bls12381.G1Affine, commitments []KZGCommitment, proofs []KZGProof) error {
+	n := len(cs)
+
+	// r = H(domain || 4096 || n || (Cᵢ || zᵢ || yᵢ || πᵢ)ᵢ)
+	h := sha256.New()
+	h.Write([]byte(randomChallengeBatchDomain))
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], FieldElementsPerBlob)
+	h.Write(buf[:])
+	binary.BigEndian.PutUint64(buf[:], uint64(n))
+	h.Write(buf[:])
+	for i := 0; i < n; i++ {
+		h.Write(commitments[i][:])
+		z := zs[i].Bytes()
+		h.Write(z[:])
+		y := ys[i].Bytes()
+		h.Write(y[:])
+		h.Write(proofs[i][:])
+	}
+	var r fr.Element
+	r.SetBytes(h.Sum(nil))
+
+	rPowers := make([]fr.Element, n)
+	rPowers[0].SetOne()
+	for i := 1; i < n; i++ {
+		rPowers[i].Mul(&rPowers[i-1], &r)
+	}
+
+	config := ecc.MultiExpConfig{}
+	var proofLincomb, left bls12381.G1Affine
+	if _, err := proofLincomb.MultiExp(ps, rPowers, config); err != nil {
+		return err
+	}
+
+	// ∑ᵢrⁱCᵢ - [∑ᵢrⁱyᵢ]G₁ + ∑ᵢrⁱzᵢπᵢ
+	points := make([]bls12381.G1Affine, 0, 2*n+1)
+	scalars := make([]fr.Element, 0, 2*n+1)
+	var sumY fr.Element
+	for i := 0; i < n; i++ {
+		var t fr.Element
+		t.Mul(&rPowers[i], &ys[i])
+		sumY.Add(&sumY, &t)
+		t.Mul(&rPowers[i], &zs[i])
+		points = append(points, cs[i], ps[i])
+		scalars = append(scalars, rPowers[i], t)
+	}
+	sumY.Neg(&sumY)
+	points = append(points, ctx.vk.G1)
+	scalars = append(scalars, sumY)
+	if _, err := left.MultiExp(points, scalars, config); err != nil {
+		return err
+	}
+
+	proofLincomb.Neg(&proofLincomb)
+	lines := ctx.vk.Lines // the Miller loop overwrites the lines
+	ok, err := bls12381.PairingCheckFixedQ(
+		[]bls12381.G1Affine{left, proofLincomb},
+		lines[:],
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrVerifyOpeningProof
+	}
+	return nil
+}
+
+// commit returns ∑ᵢpᵢ[ℓᵢ(τ)]G₁.
+func (ctx *Context) commit(polynomial []fr.Element) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	_, err := res.MultiExp(ctx.lagrange, polynomial, ecc.MultiExpConfig{})
+	return res, err
+}
+
+// computeKZGProof returns the commitment to the quotient (p(X) - p(z))/(X - z)
+// in Lagrange form, and p(z).
+func (ctx *Context) computeKZGProof(polynomial []fr.Element, z fr.Element) (bls12381.G1Affine, fr.Element, error) {
+	y := ctx.evaluate(polynomial, z)
+
+	// qᵢ = (pᵢ - y)/(ωᵢ - z), except at ωᵢ = z
+	quotient := make([]fr.Element, FieldElementsPerBlob)
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	inDomain := -1
+	for i := range ctx.roots {
+		denominators[i].Sub(&ctx.roots[i], &z)
+		if denominators[i].IsZero() {
+			inDomain = i
+		}
+	}
+	denominators = fr.BatchInvert(denominators)
+	for i := range polynomial {
+		if i == inDomain {
+			continue
+		}
+		quotient[i].Sub(&polynomial[i], &y).Mul(&quotient[i], &denominators[i])
+	}
+
+	// if z = ωₘ, the quotient at ωₘ is q(ωₘ) = p'(ωₘ) = ∑_{i≠m} (pᵢ - y)ωᵢ / (z(z - ωᵢ))
+	if inDomain >= 0 {
+		var zInv, t fr.Element
+		zInv.Inverse(&z)
+		for i := range polynomial {
+			if i == inDomain {
+				continue
+			}
+			// (pᵢ - y)/(ωᵢ - z) ⋅ (-ωᵢ/z)
+			t.Mul(&quotient[i], &ctx.roots[i]).Mul(&t, &zInv)
+			quotient[inDomain].Sub(&quotient[inDomain], &t)
+		}
+	}
+
+	proof, err := ctx.commit(quotient)
+	return proof, y, err
+}
+
+// evaluate returns p(z) from the evaluations of p on the roots of unity,
+// with the barycentric formula when z is outside the domain:
+//
+//	p(z) = (zⁿ - 1)/n ⋅ ∑ᵢ pᵢωᵢ/(z - ωᵢ)
+func (ctx *Context) evaluate(polynomial []fr.Element, z fr.Element) fr.Element {
+	denominators := make([]fr.Element, FieldElementsPerBlob)
+	for i := range ctx.roots {
+		if ctx.roots[i].Equal(&z) {
+			return polynomial[i]
+		}
+		denominators[i].Sub(&z, &ctx.roots[i])
+	}
+	denominators = fr.BatchInvert(denominators)
+
+	var res, t fr.Element
+	for i := range polynomial {
+		t.Mul(&polynomial[i], &ctx.roots[i]).Mul(&t, &denominators[i])
+		res.Add(&res, &t)
+	}
+
+	var zn, one, nInv fr.Element
+	zn.Exp(z, big.NewInt(FieldElementsPerBlob))
+	one.SetOne()
+	zn.Sub(&zn, &one)
+	nInv.SetUint64(FieldElementsPerBlob)
+	nInv.Inverse(&nInv)
+	res.Mul(&res, &zn).Mul(&res, &nInv)
+	return res
+}
+
+// computeChallenge returns the Fiat-Shamir challenge
+//
+//	H(domain || 4096 || blob || commitment) mod r
+func computeChallenge(blob *Blob, commitment KZGCommitment) fr.Element {
+	h := sha256.New()
+	h.Write([]byte(fiatShamirProtocolDomain))
+	var degree [16]byte
+	binary.BigEndian.PutUint64(degree[8:], FieldElementsPerBlob)
+	h.Write(degree[:])
+	h.Write(blob[:])
+	h.Write(commitment[:])
+	var res fr.Element
+	res.SetBytes(h.Sum(nil))
+	return res
+}
+
+// blobToPolynomial deserializes the 4096 canonical field elements of a blob.
+func blobToPolynomial(blob *Blob) ([]fr.Element, error) {
+	polynomial := make([]fr.Element, FieldElementsPerBlob)
+	for i := range polynomial {
+		if err := polynomial[i].SetBytesCanonical(blob[i*BytesPerFieldElement : (i+1)*BytesPerFieldElement]); err != nil {
+			return nil, ErrInvalidFieldElement
+		}
+	}
+	return polynomial, nil
+}
+
+// bytesToField deserializes a canonical field element.
+func bytesToField(b Bytes32) (fr.Element, error) {
+	var res fr.Element
+	if err := res.SetBytesCanonical(b[:]); err != nil {
+		return res, ErrInvalidFieldElement
+	}
+	return res, nil
+}
+
+// bytesToPoint deserializes a compressed G₁ point, checking it is in the
+// prime order subgroup. The point at infinity is valid.
+func bytesToPoint(b [bls12381.SizeOfG1AffineCompressed]byte) (bls12381.G1Affine, error) {
+	var res bls12381.G1Affine
+	_, err := res.SetBytes(b[:])
+	return res, err
+}
@@ -0,0 +1,438 @@
+// Copyright 2020-2025 Consensys Software Inc.
+// Licensed under the Apache License, Version 2.0. See the LICENSE file for details.
+
+package eip4844
+
+import (
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+// testDir contains the test vectors of the consensus specs
+// (tests/general/deneb/kzg), with the mainnet trusted setup in
+// trusted_setup.json.
+const testDir = "testdata"
+
+var (
+	testCtx     *Context
+	testCtxOnce sync.Once
+)
+
+// testContext returns a context built from an insecure setup of size 4096.
+func testContext(t *testing.T) *Context {
+	testCtxOnce.Do(func() {
+		srs, err := kzg.NewSRS(FieldElementsPerBlob, big.NewInt(42))
+		require.NoError(t, err)
+		ts := kzg.TrustedSetup{
+			G1Monomial: srs.Pk.G1,
+			G2Monomial: srs.Vk.G2[:],
+		}
+		ts.G1Lagrange, err = kzg.ToLagrangeG1(srs.Pk.G1)
+		require.NoError(t, err)
+		bitReverse(ts.G1Lagrange)
+		testCtx, err = NewContext(&ts)
+		require.NoError(t, err)
+	})
+	if testCtx == nil {
+		t.Fatal("could not build test context")
+	}
+	return testCtx
+}
+
+func bitReverse[T any](a []T) {
+	n := uint64(len(a))
+	nn := uint64(64 - len64(n-1))
+	for i := uint64(0); i < n; i++ {
+		irev := reverse64(i) >> nn
+		if irev > i {
+			a[i], a[irev] = a[irev], a[i]
+		}
+	}
+}
+
+func len64(x uint64) int {
+	n := 0
+	for ; x != 0; x >>= 1 {
+		n++
+	}
+	return n
+}
+
+func reverse64(x uint64) uint64 {
+	var r uint64
+	for i := 0; i < 64; i++ {
+		r = r<<1 | x&1
+		x >>= 1
+	}
+	return r
+}
+
+func randomBlob(t *testing.T) (*Blob, []fr.Element) {
+	var blob Blob
+	evals := make([]fr.Element, FieldElementsPerBlob)
+	for i := range evals {
+		_, err := evals[i].SetRandom()
+		require.NoError(t, err)
+		b := evals[i].Bytes()
+		copy(blob[i*BytesPerFieldElement:], b[:])
+	}
+	return &blob, evals
+}
+
+// toMonomial returns the coefficients of the polynomial of a blob.
+func toMonomial(evals []fr.Element) []fr.Element {
+	coeffs := make([]fr.Element, len(evals))
+	copy(coeffs, evals)
+	domain := fft.NewDomain(FieldElementsPerBlob)
+	domain.FFTInverse(coeffs, fft.DIT)
+	return coeffs
+}
+
+func evalMonomial(coeffs []fr.Element, z fr.Element) fr.Element {
+	var res fr.Element
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res.Mul(&res, &z).Add(&res, &coeffs[i])
+	}
+	return res
+}
+
+func TestBlobToKZGCommitment(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	blob, evals := randomBlob(t)
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+
+	srs, err := kzg.NewSRS(FieldElementsPerBlob, big.NewInt(42))
+	assert.NoError(err)
+	expected, err := kzg.Commit(toMonomial(evals), srs.Pk)
+	assert.NoError(err)
+	assert.Equal(expected.Bytes(), [BytesPerCommitment]byte(commitment))
+}
+
+func TestComputeKZGProof(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	blob, evals := randomBlob(t)
+	coeffs := toMonomial(evals)
+	commitment, err := ctx.BlobToKZGCommitment(blob)
+	assert.NoError(err)
+
+	var outside fr.Element
+	outside.SetRandom()
+	points := []fr.Element{outside, ctx.roots[0], ctx.roots[17]}
+	for _, z := range points {
+		zBytes := Bytes32(z.Bytes())
+		proof, y, err := ctx.ComputeKZGProof(blob, zBytes)
+		assert.NoError(err)
+		expected := evalMonomial(coeffs, z)
+		assert.Equal(Bytes32(expected.Bytes()), y)
+		assert.NoError(ctx.VerifyKZGProof(commitment, zBytes, y, proof))
+
+		// wrong evaluation
+		expected.Add(&expected, &expected)
+		assert.ErrorIs(ctx.VerifyKZGProof(commitment, zBytes, expected.Bytes(), proof), ErrVerifyOpeningProof)
+	}
+}
+
+func TestVerifyBlobKZGProofBatch(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	const n = 3
+	blobs := make([]Blob, n)
+	commitments := make([]KZGCommitment, n)
+	proofs := make([]KZGProof, n)
+	for i := 0; i < n; i++ {
+		blob, _ := randomBlob(t)
+		blobs[i] = *blob
+		var err error
+		commitments[i], err = ctx.BlobToKZGCommitment(blob)
+		assert.NoError(err)
+		proofs[i], err = ctx.ComputeBlobKZGProof(blob, commitments[i])
+		assert.NoError(err)
+		assert.NoError(ctx.VerifyBlobKZGProof(blob, commitments[i], proofs[i]))
+	}
+	assert.NoError(ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs))
+	assert.NoError(ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs), "the context must not be modified")
+	assert.NoError(ctx.VerifyBlobKZGProofBatch(nil, nil, nil))
+	assert.ErrorIs(ctx.VerifyBlobKZGProofBatch(blobs, commitments[:1], proofs), ErrInvalidInputSize)
+
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+	assert.ErrorIs(ctx.VerifyBlobKZGProof(&blobs[0], commitments[0], proofs[0]), ErrVerifyOpeningProof)
+	assert.ErrorIs(ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs), ErrVerifyOpeningProof)
+}
+
+func TestInvalidInputs(t *testing.T) {
+	assert := require.New(t)
+	ctx := testContext(t)
+
+	blob, _ := randomBlob(t)
+	modulus := fr.Modulus().Bytes()
+	copy(blob[BytesPerFieldElement:], modulus)
+	_, err := ctx.BlobToKZGCommitment(blob)
+	assert.ErrorIs(err, ErrInvalidFieldElement)
+
+	var z Bytes32
+	copy(z[:], modulus)
+	blob, _ = randomBlob(t)
+	_, _, err = ctx.ComputeKZGProof(blob, z)
+	assert.ErrorIs(err, ErrInvalidFieldElement)
+
+	// not a point on the curve
+	var commitment KZGCommitment
+	commitment[0] = 0x80
+	commitment[BytesPerCommitment-1] = 0x01
+	_, err = ctx.ComputeBlobKZGProof(blob, commitment)
+	assert.Error(err)
+
+	// the point at infinity is a valid commitment
+	var infinity KZGCommitment
+	infinity[0] = 0xc0
+	var zero Blob
+	commitment, err = ctx.BlobToKZGCommitment(&zero)
+	assert.NoError(err)
+	assert.Equal(infinity, commitment)
+	proof, err := ctx.ComputeBlobKZGProof(&zero, commitment)
+	assert.NoError(err)
+	assert.NoError(ctx.VerifyBlobKZGProof(&zero, commitment, proof))
+}
+
+// specContext returns a context built from the mainnet trusted setup.
+func specContext(t *testing.T) *Context {
+	f, err := os.Open(filepath.Join(testDir, "trusted_setup.json"))
+	require.NoError(t, err, "mainnet trusted setup not found")
+	defer f.Close()
+	var ts kzg.TrustedSetup
+	require.NoError(t, ts.ReadJSON(f))
+	ctx, err := NewContext(&ts)
+	require.NoError(t, err)
+	return ctx
+}
+
+// runSpecTests decodes the data.yaml files of a handler of the consensus
+// specs test vectors and calls run on each of them.
+func runSpecTests[T any](t *testing.T, handler string, run func(t *testing.T, ctx *Context, test *T)) {
+	tests, err := filepath.Glob(filepath.Join(testDir, handler, "*", "*", "data.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, tests, "no test vectors found for "+handler)
+	ctx := specContext(t)
+	for _, testPath := range tests {
+		t.Run(filepath.Base(filepath.Dir(testPath)), func(t *testing.T) {
+			testFile, err := os.Open(testPath)
+			require.NoError(t, err)
+			defer testFile.Close()
+			var test T
+			require.NoError(t, yaml.NewDecoder(testFile).Decode(&test))
+			run(t, ctx, &test)
+		})
+	}
+}
+
+// decodeHex decodes a 0x-prefixed hex string into dst, and returns false if
+// the input is malformed or has the wrong length.
+func decodeHex(s string, dst []byte) bool {
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil || len(b) != len(dst) {
+		return false
+	}
+	copy(dst, b)
+	return true
+}
+
+func TestSpecBlobToKZGCommitment(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+		}
+		Output *string `yaml:"output"`
+	}
+	runSpecTests(t, "blob_to_kzg_commitment", func(t *testing.T, ctx *Context, test *testCase) {
+		var blob Blob
+		ok := decodeHex(test.Input.Blob, blob[:])
+		var commitment KZGCommitment
+		var err error
+		if ok {
+			commitment, err = ctx.BlobToKZGCommitment(&blob)
+		}
+		if test.Output == nil {
+			require.True(t, !ok || err != nil)
+			return
+		}
+		require.True(t, ok)
+		require.NoError(t, err)
+		require.Equal(t, *test.Output, "0x"+hex.EncodeToString(commitment[:]))
+	})
+}
+
+func TestSpecComputeKZGProof(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Blob string `yaml:"blob"`
+			Z    string `yaml:"z"`
+		}
+		Output *[]string `yaml:"output"`
+	}
+	runSpecTests(t, "compute_kzg_proof", func(t *testing.T, ctx *Context, test *testCase) {
+		var blob Blob
+		ok := decodeHex(test.Input.Blob, blob[:])
+		var z Bytes32
+		okZ := decodeHex(test.Input.Z, z[:])
+		ok = ok && okZ
+		var proof KZGProof
+		var y Bytes32
+		var err error
+		if ok {
+			proof, y, err = ctx.ComputeKZGProof(&blob, z)
+		}
+		if test.Output == nil {
+			require.True(t, !ok || err != nil)
+			return
+		}
+		require.True(t, ok)
+		require.NoError(t, err)
+		require.Equal(t, (*test.Output)[0], "0x"+hex.EncodeToString(proof[:]))
+		require.Equal(t, (*test.Output)[1], "0x"+hex.EncodeToString(y[:]))
+	})
+}
+
+func TestSpecComputeBlobKZGProof(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+		}
+		Output *string `yaml:"output"`
+	}
+	runSpecTests(t, "compute_blob_kzg_proof", func(t *testing.T, ctx *Context, test *testCase) {
+		var blob Blob
+		ok := decodeHex(test.Input.Blob, blob[:])
+		var commitment KZGCommitment
+		okC := decodeHex(test.Input.Commitment, commitment[:])
+		ok = ok && okC
+		var proof KZGProof
+		var err error
+		if ok {
+			proof, err = ctx.ComputeBlobKZGProof(&blob, commitment)
+		}
+		if test.Output == nil {
+			require.True(t, !ok || err != nil)
+			return
+		}
+		require.True(t, ok)
+		require.NoError(t, err)
+		require.Equal(t, *test.Output, "0x"+hex.EncodeToString(proof[:]))
+	})
+}
+
+func TestSpecVerifyKZGProof(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Commitment string `yaml:"commitment"`
+			Z          string `yaml:"z"`
+			Y          string `yaml:"y"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	runSpecTests(t, "verify_kzg_proof", func(t *testing.T, ctx *Context, test *testCase) {
+		var commitment KZGCommitment
+		ok1 := decodeHex(test.Input.Commitment, commitment[:])
+		var z Bytes32
+		ok2 := decodeHex(test.Input.Z, z[:])
+		var y Bytes32
+		ok3 := decodeHex(test.Input.Y, y[:])
+		var proof KZGProof
+		ok4 := decodeHex(test.Input.Proof, proof[:])
+		ok := ok1 && ok2 && ok3 && ok4
+		var err error
+		if ok {
+			err = ctx.VerifyKZGProof(commitment, z, y, proof)
+		}
+		checkVerifyOutput(t, test.Output, ok, err)
+	})
+}
+
+func TestSpecVerifyBlobKZGProof(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Blob       string `yaml:"blob"`
+			Commitment string `yaml:"commitment"`
+			Proof      string `yaml:"proof"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	runSpecTests(t, "verify_blob_kzg_proof", func(t *testing.T, ctx *Context, test *testCase) {
+		var blob Blob
+		ok1 := decodeHex(test.Input.Blob, blob[:])
+		var commitment KZGCommitment
+		ok2 := decodeHex(test.Input.Commitment, commitment[:])
+		var proof KZGProof
+		ok3 := decodeHex(test.Input.Proof, proof[:])
+		ok := ok1 && ok2 && ok3
+		var err error
+		if ok {
+			err = ctx.VerifyBlobKZGProof(&blob, commitment, proof)
+		}
+		checkVerifyOutput(t, test.Output, ok, err)
+	})
+}
+
+func TestSpecVerifyBlobKZGProofBatch(t *testing.T) {
+	type testCase struct {
+		Input struct {
+			Blobs       []string `yaml:"blobs"`
+			Commitments []string `yaml:"commitments"`
+			Proofs      []string `yaml:"proofs"`
+		}
+		Output *bool `yaml:"output"`
+	}
+	runSpecTests(t, "verify_blob_kzg_proof_batch", func(t *testing.T, ctx *Context, test *testCase) {
+		ok := true
+		blobs := make([]Blob, len(test.Input.Blobs))
+		for i := range blobs {
+			ok = decodeHex(test.Input.Blobs[i], blobs[i][:]) && ok
+		}
+		commitments := make([]KZGCommitment, len(test.Input.Commitments))
+		for i := range commitments {
+			ok = decodeHex(test.Input.Commitments[i], commitments[i][:]) && ok
+		}
+		proofs := make([]KZGProof, len(test.Input.Proofs))
+		for i := range proofs {
+			ok = decodeHex(test.Input.Proofs[i], proofs[i][:]) && ok
+		}
+		var err error
+		if ok {
+			err = ctx.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+		}
+		checkVerifyOutput(t, test.Output, ok, err)
+	})
+}
+
+// checkVerifyOutput checks the result of a verification against the expected
+// output: null for invalid inputs, false for invalid proofs.
+func checkVerifyOutput(t *testing.T, output *bool, ok bool, err error) {
+	switch {
+	case output == nil:
+		require.True(t, !ok || (err != nil && err != ErrVerifyOpeningProof))
+	case *output:
+		require.True(t, ok)
+		require.NoError(t, err)
+	default:
+		require.True(t, ok)
+		require.ErrorIs(t, err, ErrVerifyOpeningProof)
+	}
+}